@@ -1,36 +1,224 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"ai-storage-orchestrator/pkg/apis"
 	"ai-storage-orchestrator/pkg/controller"
 	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/metrics"
+	"ai-storage-orchestrator/pkg/sink"
+	"ai-storage-orchestrator/pkg/types"
+	"ai-storage-orchestrator/pkg/version"
 )
 
 var (
 	port       = flag.String("port", "8080", "HTTP server port")
 	kubeconfig = flag.String("kubeconfig", "", "Path to kubeconfig file (leave empty for in-cluster config)")
+
+	// Metrics source
+	metricsProviderName = flag.String("metrics-provider", metrics.ProviderMetricsServer, "Source of pod/node resource usage: metrics-server or prometheus")
+	prometheusURL       = flag.String("prometheus-url", "", "Base URL of the Prometheus server used by the prometheus metrics provider")
+
+	// Migration record export
+	postgresDSN = flag.String("postgres-dsn", "", "PostgreSQL DSN to export finished migration records to (leave empty to disable)")
+
+	// HTTP server tuning
+	readTimeout         = flag.Duration("read-timeout", 15*time.Second, "Maximum duration for reading an entire request, including the body")
+	readHeaderTimeout   = flag.Duration("read-header-timeout", 5*time.Second, "Maximum duration for reading request headers")
+	writeTimeout        = flag.Duration("write-timeout", 30*time.Second, "Maximum duration before timing out writes of a response (streaming endpoints clear it)")
+	idleTimeout         = flag.Duration("idle-timeout", 120*time.Second, "Maximum time to wait for the next request on a keep-alive connection")
+	maxHeaderBytes      = flag.Int("max-header-bytes", 1<<20, "Maximum size of request headers in bytes")
+	responseEnvelope    = flag.Bool("response-envelope", false, "Wrap JSON responses in a {data, error, meta} envelope by default (clients can override with X-Response-Envelope)")
+	enableDebug         = flag.Bool("enable-debug-endpoints", false, "Serve /debug/state and /debug/pprof (exposes controller internals; keep off in untrusted networks)")
+	apiKeysFile         = flag.String("api-keys-file", "", "Path to a JSON file with API keys and the namespaces each may access (leave empty to disable authentication)")
+	eventDriven         = flag.Bool("event-driven", false, "Wait on pods created by the orchestrator through one shared informer instead of a watch or polling per wait")
+	shutdownTimeout     = flag.Duration("shutdown-timeout", 30*time.Second, "Maximum time to wait for in-flight migrations during shutdown")
+	httpShutdownTimeout = flag.Duration("http-shutdown-timeout", 10*time.Second, "Maximum time to wait for open HTTP requests during shutdown, once in-flight migrations are done or timed out")
+
+	// Migration tuning
+	checkpointSize            = flag.String("checkpoint-size", controller.DefaultMigrationConfig().CheckpointSize, "Size of checkpoint PVCs as a Kubernetes quantity (e.g. 1Gi) when the pod's memory usage is unknown")
+	checkpointSizeMargin      = flag.Float64("checkpoint-size-margin", controller.DefaultMigrationConfig().CheckpointSizeMargin, "Percentage added to the pod's memory usage when sizing checkpoint PVCs")
+	checkpointMinSize         = flag.String("checkpoint-min-size", controller.DefaultMigrationConfig().CheckpointMinSize, "Smallest checkpoint PVC sized from memory usage")
+	checkpointMaxSize         = flag.String("checkpoint-max-size", controller.DefaultMigrationConfig().CheckpointMaxSize, "Largest checkpoint PVC sized from memory usage")
+	checkpointAccessMode      = flag.String("checkpoint-access-mode", controller.DefaultMigrationConfig().CheckpointAccessMode, "Access mode of checkpoint PVCs (ReadWriteOnce, ReadWriteMany, ...); requests may override it")
+	podReadyTimeout           = flag.Duration("pod-ready-timeout", controller.DefaultMigrationConfig().PodReadyTimeout, "Maximum time to wait for the optimized pod to become Ready")
+	podReadyPoll              = flag.Duration("pod-ready-poll-interval", controller.DefaultMigrationConfig().PodReadyPollInterval, "How often to poll the optimized pod when its readiness cannot be watched")
+	postStartAllowance        = flag.Duration("post-start-hook-allowance", controller.DefaultMigrationConfig().PostStartHookAllowance, "Extra time to wait for the optimized pod to become Ready when a migrated container has a PostStart hook")
+	metricsStabilizationDelay = flag.Duration("metrics-stabilization-delay", controller.DefaultMigrationConfig().MetricsStabilizationDelay, "Time to let the new pod settle before collecting post-migration metrics")
+	maxConcurrentMigrations   = flag.Int("max-concurrent-migrations", controller.DefaultMigrationConfig().MaxConcurrentMigrations, "Maximum number of migrations executing at the same time")
+	maxConcurrentPerNamespace = flag.Int("max-concurrent-migrations-per-namespace", controller.DefaultMigrationConfig().MaxConcurrentPerNamespace, "Maximum number of migrations of one namespace's pods executing at the same time (0 for no cap)")
+	namespaceLimits           = flag.String("namespace-concurrency-limits", "", "Per-namespace overrides of --max-concurrent-migrations-per-namespace as namespace=limit pairs, e.g. team-a=3,team-b=1")
+	metricsWorkers            = flag.Int("metrics-workers", controller.DefaultMigrationConfig().MetricsWorkers, "Number of background workers collecting post-migration metrics")
+	presetsFile               = flag.String("presets-file", "", "Path to a JSON file with migration presets to load at startup")
+	classificationFile        = flag.String("classification-policy-file", "", "Path to a JSON file with the policy deciding which container states are migrated")
+	webhooksFile              = flag.String("webhooks-file", "", "Path to a JSON file with webhook subscribers notified of every migration result")
+	checkpointReconcile       = flag.Duration("checkpoint-reconcile-interval", controller.DefaultMigrationConfig().CheckpointReconcileInterval, "How often checkpoint PVCs whose orchestrator/checkpoint-retention expired are deleted")
+	pdbRetryInterval          = flag.Duration("pdb-retry-interval", controller.DefaultMigrationConfig().PDBRetryInterval, "How often to re-check a PodDisruptionBudget blocking deletion of the original pod")
+	safeMode                  = flag.Bool("safe-mode", controller.DefaultMigrationConfig().SafeMode, "Never delete original pods; migrations end as completed_safe with both pods running (use --safe-mode=false for full mode)")
+	restartCountPolicy        = flag.String("restart-count-policy", controller.DefaultMigrationConfig().RestartCountPolicy, "Handling of containers restarted more than --restart-count-threshold times: ignore, skip (don't migrate them) or prioritize (migrate their pods first)")
+	restartCountThreshold     = flag.Int("restart-count-threshold", int(controller.DefaultMigrationConfig().RestartCountThreshold), "Restart count above which a container counts as crash-looping")
+	stripLabels               = flag.String("strip-labels", strings.Join(controller.DefaultMigrationConfig().StrippedLabels, ","), "Comma-separated label keys removed from the optimized pod so the original pod's controller does not adopt or delete it")
+	nodePressureThreshold     = flag.Float64("node-pressure-threshold", controller.DefaultMigrationConfig().NodePressureThreshold, "Target node CPU/memory utilization percentage after a migration above which a warning is logged")
+	observeInterval           = flag.Duration("observe-interval", controller.DefaultMigrationConfig().ObserveInterval, "How often to scan nodes for pressure and record recommended migrations without starting them (0 disables the observer)")
+	observeCPUThreshold       = flag.Float64("observe-cpu-threshold", controller.DefaultMigrationConfig().ObserveCPUThreshold, "Node CPU utilization percentage at which the observer considers a node under pressure")
+	observeMemoryThreshold    = flag.Float64("observe-memory-threshold", controller.DefaultMigrationConfig().ObserveMemoryThreshold, "Node memory utilization percentage at which the observer considers a node under pressure")
+	cleanupFinalizers         = flag.Bool("cleanup-finalizers", controller.DefaultMigrationConfig().CleanupFinalizers, "Tag objects created by migrations with a finalizer so those of interrupted migrations are cleaned up after a restart")
+	maxNodeReselections       = flag.Int("max-node-reselections", controller.DefaultMigrationConfig().MaxNodeReselections, "Times a migration with target_node_selector moves to another node when its target stops being Ready while the new pod is created (0 to fail instead)")
+	backpressureThreshold     = flag.Int("backpressure-threshold", controller.DefaultMigrationConfig().BackpressureThreshold, "429 responses from the API server per --backpressure-interval that halve the concurrent migrations (0 disables adaptive backpressure)")
+	backpressureInterval      = flag.Duration("backpressure-interval", controller.DefaultMigrationConfig().BackpressureInterval, "How often API server throttling is checked to adapt the concurrent migrations")
+	maxTrackedMigrations      = flag.Int("max-tracked-migrations", controller.DefaultMigrationConfig().MaxTrackedMigrations, "Most migrations kept in memory; beyond it the longest-finished ones are forgotten, running ones never (0 for no cap)")
+	drainCoalesceWindow       = flag.Duration("drain-coalesce-window", controller.DefaultMigrationConfig().DrainCoalesceWindow, "Drain requests for a node within this long of a running drain of it join that drain instead of starting another (0 disables)")
+	logSampleRate             = flag.Int("log-sample-rate", controller.DefaultMigrationConfig().LogSampleRate, "Write only the first and then every Nth migration log line of the same kind per --log-sample-window, noting how many were suppressed (0 or 1 writes every line)")
+	logSampleWindow           = flag.Duration("log-sample-window", controller.DefaultMigrationConfig().LogSampleWindow, "Window after which log sampling starts over with the next line of each kind")
+	podNameTemplate           = flag.String("pod-name-template", controller.DefaultMigrationConfig().PodNameTemplate, "Template naming new pods, overridable per request with new_pod_name_template; placeholders: {original}, {namespace}, {sourcenode}, {targetnode}, {shortid}, {timestamp}")
+	partialReadinessPolicy    = flag.String("partial-readiness-policy", controller.DefaultMigrationConfig().PartialReadinessPolicy, "When only some containers of the new pod become ready: rollback, or keep the pod and end the migration partially_degraded")
+	specDriftPolicy           = flag.String("spec-drift-policy", controller.DefaultMigrationConfig().SpecDriftPolicy, "When the original pod changed after its migration was planned: abort, or replan if the new pod has not been created yet")
+	checkpointIntegrity       = flag.String("checkpoint-integrity-policy", controller.DefaultMigrationConfig().CheckpointIntegrityPolicy, "When a checkpoint no longer matches its checksum before the new pod restores it: fail, or cold_restart without it")
+	resultsDir                = flag.String("results-dir", "", "Directory to write the rationale of each finished migration to as versioned JSON for research analysis (leave empty to disable)")
+	policyEndpoint            = flag.String("policy-endpoint", "", "URL of an external policy service (OPA data API style) that must allow each migration before it starts (leave empty to disable)")
+	policyFailureMode         = flag.String("policy-failure-mode", controller.DefaultMigrationConfig().PolicyFailureMode, "When the policy service gives no decision: closed refuses the migration, open lets it run")
+	snapshotDir               = flag.String("snapshot-dir", "", "Directory, e.g. a mounted volume, to save the original pod manifest of migrations with snapshot_original to (leave empty to disable)")
+
+	// One-shot mode
+	migrateFile = flag.String("migrate", "", "Run the migration request in this JSON file (- for stdin) once, printing its progress, and exit instead of serving the API")
+	quiet       = flag.Bool("quiet", false, "With --migrate, print only the finished migration as JSON")
 )
 
 func main() {
 	flag.Parse()
 
 	log.Println("Starting AI Storage Orchestrator...")
+	log.Printf("Build info: %s", version.Get())
 	// Initialize Kubernetes client
 	k8sClient, err := k8s.NewClient(*kubeconfig)
 	if err != nil {
 		log.Fatalf("Failed to create Kubernetes client: %v", err)
 	}
 	log.Println("Kubernetes client initialized successfully")
+	if connection := k8sClient.Connection(); connection.InCluster {
+		log.Printf("Operating against API server %s (in-cluster, auth %s)", connection.Server, connection.AuthMethod)
+	} else {
+		log.Printf("Operating against API server %s (kubeconfig %s, context %q, cluster %q, user %q, auth %s)",
+			connection.Server, connection.Kubeconfig, connection.Context, connection.Cluster, connection.User, connection.AuthMethod)
+	}
+
+	// Track the pods the orchestrator creates through one shared informer
+	informerStop := make(chan struct{})
+	if *eventDriven {
+		if err := k8sClient.StartPodInformer(informerStop); err != nil {
+			log.Fatalf("Failed to start pod informer: %v", err)
+		}
+		log.Println("Waiting on migrated pods through a shared informer")
+	}
+
+	// Initialize metrics provider
+	metricsProvider, err := metrics.NewProvider(*metricsProviderName, k8sClient, *prometheusURL)
+	if err != nil {
+		log.Fatalf("Failed to create metrics provider: %v", err)
+	}
+	log.Printf("Metrics provider initialized: %s", *metricsProviderName)
+
+	// Initialize migration record sink
+	migrationSink, err := sink.NewSink(*postgresDSN)
+	if err != nil {
+		log.Fatalf("Failed to create migration sink: %v", err)
+	}
+	defer migrationSink.Close()
+	if *postgresDSN != "" {
+		log.Println("Exporting migration records to PostgreSQL")
+	}
 
 	// Initialize migration controller
-	migrationController := controller.NewMigrationController(k8sClient)
+	migrationConfig := controller.DefaultMigrationConfig()
+	migrationConfig.CheckpointSize = *checkpointSize
+	migrationConfig.CheckpointSizeMargin = *checkpointSizeMargin
+	migrationConfig.CheckpointMinSize = *checkpointMinSize
+	migrationConfig.CheckpointMaxSize = *checkpointMaxSize
+	migrationConfig.CheckpointAccessMode = *checkpointAccessMode
+	migrationConfig.PodReadyTimeout = *podReadyTimeout
+	migrationConfig.PodReadyPollInterval = *podReadyPoll
+	migrationConfig.PostStartHookAllowance = *postStartAllowance
+	migrationConfig.MetricsStabilizationDelay = *metricsStabilizationDelay
+	migrationConfig.MaxConcurrentMigrations = *maxConcurrentMigrations
+	migrationConfig.MaxConcurrentPerNamespace = *maxConcurrentPerNamespace
+	migrationConfig.NamespaceConcurrencyLimits, err = controller.ParseNamespaceLimits(*namespaceLimits)
+	if err != nil {
+		log.Fatalf("Invalid --namespace-concurrency-limits: %v", err)
+	}
+	migrationConfig.MetricsWorkers = *metricsWorkers
+	migrationConfig.PDBRetryInterval = *pdbRetryInterval
+	migrationConfig.CheckpointReconcileInterval = *checkpointReconcile
+	migrationConfig.NodePressureThreshold = *nodePressureThreshold
+	migrationConfig.SafeMode = *safeMode
+	migrationConfig.RestartCountPolicy = *restartCountPolicy
+	migrationConfig.RestartCountThreshold = int32(*restartCountThreshold)
+	migrationConfig.StrippedLabels = controller.ParseLabelKeys(*stripLabels)
+	migrationConfig.ObserveInterval = *observeInterval
+	migrationConfig.ObserveCPUThreshold = *observeCPUThreshold
+	migrationConfig.ObserveMemoryThreshold = *observeMemoryThreshold
+	migrationConfig.CleanupFinalizers = *cleanupFinalizers
+	migrationConfig.MaxNodeReselections = *maxNodeReselections
+	migrationConfig.BackpressureThreshold = *backpressureThreshold
+	migrationConfig.BackpressureInterval = *backpressureInterval
+	migrationConfig.ResultsDir = *resultsDir
+	migrationConfig.SnapshotDir = *snapshotDir
+	migrationConfig.PolicyEndpoint = *policyEndpoint
+	migrationConfig.PolicyFailureMode = *policyFailureMode
+	migrationConfig.MaxTrackedMigrations = *maxTrackedMigrations
+	migrationConfig.DrainCoalesceWindow = *drainCoalesceWindow
+	migrationConfig.LogSampleRate = *logSampleRate
+	migrationConfig.LogSampleWindow = *logSampleWindow
+	migrationConfig.PodNameTemplate = *podNameTemplate
+	migrationConfig.PartialReadinessPolicy = *partialReadinessPolicy
+	migrationConfig.SpecDriftPolicy = *specDriftPolicy
+	migrationConfig.CheckpointIntegrityPolicy = *checkpointIntegrity
+	migrationConfig.MetricsProvider = *metricsProviderName
+
+	migrationController, err := controller.NewMigrationController(k8sClient, metricsProvider, migrationSink, migrationConfig)
+	if err != nil {
+		log.Fatalf("Failed to create migration controller: %v", err)
+	}
 	log.Println("Migration controller initialized")
+	if *observeInterval > 0 {
+		log.Printf("Pressure observer recording recommendations every %s", *observeInterval)
+	}
+	if *safeMode {
+		log.Println("Safe mode is enabled: original pods are never deleted (start with --safe-mode=false for full mode)")
+	}
+
+	if *presetsFile != "" {
+		if err := migrationController.LoadPresetsFile(*presetsFile); err != nil {
+			log.Fatalf("Failed to load migration presets: %v", err)
+		}
+		log.Printf("Loaded %d migration presets from %s", len(migrationController.ListPresets()), *presetsFile)
+	}
+
+	if *webhooksFile != "" {
+		if err := migrationController.LoadWebhooksFile(*webhooksFile); err != nil {
+			log.Fatalf("Failed to load webhook subscribers: %v", err)
+		}
+		log.Printf("Loaded %d webhook subscribers from %s", len(migrationController.WebhookSubscribers()), *webhooksFile)
+	}
+
+	if *classificationFile != "" {
+		if err := migrationController.LoadClassificationPolicyFile(*classificationFile); err != nil {
+			log.Fatalf("Failed to load classification policy: %v", err)
+		}
+		log.Printf("Loaded container classification policy from %s", *classificationFile)
+	}
 
 	// Initialize autoscaling controller
 	autoscalingController := controller.NewAutoscalingController(k8sClient)
@@ -38,20 +226,86 @@ func main() {
 
 	// Initialize HTTP API handler
 	apiHandler := apis.NewHandler(migrationController, autoscalingController)
+	if *responseEnvelope {
+		apiHandler.EnableResponseEnvelope()
+	}
+
+	// One-shot mode runs a single migration without serving the API
+	if *migrateFile != "" {
+		code := runOneShot(migrationController, apiHandler, *migrateFile, *quiet)
+		close(informerStop)
+		os.Exit(code)
+	}
+	if *enableDebug {
+		apiHandler.EnableDebugEndpoints()
+		log.Println("Debug endpoints enabled: /debug/state and /debug/pprof")
+	}
+	if *apiKeysFile != "" {
+		keys, err := apis.LoadAPIKeysFile(*apiKeysFile)
+		if err != nil {
+			log.Fatalf("Failed to load API keys: %v", err)
+		}
+		if err := apiHandler.EnableAPIKeys(keys); err != nil {
+			log.Fatalf("Invalid API keys: %v", err)
+		}
+		log.Printf("API key authentication enabled with %d keys from %s", len(keys), *apiKeysFile)
+	}
 	router := apiHandler.SetupRoutes()
 
-	log.Printf("HTTP server starting on port %s", *port)
+	server := &http.Server{
+		Addr:              ":" + *port,
+		Handler:           router,
+		ReadTimeout:       *readTimeout,
+		ReadHeaderTimeout: *readHeaderTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+		MaxHeaderBytes:    *maxHeaderBytes,
+	}
+
+	log.Printf("HTTP server starting on port %s (read timeout %s, write timeout %s, idle timeout %s)",
+		*port, *readTimeout, *writeTimeout, *idleTimeout)
 	log.Println("Available endpoints:")
 	log.Println("  POST /api/v1/migrations - Start new pod migration")
+	log.Println("  POST /api/v1/migrations/status - Get status of multiple migrations")
+	log.Println("  POST /api/v1/migrations/validate - Check all preconditions of a migration without starting it")
+	log.Println("  POST /api/v1/migrations/validate-request - Statically validate a migration request and show its defaults")
+	log.Println("  POST /api/v1/migrations/plan - Dry run: return the plan a migration would execute")
 	log.Println("  GET  /api/v1/migrations/:id - Get migration details")
 	log.Println("  GET  /api/v1/migrations/:id/status - Get migration status")
+	log.Println("  GET  /api/v1/migrations/:id/logs - Get migration logs (?follow=true to stream)")
+	log.Println("  GET  /api/v1/migrations/:id/wait - Wait for a migration to finish (?cancel_on_disconnect=true ties it to the client)")
 	log.Println("  GET  /api/v1/metrics - Get migration metrics")
+	log.Println("  GET  /api/v1/metrics/summary - Aggregate migrations finished between ?from= and ?to=")
+	log.Println("  POST /api/v1/batches - Start batch migration with a max-unavailable guard")
+	log.Println("  GET  /api/v1/batches/:id - Get batch migration status")
+	log.Println("  GET  /api/v1/pods/:namespace/:name/migrations - Migration history of a pod")
+	log.Println("  POST /api/v1/pods/:namespace/:name/analyze - Preview the optimized pod's resources with optional overrides")
+	log.Println("  POST /api/v1/nodes/:name/cancel-migrations - Cancel pending/running migrations to a node")
+	log.Println("  POST /api/v1/nodes/:name/drain - Migrate all pods off a node as a paced batch")
+	log.Println("  GET  /api/v1/recommendations - Migrations the pressure observer would start")
+	log.Println("  POST /api/v1/recommendations/:id/promote - Start the migration of a recommendation")
+	log.Println("  POST /api/v1/presets - Create or replace migration preset")
+	log.Println("  GET  /api/v1/presets - List migration presets")
+	log.Println("  GET  /api/v1/presets/:name - Get migration preset")
+	log.Println("  DELETE /api/v1/presets/:name - Delete migration preset")
+	log.Println("  GET  /api/v1/config/webhooks - List webhook subscribers")
+	log.Println("  PUT  /api/v1/config/webhooks - Replace webhook subscribers")
+	log.Println("  GET  /api/v1/config/classification - Show container classification policy")
+	log.Println("  PUT  /api/v1/config/classification - Replace container classification policy")
 	log.Println("  POST /api/v1/autoscaling - Create autoscaler")
 	log.Println("  GET  /api/v1/autoscaling/:id - Get autoscaler details")
 	log.Println("  DELETE /api/v1/autoscaling/:id - Delete autoscaler")
 	log.Println("  GET  /api/v1/autoscaling - List all autoscalers")
 	log.Println("  GET  /api/v1/autoscaling/metrics - Get autoscaling metrics")
+	log.Println("  GET  /api/v1/version - Get orchestrator version and build info")
+	log.Println("  GET  /api/v1/status - Lifecycle state and in-flight work")
 	log.Println("  GET  /health - Health check")
+	log.Println("  GET  /ready - Readiness check (503 while shutting down)")
+	log.Println("  GET  /metrics - Prometheus metrics")
+	if *enableDebug {
+		log.Println("  GET  /debug/state - Dump controller internals")
+		log.Println("  GET  /debug/pprof/ - Go profiling")
+	}
 
 	// Setup graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -59,7 +313,7 @@ func main() {
 
 	// Start server in goroutine
 	go func() {
-		if err := router.Run(":" + *port); err != nil {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start HTTP server: %v", err)
 		}
 	}()
@@ -69,5 +323,202 @@ func main() {
 	// Wait for interrupt signal
 	<-quit
 	log.Println("Shutting down AI Storage Orchestrator...")
+
+	// Stop accepting new work and let in-flight migrations finish so readiness
+	// probes and /api/v1/status reflect the drain while it happens
+	migrationController.BeginDrain()
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancelDrain()
+	if status := migrationController.Status(); status.InFlightMigrations > 0 || status.InFlightBatches > 0 {
+		log.Printf("Waiting for %d migrations and %d batches to finish", status.InFlightMigrations, status.InFlightBatches)
+	}
+	if err := migrationController.WaitForInFlight(drainCtx); err != nil {
+		status := migrationController.Status()
+		log.Printf("Warning: %d migrations and %d batches still running at shutdown timeout", status.InFlightMigrations, status.InFlightBatches)
+	}
+
+	// Open requests get their own deadline: a drain that used up the shutdown timeout
+	// must not cut off the requests still being answered, such as status polls
+	httpCtx, cancelHTTP := context.WithTimeout(context.Background(), *httpShutdownTimeout)
+	defer cancelHTTP()
+	if err := server.Shutdown(httpCtx); err != nil {
+		log.Printf("Warning: HTTP server shutdown did not complete cleanly: %v", err)
+	}
+	close(informerStop)
+	migrationController.MarkStopped()
 	log.Println("Graceful shutdown completed")
-}
\ No newline at end of file
+}
+
+// metricsPollInterval is how often the one-shot mode checks for post-migration metrics
+const metricsPollInterval = time.Second
+
+// runOneShot runs the migration described by the JSON file at path ("-" for stdin)
+// and returns the process exit code: 0 when it completed, 1 otherwise. Progress
+// goes to stdout as the migration log advances, followed by a summary table; with
+// quiet only the final migration is printed, as JSON.
+func runOneShot(mc *controller.MigrationController, h *apis.Handler, path string, quiet bool) int {
+	var body []byte
+	var err error
+	if path == "-" {
+		body, err = io.ReadAll(os.Stdin)
+	} else {
+		body, err = os.ReadFile(path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read migration request: %v\n", err)
+		return 1
+	}
+
+	req, err := h.ParseMigrationRequest(body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid migration request: %v\n", err)
+		return 1
+	}
+	started, err := mc.StartMigration(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start migration: %v\n", err)
+		return 1
+	}
+
+	// An interrupt stops the waiting below; a second one kills the process as usual
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	id := started.MigrationID
+	if quiet {
+		if _, _, err := mc.WaitForMigration(ctx, id); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to wait for migration: %v\n", err)
+			return 1
+		}
+	} else {
+		fmt.Printf("Migrating %s/%s from %s (migration %s)\n", req.PodNamespace, req.PodName, req.SourceNode, id)
+		if err := followMigration(ctx, mc, id); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to follow migration: %v\n", err)
+			return 1
+		}
+	}
+
+	// Savings are only known once the post-migration metrics are in
+	response, err := waitForMetrics(ctx, mc, id, quiet)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get migration: %v\n", err)
+		return 1
+	}
+
+	if quiet {
+		out, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode migration: %v\n", err)
+			return 1
+		}
+		fmt.Println(string(out))
+	} else {
+		printSummary(response)
+	}
+
+	if response.Status.Succeeded() {
+		return 0
+	}
+	return 1
+}
+
+// followMigration prints the migration's log entries, and a line whenever it enters
+// a new step, from the same log stream the SSE endpoint serves, until it finishes
+func followMigration(ctx context.Context, mc *controller.MigrationController, id string) error {
+	var since int64
+	var step string
+	for {
+		entries, updated, finished, err := mc.GetMigrationLogs(id, since)
+		if err != nil {
+			return err
+		}
+
+		if status, err := mc.GetMigrationStatus(id); err == nil && status.Details != nil && status.Details.CurrentStep != step {
+			step = status.Details.CurrentStep
+			if step != "" {
+				fmt.Printf("==> %s\n", step)
+			}
+		}
+		for _, entry := range entries {
+			fmt.Printf("    %s  %s\n", entry.Timestamp.Format("15:04:05"), entry.Message)
+			since = entry.Sequence
+		}
+
+		if finished {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+// waitForMetrics returns the finished migration once its post-migration metrics are
+// collected, or right away when none are outstanding
+func waitForMetrics(ctx context.Context, mc *controller.MigrationController, id string, quiet bool) (*types.MigrationResponse, error) {
+	announced := quiet
+	for {
+		response, err := mc.GetMigrationStatus(id)
+		if err != nil {
+			return nil, err
+		}
+		if response.Details == nil || !response.Details.MetricsPending {
+			return response, nil
+		}
+		if !announced {
+			fmt.Println("==> waiting for post-migration metrics")
+			announced = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(metricsPollInterval):
+		}
+	}
+}
+
+// printSummary prints the outcome of a finished migration as a table, with the failed
+// step and remediation hint of a failure
+func printSummary(response *types.MigrationResponse) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Status\t%s\n", response.Status)
+	fmt.Fprintf(w, "Message\t%s\n", response.Message)
+
+	summary := response.Summary
+	if summary == nil {
+		w.Flush()
+		return
+	}
+	fmt.Fprintf(w, "Duration\t%s\n", summary.Duration.Round(time.Millisecond))
+	if summary.NewPodName != "" {
+		fmt.Fprintf(w, "New pod\t%s/%s on %s\n", summary.PodNamespace, summary.NewPodName, summary.TargetNode)
+	}
+	fmt.Fprintf(w, "Containers migrated\t%d of %d\n", summary.ContainersMigrated, summary.ContainersTotal)
+	if len(summary.DegradedContainers) > 0 {
+		fmt.Fprintf(w, "Not ready\t%s\n", strings.Join(summary.DegradedContainers, ", "))
+	}
+	if summary.CPUSavings != nil {
+		fmt.Fprintf(w, "CPU savings\t%.1f%%\n", *summary.CPUSavings)
+	}
+	if summary.MemorySavings != nil {
+		fmt.Fprintf(w, "Memory savings\t%.1f%%\n", *summary.MemorySavings)
+	}
+	if summary.GPUSavings != nil {
+		fmt.Fprintf(w, "GPU savings\t%.1f%%\n", *summary.GPUSavings)
+	}
+	if failure := summary.Failure; failure != nil {
+		fmt.Fprintf(w, "Failed step\t%s\n", failure.Step)
+		fmt.Fprintf(w, "Error\t%s\n", failure.Error)
+		if failure.Hint != "" {
+			fmt.Fprintf(w, "Hint\t%s\n", failure.Hint)
+		}
+	}
+	w.Flush()
+}
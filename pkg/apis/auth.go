@@ -0,0 +1,160 @@
+package apis
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// apiKeyHeader carries an API key as an alternative to a bearer token
+	apiKeyHeader = "X-API-Key"
+	// apiKeyScopeKey stores the caller's key scope in the gin context
+	apiKeyScopeKey = "api_key_scope"
+)
+
+// apiKeyScope is what the key of the current request may access
+type apiKeyScope struct {
+	name       string
+	namespaces map[string]bool // nil for an unscoped key
+}
+
+// allows reports whether the scope covers the namespace
+func (s *apiKeyScope) allows(namespace string) bool {
+	return s == nil || s.namespaces == nil || s.namespaces[namespace]
+}
+
+// EnableAPIKeys requires one of the keys on every request except /health
+func (h *Handler) EnableAPIKeys(keys []types.APIKey) error {
+	seenNames := make(map[string]bool, len(keys))
+	seenKeys := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		if key.Name == "" {
+			return fmt.Errorf("api key %d: name is required", i)
+		}
+		if len(key.Key) < 16 {
+			return fmt.Errorf("api key %s: key must be at least 16 characters", key.Name)
+		}
+		if seenNames[key.Name] || seenKeys[key.Key] {
+			return fmt.Errorf("api key %s: duplicate name or key", key.Name)
+		}
+		seenNames[key.Name] = true
+		seenKeys[key.Key] = true
+		for _, namespace := range key.Namespaces {
+			if namespace == "" {
+				return fmt.Errorf("api key %s: namespaces must not be empty strings", key.Name)
+			}
+		}
+	}
+	h.apiKeys = keys
+	return nil
+}
+
+// LoadAPIKeysFile reads a JSON array of API keys
+func LoadAPIKeysFile(path string) ([]types.APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API keys file: %w", err)
+	}
+
+	var keys []types.APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys file: %w", err)
+	}
+	return keys, nil
+}
+
+// authMiddleware resolves the request's API key to its scope, rejecting requests
+// without a valid key
+func (h *Handler) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented := c.GetHeader(apiKeyHeader)
+		if presented == "" {
+			presented = strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		}
+
+		var matched *types.APIKey
+		for i := range h.apiKeys {
+			if subtle.ConstantTimeCompare([]byte(presented), []byte(h.apiKeys[i].Key)) == 1 {
+				matched = &h.apiKeys[i]
+			}
+		}
+		if presented == "" || matched == nil {
+			abortWithProblem(c, http.StatusUnauthorized, "Unauthorized", "a valid API key is required in the Authorization or "+apiKeyHeader+" header")
+			return
+		}
+
+		scope := &apiKeyScope{name: matched.Name}
+		if len(matched.Namespaces) > 0 {
+			scope.namespaces = make(map[string]bool, len(matched.Namespaces))
+			for _, namespace := range matched.Namespaces {
+				scope.namespaces[namespace] = true
+			}
+		}
+		c.Set(apiKeyScopeKey, scope)
+		c.Next()
+	}
+}
+
+// scopeOf returns the key scope of the request, nil when API keys are disabled
+func scopeOf(c *gin.Context) *apiKeyScope {
+	scope, _ := c.Get(apiKeyScopeKey)
+	s, _ := scope.(*apiKeyScope)
+	return s
+}
+
+// requireNamespace answers 403 and returns false if the caller's key does not cover
+// the namespace
+func requireNamespace(c *gin.Context, namespace string) bool {
+	scope := scopeOf(c)
+	if scope.allows(namespace) {
+		return true
+	}
+	abortWithProblem(c, http.StatusForbidden, "Forbidden", fmt.Sprintf("API key %s is not allowed to access namespace %q", scope.name, namespace))
+	return false
+}
+
+// unscopedOnly guards cluster-wide endpoints, such as node operations and runtime
+// configuration, from namespace-scoped keys
+func unscopedOnly(c *gin.Context) {
+	scope := scopeOf(c)
+	if scope == nil || scope.namespaces == nil {
+		c.Next()
+		return
+	}
+	abortWithProblem(c, http.StatusForbidden, "Forbidden", fmt.Sprintf("API key %s is limited to namespaces and cannot use this endpoint", scope.name))
+}
+
+// migrationInScope guards /migrations/:id endpoints. Unknown IDs pass through so the
+// handler can answer 404.
+func (h *Handler) migrationInScope(c *gin.Context) {
+	if namespace, exists := h.migrationController.MigrationNamespace(c.Param("id")); exists && !requireNamespace(c, namespace) {
+		return
+	}
+	c.Next()
+}
+
+// batchInScope guards /batches/:id; every migration of the batch must be covered
+func (h *Handler) batchInScope(c *gin.Context) {
+	for _, namespace := range h.migrationController.BatchNamespaces(c.Param("id")) {
+		if !requireNamespace(c, namespace) {
+			return
+		}
+	}
+	c.Next()
+}
+
+// autoscalerInScope guards /autoscaling/:id endpoints
+func (h *Handler) autoscalerInScope(c *gin.Context) {
+	if namespace, exists := h.autoscalingController.AutoscalerNamespace(c.Param("id")); exists && !requireNamespace(c, namespace) {
+		return
+	}
+	c.Next()
+}
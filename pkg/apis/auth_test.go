@@ -0,0 +1,164 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	adminKey = "admin-key-0123456789"
+	teamAKey = "team-a-key-0123456789"
+)
+
+// newAuthRouter serves the API with an unscoped admin key and a key limited to the
+// team-a namespace
+func newAuthRouter(t *testing.T) (*Handler, *gin.Engine) {
+	t.Helper()
+	h := newTestHandler(t)
+	if err := h.EnableAPIKeys([]types.APIKey{
+		{Name: "admin", Key: adminKey},
+		{Name: "team-a", Key: teamAKey, Namespaces: []string{"team-a"}},
+	}); err != nil {
+		t.Fatalf("EnableAPIKeys: %v", err)
+	}
+	return h, h.SetupRoutes()
+}
+
+// serveWithKey sends a request with key as a bearer token, none if empty
+func serveWithKey(router http.Handler, method, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// migrationBody is a migration request of pod app in namespace
+func migrationBody(namespace string) string {
+	return `{"pod_name": "app", "pod_namespace": "` + namespace + `", "source_node": "node-a", "target_node": "node-b"}`
+}
+
+func TestAuthRequiresKey(t *testing.T) {
+	_, router := newAuthRouter(t)
+
+	if code := serveWithKey(router, http.MethodGet, "/api/v1/version", "", "").Code; code != http.StatusUnauthorized {
+		t.Errorf("no key: status %d, want 401", code)
+	}
+	if code := serveWithKey(router, http.MethodGet, "/api/v1/version", "wrong-key-0123456789", "").Code; code != http.StatusUnauthorized {
+		t.Errorf("wrong key: status %d, want 401", code)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/version", nil)
+	req.Header.Set(apiKeyHeader, adminKey)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Errorf("key in %s: status %d, want 200", apiKeyHeader, recorder.Code)
+	}
+	if code := serveWithKey(router, http.MethodGet, "/health", "", "").Code; code != http.StatusOK {
+		t.Errorf("/health without a key: status %d, want 200", code)
+	}
+}
+
+func TestAuthScopedKeyLimitedToNamespaces(t *testing.T) {
+	_, router := newAuthRouter(t)
+
+	if recorder := serveWithKey(router, http.MethodPost, "/api/v1/migrations", teamAKey, migrationBody("team-b")); recorder.Code != http.StatusForbidden {
+		t.Errorf("migration in team-b: status %d, want 403: %s", recorder.Code, recorder.Body)
+	}
+	batch := `{"migrations": [` + migrationBody("team-a") + `, ` + strings.Replace(migrationBody("team-b"), `"app"`, `"db"`, 1) + `]}`
+	if recorder := serveWithKey(router, http.MethodPost, "/api/v1/batches", teamAKey, batch); recorder.Code != http.StatusForbidden {
+		t.Errorf("batch with a pod in team-b: status %d, want 403: %s", recorder.Code, recorder.Body)
+	}
+	if recorder := serveWithKey(router, http.MethodPost, "/api/v1/migrations", teamAKey, migrationBody("team-a")); recorder.Code != http.StatusAccepted {
+		t.Errorf("migration in team-a: status %d, want 202: %s", recorder.Code, recorder.Body)
+	}
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/status"},
+		{http.MethodPost, "/api/v1/nodes/node-a/drain"},
+		{http.MethodGet, "/metrics"},
+	} {
+		if code := serveWithKey(router, route.method, route.path, teamAKey, `{"target_node": "node-b"}`).Code; code != http.StatusForbidden {
+			t.Errorf("%s %s with a scoped key: status %d, want 403", route.method, route.path, code)
+		}
+	}
+	if code := serveWithKey(router, http.MethodGet, "/api/v1/status", adminKey, "").Code; code != http.StatusOK {
+		t.Errorf("GET /api/v1/status with the admin key: status %d, want 200", code)
+	}
+}
+
+func TestAuthResourcesOfOtherNamespaces(t *testing.T) {
+	h, router := newAuthRouter(t)
+
+	created := serveWithKey(router, http.MethodPost, "/api/v1/migrations", adminKey, migrationBody("team-b"))
+	if created.Code != http.StatusAccepted {
+		t.Fatalf("create migration: status %d: %s", created.Code, created.Body)
+	}
+	var migration types.MigrationResponse
+	if err := json.Unmarshal(created.Body.Bytes(), &migration); err != nil {
+		t.Fatal(err)
+	}
+	batchBody := `{"migrations": [` + strings.Replace(migrationBody("team-b"), `"app"`, `"db"`, 1) + `]}`
+	created = serveWithKey(router, http.MethodPost, "/api/v1/batches", adminKey, batchBody)
+	if created.Code != http.StatusAccepted {
+		t.Fatalf("create batch: status %d: %s", created.Code, created.Body)
+	}
+	var batch types.BatchMigrationResponse
+	if err := json.Unmarshal(created.Body.Bytes(), &batch); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{
+		"/api/v1/migrations/" + migration.MigrationID,
+		"/api/v1/migrations/" + migration.MigrationID + "/logs",
+		"/api/v1/batches/" + batch.BatchID,
+	} {
+		if code := serveWithKey(router, http.MethodGet, path, teamAKey, "").Code; code != http.StatusForbidden {
+			t.Errorf("GET %s with the team-a key: status %d, want 403", path, code)
+		}
+		if code := serveWithKey(router, http.MethodGet, path, adminKey, "").Code; code != http.StatusOK {
+			t.Errorf("GET %s with the admin key: status %d, want 200", path, code)
+		}
+	}
+
+	// The pod does not exist, so the migration soon fails and is counted
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		status, err := h.migrationController.GetMigrationStatus(migration.MigrationID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Status == types.MigrationStatusFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("migration still %s", status.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	totals := map[string]int64{}
+	for name, key := range map[string]string{"admin": adminKey, "team-a": teamAKey} {
+		recorder := serveWithKey(router, http.MethodGet, "/api/v1/metrics", key, "")
+		var metrics types.MigrationMetrics
+		if err := json.Unmarshal(recorder.Body.Bytes(), &metrics); err != nil {
+			t.Fatal(err)
+		}
+		totals[name] = metrics.FailedMigrations
+	}
+	if totals["admin"] == 0 || totals["team-a"] != 0 {
+		t.Errorf("failed migrations seen by admin %d and team-a %d, want team-b's failure only seen by admin", totals["admin"], totals["team-a"])
+	}
+}
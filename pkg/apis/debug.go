@@ -0,0 +1,50 @@
+package apis
+
+import (
+	"log"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnableDebugEndpoints registers /debug/state and /debug/pprof on the next
+// SetupRoutes call. They expose internals and must stay off in untrusted networks.
+func (h *Handler) EnableDebugEndpoints() {
+	h.debugEndpoints = true
+}
+
+// setupDebugRoutes registers the debug endpoints
+func (h *Handler) setupDebugRoutes(router *gin.Engine) {
+	debug := router.Group("/debug", unscopedOnly)
+	debug.GET("/state", h.getDebugState)
+	debug.GET("/pprof/*profile", servePprof)
+}
+
+// getDebugState handles GET /debug/state
+func (h *Handler) getDebugState(c *gin.Context) {
+	c.JSON(http.StatusOK, h.migrationController.DebugState())
+}
+
+// servePprof handles GET /debug/pprof/*profile with the standard pprof handlers
+func servePprof(c *gin.Context) {
+	// CPU profiles and traces run for as long as the client asks
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Warning: Failed to clear write deadline for pprof: %v", err)
+	}
+
+	switch c.Param("profile") {
+	case "/cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "/profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "/symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "/trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		// Index serves both the listing and named profiles such as /goroutine
+		pprof.Index(c.Writer, c.Request)
+	}
+}
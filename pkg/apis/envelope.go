@@ -0,0 +1,212 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// envelopeHeader lets a client ask for (true) or opt out of (false) the response
+	// envelope, overriding the server default
+	envelopeHeader = "X-Response-Envelope"
+	// paginationKey stores list metadata in the gin context for the envelope
+	paginationKey = "pagination"
+)
+
+// responseEnvelope is the standard shape of enveloped responses: exactly one of
+// Data and Error is set
+type responseEnvelope struct {
+	Data  json.RawMessage `json:"data"`
+	Error json.RawMessage `json:"error"`
+	Meta  envelopeMeta    `json:"meta"`
+}
+
+// envelopeMeta describes the request that produced an enveloped response
+type envelopeMeta struct {
+	RequestID  string          `json:"request_id"`
+	Status     int             `json:"status"`
+	Timestamp  time.Time       `json:"timestamp"`
+	DurationMS float64         `json:"duration_ms"`
+	Pagination *paginationMeta `json:"pagination,omitempty"`
+}
+
+// paginationMeta describes the slice of a collection returned by a list endpoint
+type paginationMeta struct {
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit,omitempty"`
+	Count  int `json:"count"`
+}
+
+// EnableResponseEnvelope wraps JSON responses in the standard envelope unless a
+// request opts out with X-Response-Envelope: false
+func (h *Handler) EnableResponseEnvelope() {
+	h.envelopeByDefault = true
+}
+
+// paginate returns the page of items selected by the offset and limit query
+// parameters and records it for the response envelope. No limit returns every item
+// from offset on. It answers 400 and returns false on an invalid parameter.
+func paginate[T any](c *gin.Context, items []T) ([]T, bool) {
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		writeProblem(c, http.StatusBadRequest, "Invalid offset parameter", "offset must be a non-negative integer")
+		return nil, false
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		writeProblem(c, http.StatusBadRequest, "Invalid limit parameter", "limit must be a non-negative integer")
+		return nil, false
+	}
+
+	total := len(items)
+	start := min(offset, total)
+	end := total
+	if limit > 0 {
+		end = min(start+limit, total)
+	}
+	page := items[start:end]
+	c.Set(paginationKey, &paginationMeta{Total: total, Offset: offset, Limit: limit, Count: len(page)})
+	return page, true
+}
+
+// envelopeMiddleware buffers JSON responses and rewrites them into the standard
+// envelope. Other content, such as server-sent event streams and profiles, passes
+// through untouched.
+func envelopeMiddleware(byDefault bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		enabled := byDefault
+		if value := c.GetHeader(envelopeHeader); value != "" {
+			if parsed, err := strconv.ParseBool(value); err == nil {
+				enabled = parsed
+			}
+		}
+		if !enabled {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		writer := &envelopeWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.passthrough {
+			return
+		}
+		if writer.status == http.StatusNoContent || writer.status == http.StatusNotModified {
+			c.Writer.WriteHeader(writer.status)
+			c.Writer.WriteHeaderNow()
+			return
+		}
+
+		envelope := responseEnvelope{
+			Meta: envelopeMeta{
+				RequestID:  c.GetString(requestIDKey),
+				Status:     writer.status,
+				Timestamp:  start,
+				DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+			},
+		}
+		if pagination, exists := c.Get(paginationKey); exists {
+			envelope.Meta.Pagination = pagination.(*paginationMeta)
+		}
+
+		body := writer.body.Bytes()
+		if len(body) == 0 {
+			body = []byte("null")
+		}
+		if writer.status >= http.StatusBadRequest {
+			envelope.Error = body
+		} else {
+			envelope.Data = body
+		}
+
+		encoded, err := json.Marshal(envelope)
+		if err != nil {
+			log.Printf("Warning: Failed to encode response envelope: %v", err)
+			encoded = body
+		}
+		c.Writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		c.Writer.WriteHeader(writer.status)
+		if _, err := c.Writer.Write(encoded); err != nil {
+			log.Printf("Warning: Failed to write response envelope: %v", err)
+		}
+	}
+}
+
+// envelopeWriter holds back JSON bodies for envelopeMiddleware. Whether to buffer is
+// decided from the Content-Type on the first write.
+type envelopeWriter struct {
+	gin.ResponseWriter
+	body        bytes.Buffer
+	status      int
+	decided     bool
+	passthrough bool
+}
+
+func (w *envelopeWriter) WriteHeader(code int) {
+	w.status = code
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *envelopeWriter) Write(data []byte) (int, error) {
+	if w.decide() {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *envelopeWriter) WriteString(s string) (int, error) {
+	if w.decide() {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+func (w *envelopeWriter) Flush() {
+	if w.decide() {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// WriteHeaderNow is a no-op while buffering; the envelope is written after the handler
+func (w *envelopeWriter) WriteHeaderNow() {
+	if w.decide() {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *envelopeWriter) Status() int {
+	return w.status
+}
+
+// Unwrap lets http.ResponseController reach the connection
+func (w *envelopeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// decide reports whether the response bypasses the envelope, fixing the choice on
+// first use
+func (w *envelopeWriter) decide() bool {
+	if !w.decided {
+		w.decided = true
+		contentType := w.Header().Get("Content-Type")
+		w.passthrough = !strings.HasPrefix(contentType, "application/json") &&
+			!strings.HasPrefix(contentType, problemContentType)
+		if w.passthrough {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+	}
+	return w.passthrough
+}
@@ -0,0 +1,78 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// offset and limit select a page of a list; the envelope reports it
+func TestListPagination(t *testing.T) {
+	h := newTestHandler(t)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if err := h.migrationController.SavePreset(&types.MigrationPreset{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	router := h.SetupRoutes()
+
+	tests := []struct {
+		query string
+		names []string
+		meta  paginationMeta
+	}{
+		{"", []string{"a", "b", "c", "d", "e"}, paginationMeta{Total: 5, Count: 5}},
+		{"?offset=1&limit=2", []string{"b", "c"}, paginationMeta{Total: 5, Offset: 1, Limit: 2, Count: 2}},
+		{"?offset=3", []string{"d", "e"}, paginationMeta{Total: 5, Offset: 3, Count: 2}},
+		{"?offset=4&limit=10", []string{"e"}, paginationMeta{Total: 5, Offset: 4, Limit: 10, Count: 1}},
+		{"?offset=7", []string{}, paginationMeta{Total: 5, Offset: 7, Count: 0}},
+	}
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/presets"+tt.query, nil)
+		req.Header.Set(envelopeHeader, "true")
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("GET presets%s: status %d: %s", tt.query, recorder.Code, recorder.Body)
+		}
+
+		var envelope struct {
+			Data struct {
+				Presets []types.MigrationPreset `json:"presets"`
+				Count   int                     `json:"count"`
+			} `json:"data"`
+			Meta envelopeMeta `json:"meta"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, preset := range envelope.Data.Presets {
+			names = append(names, preset.Name)
+		}
+		if len(names) != len(tt.names) || envelope.Data.Count != len(tt.names) {
+			t.Errorf("presets%s = %v (count %d), want %v", tt.query, names, envelope.Data.Count, tt.names)
+		} else {
+			for i := range names {
+				if names[i] != tt.names[i] {
+					t.Errorf("presets%s = %v, want %v", tt.query, names, tt.names)
+					break
+				}
+			}
+		}
+		if envelope.Meta.Pagination == nil || *envelope.Meta.Pagination != tt.meta {
+			t.Errorf("pagination of presets%s = %+v, want %+v", tt.query, envelope.Meta.Pagination, tt.meta)
+		}
+	}
+
+	for _, query := range []string{"?offset=-1", "?offset=x", "?limit=-2"} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/presets"+query, nil))
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("GET presets%s: status %d, want 400", query, recorder.Code)
+		}
+	}
+}
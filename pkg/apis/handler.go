@@ -1,19 +1,52 @@
 package apis
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"ai-storage-orchestrator/pkg/controller"
 	"ai-storage-orchestrator/pkg/types"
+	"ai-storage-orchestrator/pkg/version"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// maxBulkStatusIDs caps the number of migration IDs accepted by the bulk status endpoint
+const maxBulkStatusIDs = 100
+
+// defaultQueueRetryAfter is the shortest Retry-After suggested for a queued migration
+const defaultQueueRetryAfter = 5 * time.Second
+
+// preflightTimeout bounds the Kubernetes calls of a preflight validation
+const preflightTimeout = 30 * time.Second
+
+// defaultMigrationTimeout is the timeout of migrations requested without one, in seconds
+const defaultMigrationTimeout = 600
+
+// Bounds of the wait endpoint's timeout parameter, in seconds
+const (
+	defaultWaitTimeout = 300
+	maxWaitTimeout     = 3600
 )
 
 // Handler provides HTTP API endpoints for the migration orchestrator
 type Handler struct {
 	migrationController   *controller.MigrationController
 	autoscalingController *controller.AutoscalingController
+	debugEndpoints        bool
+	envelopeByDefault     bool
+	apiKeys               []types.APIKey
 }
 
 // NewHandler creates a new API handler
@@ -32,24 +65,79 @@ func (h *Handler) SetupRoutes() *gin.Engine {
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(corsMiddleware())
+	router.Use(requestIDMiddleware())
+	router.Use(envelopeMiddleware(h.envelopeByDefault))
+
+	// Unknown routes get a problem response like every other error
+	router.NoRoute(routeNotFound)
 
 	// Health check endpoint
 	router.GET("/health", h.healthCheck)
+	router.GET("/ready", h.readinessCheck)
+
+	// Everything registered below needs an API key once keys are configured
+	if len(h.apiKeys) > 0 {
+		router.Use(h.authMiddleware())
+	}
+
+	// Prometheus scrape endpoint
+	router.GET("/metrics", unscopedOnly, h.getPrometheusMetrics)
+
+	if h.debugEndpoints {
+		h.setupDebugRoutes(router)
+	}
 
 	// Migration API endpoints
 	v1 := router.Group("/api/v1")
 	{
 		v1.POST("/migrations", h.createMigration)
-		v1.GET("/migrations/:id", h.getMigration)
-		v1.GET("/migrations/:id/status", h.getMigrationStatus)
+		v1.POST("/migrations/status", h.getMigrationStatuses)
+		v1.POST("/migrations/validate", h.validateMigration)
+		v1.POST("/migrations/validate-request", h.validateMigrationRequestOnly)
+		v1.POST("/migrations/plan", h.planMigration)
+		v1.GET("/migrations/:id", h.migrationInScope, h.getMigration)
+		v1.GET("/migrations/:id/status", h.migrationInScope, h.getMigrationStatus)
+		v1.GET("/migrations/:id/logs", h.migrationInScope, h.getMigrationLogs)
+		v1.GET("/migrations/:id/wait", h.migrationInScope, h.waitForMigration)
 		v1.GET("/metrics", h.getMetrics)
+		v1.GET("/metrics/summary", h.getMetricsSummary)
+		v1.GET("/version", h.getVersion)
+		v1.GET("/status", unscopedOnly, h.getStatus)
+
+		// Batch migration endpoints
+		v1.POST("/batches", h.createBatch)
+		v1.GET("/batches/:id", h.batchInScope, h.getBatch)
+
+		// Pod history
+		v1.GET("/pods/:namespace/:name/migrations", h.getPodMigrations)
+		v1.POST("/pods/:namespace/:name/analyze", h.analyzePod)
+
+		// Node operations
+		v1.POST("/nodes/:name/cancel-migrations", unscopedOnly, h.cancelMigrationsToNode)
+		v1.POST("/nodes/:name/drain", unscopedOnly, h.drainNode)
+
+		// Recommendations of the pressure observer
+		v1.GET("/recommendations", unscopedOnly, h.listRecommendations)
+		v1.POST("/recommendations/:id/promote", unscopedOnly, h.promoteRecommendation)
+
+		// Migration preset endpoints
+		v1.POST("/presets", unscopedOnly, h.savePreset)
+		v1.GET("/presets", h.listPresets)
+		v1.GET("/presets/:name", h.getPreset)
+		v1.DELETE("/presets/:name", unscopedOnly, h.deletePreset)
+
+		// Runtime configuration endpoints
+		v1.GET("/config/webhooks", unscopedOnly, h.listWebhooks)
+		v1.PUT("/config/webhooks", unscopedOnly, h.replaceWebhooks)
+		v1.GET("/config/classification", unscopedOnly, h.getClassificationPolicy)
+		v1.PUT("/config/classification", unscopedOnly, h.replaceClassificationPolicy)
 
 		// Autoscaling API endpoints
 		v1.POST("/autoscaling", h.createAutoscaler)
-		v1.GET("/autoscaling/:id", h.getAutoscaler)
-		v1.DELETE("/autoscaling/:id", h.deleteAutoscaler)
+		v1.GET("/autoscaling/:id", h.autoscalerInScope, h.getAutoscaler)
+		v1.DELETE("/autoscaling/:id", h.autoscalerInScope, h.deleteAutoscaler)
 		v1.GET("/autoscaling", h.listAutoscalers)
-		v1.GET("/autoscaling/metrics", h.getAutoscalingMetrics)
+		v1.GET("/autoscaling/metrics", unscopedOnly, h.getAutoscalingMetrics)
 	}
 
 	return router
@@ -59,76 +147,290 @@ func (h *Handler) SetupRoutes() *gin.Engine {
 func (h *Handler) healthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "healthy",
+		"state":   h.migrationController.Lifecycle(),
 		"service": "ai-storage-orchestrator",
-		"version": "1.0.0",
+		"version": version.Version,
 	})
 }
 
-// createMigration handles POST /api/v1/migrations
-func (h *Handler) createMigration(c *gin.Context) {
-	var req types.MigrationRequest
-	
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+// readinessCheck reports whether the orchestrator should receive new traffic.
+// It answers 503 once shutdown has begun so load balancers stop routing here.
+func (h *Handler) readinessCheck(c *gin.Context) {
+	state := h.migrationController.Lifecycle()
+	code := http.StatusOK
+	if state != types.LifecycleRunning {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, gin.H{
+		"ready": code == http.StatusOK,
+		"state": state,
+	})
+}
+
+// getStatus handles GET /api/v1/status
+func (h *Handler) getStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.migrationController.Status())
+}
+
+// versionResponse is the build information plus which cluster is used and what it supports
+type versionResponse struct {
+	version.Info
+	Connection types.ClusterConnection    `json:"connection"`
+	Cluster    *types.ClusterCapabilities `json:"cluster"`
+}
+
+// getMetricsSummary handles GET /api/v1/metrics/summary?from=&to=. Both bounds are
+// RFC 3339 timestamps or dates; from defaults to the earliest migration and to to now.
+func (h *Handler) getMetricsSummary(c *gin.Context) {
+	from, err := parseTimeBound(c.Query("from"), time.Time{})
+	if err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid from parameter", err)
+		return
+	}
+	to, err := parseTimeBound(c.Query("to"), time.Now())
+	if err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid to parameter", err)
+		return
+	}
+	if !from.Before(to) {
+		writeProblem(c, http.StatusBadRequest, "Invalid time range", "from must be before to")
 		return
 	}
 
-	// Validate required fields
-	if err := h.validateMigrationRequest(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Validation failed",
-			"details": err.Error(),
-		})
+	summary, err := h.migrationController.GetMetricsSummary(c.Request.Context(), from, to, scopeOf(c).allows)
+	if err != nil {
+		writeProblemErr(c, http.StatusInternalServerError, "Failed to read migration records", err)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
+
+// parseTimeBound parses an RFC 3339 timestamp or a date (midnight UTC), returning
+// fallback for an empty value
+func parseTimeBound(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor a date (YYYY-MM-DD)", value)
+	}
+	return t, nil
+}
+
+// getVersion handles GET /api/v1/version
+func (h *Handler) getVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, versionResponse{
+		Info:       version.Get(),
+		Connection: h.migrationController.ClusterConnection(),
+		Cluster:    h.migrationController.ClusterCapabilities(),
+	})
+}
+
+// createMigration handles POST /api/v1/migrations
+func (h *Handler) createMigration(c *gin.Context) {
+	req, ok := h.bindMigrationRequest(c)
+	if !ok {
 		return
 	}
+	req.RequestID = c.GetString(requestIDKey)
 
-	// Set default timeout if not provided
-	if req.Timeout == 0 {
-		req.Timeout = 600 // 10 minutes default
+	// Fault injection for resilience testing, only with debug endpoints enabled
+	if failAt := c.Query("fail-at"); failAt != "" {
+		if !h.debugEndpoints {
+			writeProblem(c, http.StatusForbidden, "Fault injection disabled", "fail-at requires the server to run with --enable-debug-endpoints")
+			return
+		}
+		step, err := controller.NormalizeFaultStep(failAt)
+		if err != nil {
+			writeProblemErr(c, http.StatusBadRequest, "Invalid fail-at parameter", err)
+			return
+		}
+		req.FailAt = step
 	}
 
 	// Start migration
-	response, err := h.migrationController.StartMigration(&req)
+	response, err := h.migrationController.StartMigration(req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to start migration",
-			"details": err.Error(),
-		})
+		writeProblemErr(c, http.StatusInternalServerError, "Failed to start migration", err)
 		return
 	}
 
+	// The migration runs asynchronously; point clients at its status resource
+	c.Header("Location", fmt.Sprintf("/api/v1/migrations/%s", response.MigrationID))
+	if response.Details != nil && response.Details.AlreadyMigrated {
+		// Nothing was started, the request is already satisfied
+		c.JSON(http.StatusOK, response)
+		return
+	}
 	c.JSON(http.StatusAccepted, response)
 }
 
+// validateMigration handles POST /api/v1/migrations/validate: a synchronous preflight
+// of every precondition that starts nothing
+func (h *Handler) validateMigration(c *gin.Context) {
+	req, ok := h.bindMigrationRequest(c)
+	if !ok {
+		return
+	}
+	req.RequestID = c.GetString(requestIDKey)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), preflightTimeout)
+	defer cancel()
+
+	c.JSON(http.StatusOK, h.migrationController.ValidateMigration(ctx, req))
+}
+
+// validateMigrationRequestOnly handles POST /api/v1/migrations/validate-request: the
+// static validation of createMigration without any cluster access, cheap enough to
+// run on every form change. It returns the request with its defaults applied.
+func (h *Handler) validateMigrationRequestOnly(c *gin.Context) {
+	req, ok := h.bindMigrationRequest(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"valid":   true,
+		"request": h.migrationController.DefaultedRequest(req),
+	})
+}
+
+// planMigration handles POST /api/v1/migrations/plan: a dry run returning the plan
+// the migration would execute, for clients to review before creating it
+func (h *Handler) planMigration(c *gin.Context) {
+	req, ok := h.bindMigrationRequest(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), preflightTimeout)
+	defer cancel()
+
+	plan, err := h.migrationController.PlanMigration(ctx, req)
+	if apierrors.IsNotFound(err) {
+		writeProblemErr(c, http.StatusNotFound, "Pod not found", err)
+		return
+	}
+	if err != nil {
+		writeProblemErr(c, http.StatusUnprocessableEntity, "Failed to plan migration", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// requestError is a migration request rejected before anything started, with the
+// title of the problem it is answered with
+type requestError struct {
+	title string
+	err   error
+}
+
+func (e *requestError) Error() string {
+	return strings.ToLower(e.title) + ": " + e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// ParseMigrationRequest decodes a migration request given as JSON and prepares it
+// the way createMigration does, for callers outside the HTTP API
+func (h *Handler) ParseMigrationRequest(body []byte) (*types.MigrationRequest, error) {
+	var req types.MigrationRequest
+	if err := binding.JSON.BindBody(body, &req); err != nil {
+		return nil, &requestError{"Invalid request format", err}
+	}
+	if err := h.prepareMigrationRequest(&req, body); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// bindMigrationRequest decodes a migration request and prepares it, writing a 400
+// response and returning false on failure
+func (h *Handler) bindMigrationRequest(c *gin.Context) (*types.MigrationRequest, bool) {
+	var req types.MigrationRequest
+
+	// Bind with body caching so the raw body can be re-applied over a preset
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+		return nil, false
+	}
+	if err := h.prepareMigrationRequest(&req, c.MustGet(gin.BodyBytesKey).([]byte)); err != nil {
+		rejected := err.(*requestError)
+		writeProblemErr(c, http.StatusBadRequest, rejected.title, rejected.err)
+		return nil, false
+	}
+
+	if !requireNamespace(c, req.PodNamespace) {
+		return nil, false
+	}
+
+	return &req, true
+}
+
+// prepareMigrationRequest merges the preset of a migration request decoded from body
+// (explicit request fields win), validates it and applies the defaults. Failures are
+// *requestError.
+func (h *Handler) prepareMigrationRequest(req *types.MigrationRequest, body []byte) error {
+	if req.Preset != "" {
+		if err := h.migrationController.ApplyPreset(req, body); err != nil {
+			return &requestError{"Invalid preset", err}
+		}
+	}
+	if err := h.validateMigrationRequest(req); err != nil {
+		return &requestError{"Validation failed", err}
+	}
+	defaultMigrationOptions(&req.MigrationOptions)
+	return nil
+}
+
+// defaultMigrationOptions applies the defaults of options a request left unset
+func defaultMigrationOptions(options *types.MigrationOptions) {
+	if options.Timeout == 0 {
+		options.Timeout = defaultMigrationTimeout
+	}
+}
+
 // getMigration handles GET /api/v1/migrations/:id
 func (h *Handler) getMigration(c *gin.Context) {
 	migrationID := c.Param("id")
 	
 	response, err := h.migrationController.GetMigrationStatus(migrationID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Migration not found",
-			"details": err.Error(),
-		})
+		writeProblemErr(c, http.StatusNotFound, "Migration not found", err)
 		return
 	}
 
+	setRetryAfter(c, response)
 	c.JSON(http.StatusOK, response)
 }
 
+// setRetryAfter tells clients polling a queued migration when to look again: at its
+// estimated start, or after defaultQueueRetryAfter while there is no estimate yet
+func setRetryAfter(c *gin.Context, response *types.MigrationResponse) {
+	if response.QueuePosition == 0 {
+		return
+	}
+	retryAfter := defaultQueueRetryAfter
+	if response.EstimatedWait != nil && *response.EstimatedWait > retryAfter {
+		retryAfter = *response.EstimatedWait
+	}
+	c.Header("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second).Seconds())))
+}
+
 // getMigrationStatus handles GET /api/v1/migrations/:id/status
 func (h *Handler) getMigrationStatus(c *gin.Context) {
 	migrationID := c.Param("id")
 	
 	response, err := h.migrationController.GetMigrationStatus(migrationID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Migration not found",
-			"details": err.Error(),
-		})
+		writeProblemErr(c, http.StatusNotFound, "Migration not found", err)
 		return
 	}
 
@@ -149,16 +451,524 @@ func (h *Handler) getMigrationStatus(c *gin.Context) {
 			statusResponse["duration_seconds"] = response.Details.Duration.Seconds()
 		}
 	}
+	if response.QueuePosition > 0 {
+		statusResponse["queue_position"] = response.QueuePosition
+		if response.EstimatedStartTime != nil {
+			statusResponse["estimated_start_time"] = response.EstimatedStartTime
+		}
+	}
 
+	setRetryAfter(c, response)
 	c.JSON(http.StatusOK, statusResponse)
 }
 
-// getMetrics handles GET /api/v1/metrics
+// getMigrationStatuses handles POST /api/v1/migrations/status
+func (h *Handler) getMigrationStatuses(c *gin.Context) {
+	var migrationIDs []string
+
+	if err := c.ShouldBindJSON(&migrationIDs); err != nil {
+		writeProblem(c, http.StatusBadRequest, "Invalid request format", "expected a JSON array of migration IDs: "+err.Error())
+		return
+	}
+	if len(migrationIDs) == 0 {
+		writeProblem(c, http.StatusBadRequest, "Validation failed", "at least one migration ID is required")
+		return
+	}
+	if len(migrationIDs) > maxBulkStatusIDs {
+		writeProblem(c, http.StatusBadRequest, "Validation failed", fmt.Sprintf("at most %d migration IDs are allowed per request", maxBulkStatusIDs))
+		return
+	}
+
+	statuses := h.migrationController.GetMigrationStatuses(migrationIDs)
+	if scope := scopeOf(c); scope != nil {
+		for migrationID, status := range statuses {
+			namespace, _ := h.migrationController.MigrationNamespace(migrationID)
+			if status.Found && !scope.allows(namespace) {
+				statuses[migrationID] = &types.BulkMigrationStatus{
+					Found: false,
+					Error: fmt.Sprintf("migration %s is outside the namespaces of API key %s", migrationID, scope.name),
+				}
+			}
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"migrations": statuses,
+		"count":      len(statuses),
+	})
+}
+
+// createBatch handles POST /api/v1/batches
+func (h *Handler) createBatch(c *gin.Context) {
+	var req types.BatchMigrationRequest
+
+	// Bind with body caching so each child's raw body can be re-applied over a preset
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	if err := h.prepareBatchRequest(&req, c.MustGet(gin.BodyBytesKey).([]byte)); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+	for i := range req.Migrations {
+		if !requireNamespace(c, req.Migrations[i].PodNamespace) {
+			return
+		}
+	}
+	for i := range req.Migrations {
+		req.Migrations[i].RequestID = c.GetString(requestIDKey)
+	}
+
+	response, err := h.migrationController.StartBatch(&req)
+	if err != nil {
+		writeProblemErr(c, http.StatusInternalServerError, "Failed to start batch", err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/v1/batches/%s", response.BatchID))
+	c.JSON(http.StatusAccepted, response)
+}
+
+// getBatch handles GET /api/v1/batches/:id
+func (h *Handler) getBatch(c *gin.Context) {
+	batchID := c.Param("id")
+
+	response, err := h.migrationController.GetBatch(batchID)
+	if err != nil {
+		writeProblemErr(c, http.StatusNotFound, "Batch not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// prepareBatchRequest applies presets, validates and defaults every migration of a
+// batch decoded from body
+func (h *Handler) prepareBatchRequest(req *types.BatchMigrationRequest, body []byte) error {
+	if len(req.Migrations) == 0 {
+		return fmt.Errorf("migrations must contain at least one migration")
+	}
+	if _, err := controller.ResolveMaxUnavailable(req.MaxUnavailable, len(req.Migrations)); err != nil {
+		return err
+	}
+	// Each child's own JSON is merged over its preset, so that the fields it sets win
+	// even when false or zero
+	var raw struct {
+		Migrations []json.RawMessage `json:"migrations"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return err
+	}
+
+	for i := range req.Migrations {
+		if err := h.prepareMigrationRequest(&req.Migrations[i], raw.Migrations[i]); err != nil {
+			return fmt.Errorf("migrations[%d]: %w", i, err)
+		}
+	}
+	if req.PodDelay < 0 {
+		return fmt.Errorf("pod_delay must be non-negative")
+	}
+	if _, _, err := controller.ResolveBatchOrder(req); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getPodMigrations handles GET /api/v1/pods/:namespace/:name/migrations
+func (h *Handler) getPodMigrations(c *gin.Context) {
+	namespace, name := c.Param("namespace"), c.Param("name")
+	if !requireNamespace(c, namespace) {
+		return
+	}
+
+	history, ok := paginate(c, h.migrationController.PodMigrationHistory(namespace, name))
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"namespace":  namespace,
+		"pod_name":   name,
+		"migrations": history,
+		"count":      len(history),
+	})
+}
+
+// analyzePod handles POST /api/v1/pods/:namespace/:name/analyze. The optional body
+// carries resource overrides to preview against the pod's current resources.
+func (h *Handler) analyzePod(c *gin.Context) {
+	namespace, name := c.Param("namespace"), c.Param("name")
+	if !requireNamespace(c, namespace) {
+		return
+	}
+
+	var req types.ResourcePreviewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+			return
+		}
+	}
+	if err := controller.ValidateResourceOverrides("overrides", req.Overrides); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	preview, err := h.migrationController.PreviewResources(ctx, namespace, name, &req)
+	if apierrors.IsNotFound(err) {
+		writeProblemErr(c, http.StatusNotFound, "Pod not found", err)
+		return
+	}
+	if err != nil {
+		writeProblemErr(c, http.StatusUnprocessableEntity, "Failed to analyze pod", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// drainNode handles POST /api/v1/nodes/:name/drain
+func (h *Handler) drainNode(c *gin.Context) {
+	node := c.Param("name")
+	var req types.DrainRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	if err := h.validateDrainRequest(node, &req); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+	defaultMigrationOptions(&req.MigrationOptions)
+
+	response, err := h.migrationController.StartDrain(node, &req, c.GetString(requestIDKey))
+	if err != nil {
+		writeProblemErr(c, http.StatusInternalServerError, "Failed to start drain", err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/v1/batches/%s", response.BatchID))
+	if response.Coalesced {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	c.JSON(http.StatusAccepted, response)
+}
+
+// validateDrainRequest validates a drain of node
+func (h *Handler) validateDrainRequest(node string, req *types.DrainRequest) error {
+	if req.TargetNode == node {
+		return fmt.Errorf("target_node cannot be the drained node")
+	}
+	if req.PodDelay < 0 {
+		return fmt.Errorf("pod_delay must be non-negative")
+	}
+	if _, err := controller.ResolveMaxUnavailable(req.MaxUnavailable, 1); err != nil {
+		return err
+	}
+	return h.validateMigrationOptions(&req.MigrationOptions)
+}
+
+// listRecommendations handles GET /api/v1/recommendations
+func (h *Handler) listRecommendations(c *gin.Context) {
+	c.JSON(http.StatusOK, h.migrationController.ListRecommendations())
+}
+
+// promoteRecommendation handles POST /api/v1/recommendations/:id/promote. The optional
+// body holds the options of the migration.
+func (h *Handler) promoteRecommendation(c *gin.Context) {
+	var req types.PromoteRecommendationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+			return
+		}
+	}
+	defaultMigrationOptions(&req.MigrationOptions)
+
+	// The options are validated with the pod and nodes of the recommendation
+	var invalid error
+	response, err := h.migrationController.PromoteRecommendation(c.Param("id"), &req, c.GetString(requestIDKey), func(migration *types.MigrationRequest) error {
+		invalid = h.validateMigrationRequest(migration)
+		return invalid
+	})
+	if invalid != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Validation failed", invalid)
+		return
+	}
+	if err != nil {
+		writeProblemErr(c, http.StatusInternalServerError, "Failed to promote recommendation", err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/v1/migrations/%s", response.MigrationID))
+	c.JSON(http.StatusAccepted, response)
+}
+
+// cancelMigrationsToNode handles POST /api/v1/nodes/:name/cancel-migrations
+func (h *Handler) cancelMigrationsToNode(c *gin.Context) {
+	node := c.Param("name")
+
+	cancelled := h.migrationController.CancelMigrationsToNode(node)
+	c.JSON(http.StatusOK, gin.H{
+		"node":      node,
+		"cancelled": cancelled,
+		"count":     len(cancelled),
+	})
+}
+
+// waitForMigration handles GET /api/v1/migrations/:id/wait. It blocks until the
+// migration finishes (200) or timeout seconds pass (202 with the current status).
+// With cancel_on_disconnect=true the migration is cancelled if the client goes away
+// before it finishes; otherwise it keeps running as with every other endpoint.
+func (h *Handler) waitForMigration(c *gin.Context) {
+	migrationID := c.Param("id")
+
+	timeout, err := strconv.Atoi(c.DefaultQuery("timeout", strconv.Itoa(defaultWaitTimeout)))
+	if err != nil || timeout <= 0 || timeout > maxWaitTimeout {
+		writeProblem(c, http.StatusBadRequest, "Invalid timeout parameter", fmt.Sprintf("timeout must be between 1 and %d seconds", maxWaitTimeout))
+		return
+	}
+	cancelOnDisconnect, err := strconv.ParseBool(c.DefaultQuery("cancel_on_disconnect", "false"))
+	if err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid cancel_on_disconnect parameter", err)
+		return
+	}
+
+	// The wait may outlast the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Warning: Failed to clear write deadline for wait on %s: %v", migrationID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	response, finished, err := h.migrationController.WaitForMigration(ctx, migrationID)
+	if err != nil {
+		writeProblemErr(c, http.StatusNotFound, "Migration not found", err)
+		return
+	}
+
+	if !finished && c.Request.Context().Err() != nil {
+		// The client disconnected; there is nobody to respond to
+		if cancelOnDisconnect {
+			if err := h.migrationController.CancelMigration(migrationID, "client waiting on the migration disconnected"); err != nil {
+				log.Printf("Warning: Failed to cancel migration %s after client disconnect: %v", migrationID, err)
+			}
+		}
+		return
+	}
+
+	if !finished {
+		c.JSON(http.StatusAccepted, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// getMigrationLogs handles GET /api/v1/migrations/:id/logs
+func (h *Handler) getMigrationLogs(c *gin.Context) {
+	migrationID := c.Param("id")
+
+	since, err := strconv.ParseInt(c.DefaultQuery("since", "0"), 10, 64)
+	if err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid since parameter", err)
+		return
+	}
+	follow, err := strconv.ParseBool(c.DefaultQuery("follow", "false"))
+	if err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid follow parameter", err)
+		return
+	}
+
+	entries, _, _, err := h.migrationController.GetMigrationLogs(migrationID, since)
+	if err != nil {
+		writeProblemErr(c, http.StatusNotFound, "Migration not found", err)
+		return
+	}
+
+	if !follow {
+		c.JSON(http.StatusOK, gin.H{
+			"migration_id": migrationID,
+			"logs":         entries,
+			"count":        len(entries),
+		})
+		return
+	}
+
+	h.streamMigrationLogs(c, migrationID, since)
+}
+
+// streamMigrationLogs sends log entries as server-sent events until the migration
+// finishes or the client goes away. A disconnect only ends the stream; use the wait
+// endpoint with cancel_on_disconnect to tie the migration to the client.
+func (h *Handler) streamMigrationLogs(c *gin.Context, migrationID string, since int64) {
+	// The stream lives as long as the migration, so it must not be cut by the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("Warning: Failed to clear write deadline for log stream of %s: %v", migrationID, err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	for {
+		entries, updated, finished, err := h.migrationController.GetMigrationLogs(migrationID, since)
+		if err != nil {
+			c.SSEvent("error", err.Error())
+			return
+		}
+
+		for _, entry := range entries {
+			c.SSEvent("log", entry)
+			since = entry.Sequence
+		}
+
+		if finished {
+			c.SSEvent("end", gin.H{"migration_id": migrationID})
+			c.Writer.Flush()
+			return
+		}
+		c.Writer.Flush()
+
+		select {
+		case <-updated:
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// getMetrics handles GET /api/v1/metrics. Namespace-scoped API keys get metrics
+// computed from their own namespaces' migrations.
 func (h *Handler) getMetrics(c *gin.Context) {
+	if scope := scopeOf(c); scope != nil && scope.namespaces != nil {
+		c.JSON(http.StatusOK, h.migrationController.GetNamespaceMetrics(scope.allows))
+		return
+	}
 	metrics := h.migrationController.GetMetrics()
 	c.JSON(http.StatusOK, metrics)
 }
 
+// savePreset handles POST /api/v1/presets
+func (h *Handler) savePreset(c *gin.Context) {
+	var preset types.MigrationPreset
+
+	if err := c.ShouldBindJSON(&preset); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	if err := h.migrationController.SavePreset(&preset); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, preset)
+}
+
+// listPresets handles GET /api/v1/presets
+func (h *Handler) listPresets(c *gin.Context) {
+	presets, ok := paginate(c, h.migrationController.ListPresets())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"presets": presets,
+		"count":   len(presets),
+	})
+}
+
+// getPreset handles GET /api/v1/presets/:name
+func (h *Handler) getPreset(c *gin.Context) {
+	preset, err := h.migrationController.GetPreset(c.Param("name"))
+	if err != nil {
+		writeProblemErr(c, http.StatusNotFound, "Preset not found", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preset)
+}
+
+// deletePreset handles DELETE /api/v1/presets/:name
+func (h *Handler) deletePreset(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := h.migrationController.DeletePreset(name); err != nil {
+		writeProblemErr(c, http.StatusNotFound, "Failed to delete preset", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Preset deleted successfully",
+		"name":    name,
+	})
+}
+
+// listWebhooks handles GET /api/v1/config/webhooks
+func (h *Handler) listWebhooks(c *gin.Context) {
+	subscribers, ok := paginate(c, h.migrationController.WebhookSubscribers())
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"subscribers": subscribers,
+		"count":       len(subscribers),
+	})
+}
+
+// replaceWebhooks handles PUT /api/v1/config/webhooks, replacing all subscribers
+// without a restart
+func (h *Handler) replaceWebhooks(c *gin.Context) {
+	var subscribers []types.WebhookSubscriber
+
+	if err := c.ShouldBindJSON(&subscribers); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	if err := h.migrationController.SetWebhookSubscribers(subscribers); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook subscribers updated",
+		"count":   len(subscribers),
+	})
+}
+
+// getClassificationPolicy handles GET /api/v1/config/classification
+func (h *Handler) getClassificationPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, h.migrationController.ClassificationPolicy())
+}
+
+// replaceClassificationPolicy handles PUT /api/v1/config/classification. Fields left
+// out of the body fall back to the defaults, not to the current policy.
+func (h *Handler) replaceClassificationPolicy(c *gin.Context) {
+	policy := types.DefaultClassificationPolicy()
+
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	if err := h.migrationController.SetClassificationPolicy(policy); err != nil {
+		writeProblemErr(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Classification policy updated",
+		"policy":  h.migrationController.ClassificationPolicy(),
+	})
+}
+
 // validateMigrationRequest validates the migration request
 func (h *Handler) validateMigrationRequest(req *types.MigrationRequest) error {
 	if req.PodName == "" {
@@ -170,15 +980,94 @@ func (h *Handler) validateMigrationRequest(req *types.MigrationRequest) error {
 	if req.SourceNode == "" {
 		return fmt.Errorf("source_node is required")
 	}
-	if req.TargetNode == "" {
-		return fmt.Errorf("target_node is required")
+	if err := controller.ValidateTargetNode(req); err != nil {
+		return err
 	}
 	if req.SourceNode == req.TargetNode {
 		return fmt.Errorf("source_node and target_node cannot be the same")
 	}
+	if req.NewPodName != "" {
+		if errs := validation.IsDNS1123Subdomain(req.NewPodName); len(errs) > 0 {
+			return fmt.Errorf("invalid new_pod_name %q: %s", req.NewPodName, strings.Join(errs, "; "))
+		}
+		if req.NewPodName == req.PodName {
+			return fmt.Errorf("new_pod_name must differ from pod_name")
+		}
+		if req.NewPodNameTemplate != "" {
+			return fmt.Errorf("new_pod_name and new_pod_name_template are mutually exclusive")
+		}
+	}
+	if req.ServiceName != "" {
+		if !req.EnsureService {
+			return fmt.Errorf("service_name requires ensure_service")
+		}
+		if errs := validation.IsDNS1035Label(req.ServiceName); len(errs) > 0 {
+			return fmt.Errorf("invalid service_name %q: %s", req.ServiceName, strings.Join(errs, "; "))
+		}
+	}
+	if err := controller.ValidateCommandOverrides(req); err != nil {
+		return err
+	}
+
+	return h.validateMigrationOptions(&req.MigrationOptions)
+}
+
+// validateMigrationOptions validates the options shared by single, batch and drain requests
+func (h *Handler) validateMigrationOptions(req *types.MigrationOptions) error {
 	if req.Timeout < 0 {
 		return fmt.Errorf("timeout must be non-negative")
 	}
+	if req.CheckpointSize != "" {
+		if _, err := resource.ParseQuantity(req.CheckpointSize); err != nil {
+			return fmt.Errorf("invalid checkpoint_size %q: %w", req.CheckpointSize, err)
+		}
+	}
+	if req.CheckpointAccessMode != "" {
+		if err := controller.ValidateAccessMode(req.CheckpointAccessMode); err != nil {
+			return fmt.Errorf("checkpoint_access_mode: %w", err)
+		}
+	}
+	if req.NewPodNameTemplate != "" {
+		if err := controller.ValidatePodNameTemplate(req.NewPodNameTemplate); err != nil {
+			return fmt.Errorf("new_pod_name_template: %w", err)
+		}
+	}
+	if req.Placement != "" && req.Placement != types.PlacementNodeName && req.Placement != types.PlacementScheduler {
+		return fmt.Errorf("placement must be %s or %s", types.PlacementNodeName, types.PlacementScheduler)
+	}
+	if req.WrapInDeployment && req.Placement == types.PlacementNodeName {
+		return fmt.Errorf("wrap_in_deployment always uses placement %s", types.PlacementScheduler)
+	}
+	if req.VerifyContainer != "" && len(req.VerifyCommand) == 0 {
+		return fmt.Errorf("verify_container requires verify_command")
+	}
+	if req.CaptureLogLines < 0 || req.CaptureLogLines > controller.MaxCaptureLogLines {
+		return fmt.Errorf("capture_log_lines must be between 0 and %d", controller.MaxCaptureLogLines)
+	}
+	if err := controller.ValidateContainerGrouping(req.ContainerGrouping); err != nil {
+		return err
+	}
+	if err := controller.ValidateUsageSampling(req); err != nil {
+		return err
+	}
+	if err := controller.ValidateSuccessCriteria(req); err != nil {
+		return err
+	}
+	for _, condition := range req.ReadinessConditions {
+		if condition == "" {
+			return fmt.Errorf("readiness_conditions must not contain empty condition types")
+		}
+	}
+	for _, container := range req.TolerateUnreadyContainers {
+		if container == "" {
+			return fmt.Errorf("tolerate_unready_containers must not contain empty container names")
+		}
+	}
+	for _, container := range req.MigrateCompletedContainers {
+		if container == "" {
+			return fmt.Errorf("migrate_completed_containers must not contain empty container names")
+		}
+	}
 	
 	return nil
 }
@@ -188,19 +1077,17 @@ func (h *Handler) createAutoscaler(c *gin.Context) {
 	var req types.AutoscalingRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+		writeProblemErr(c, http.StatusBadRequest, "Invalid request format", err)
+		return
+	}
+
+	if !requireNamespace(c, req.WorkloadNamespace) {
 		return
 	}
 
 	response, err := h.autoscalingController.CreateAutoscaler(&req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create autoscaler",
-			"details": err.Error(),
-		})
+		writeProblemErr(c, http.StatusInternalServerError, "Failed to create autoscaler", err)
 		return
 	}
 
@@ -213,10 +1100,7 @@ func (h *Handler) getAutoscaler(c *gin.Context) {
 
 	response, err := h.autoscalingController.GetAutoscaler(autoscalerID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Autoscaler not found",
-			"details": err.Error(),
-		})
+		writeProblemErr(c, http.StatusNotFound, "Autoscaler not found", err)
 		return
 	}
 
@@ -229,10 +1113,7 @@ func (h *Handler) deleteAutoscaler(c *gin.Context) {
 
 	err := h.autoscalingController.DeleteAutoscaler(autoscalerID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Failed to delete autoscaler",
-			"details": err.Error(),
-		})
+		writeProblemErr(c, http.StatusNotFound, "Failed to delete autoscaler", err)
 		return
 	}
 
@@ -244,7 +1125,14 @@ func (h *Handler) deleteAutoscaler(c *gin.Context) {
 
 // listAutoscalers handles GET /api/v1/autoscaling
 func (h *Handler) listAutoscalers(c *gin.Context) {
-	autoscalers := h.autoscalingController.ListAutoscalers()
+	var allowed func(string) bool
+	if scope := scopeOf(c); scope != nil {
+		allowed = scope.allows
+	}
+	autoscalers, ok := paginate(c, h.autoscalingController.ListAutoscalers(allowed))
+	if !ok {
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"autoscalers": autoscalers,
 		"count":       len(autoscalers),
@@ -262,7 +1150,8 @@ func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, Authorization, "+requestIDHeader+", "+envelopeHeader)
+		c.Header("Access-Control-Expose-Headers", requestIDHeader)
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -272,3 +1161,40 @@ func corsMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+const (
+	// requestIDHeader carries the correlation ID of a request
+	requestIDHeader = "X-Request-ID"
+	// requestIDKey stores the correlation ID in the gin context
+	requestIDKey = "request_id"
+	// maxRequestIDLength bounds accepted incoming correlation IDs
+	maxRequestIDLength = 128
+)
+
+// requestIDMiddleware takes the correlation ID from the X-Request-ID header, or
+// generates one, and echoes it back in the response header
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Header(requestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// validRequestID accepts short IDs of printable ASCII so they are safe to log
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
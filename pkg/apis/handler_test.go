@@ -0,0 +1,136 @@
+package apis
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/controller"
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/metrics"
+	"ai-storage-orchestrator/pkg/sink"
+	"ai-storage-orchestrator/pkg/types"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestHandler serves the API of a controller on an empty fake cluster
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	client := k8s.NewClientForClientsets(fake.NewSimpleClientset(), metricsfake.NewSimpleClientset())
+	mc, err := controller.NewMigrationController(client, metrics.NewMetricsServerProvider(client), sink.NoopSink{}, controller.DefaultMigrationConfig())
+	if err != nil {
+		t.Fatalf("NewMigrationController: %v", err)
+	}
+	return NewHandler(mc, nil)
+}
+
+// testContext returns a gin context for a request with the given JSON body
+func testContext(body string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c
+}
+
+// A batch child merges its own JSON over its preset, so an explicit false or zero
+// wins like it does for a single migration
+func TestPrepareBatchRequestChildOverridesPreset(t *testing.T) {
+	h := newTestHandler(t)
+	if err := h.migrationController.SavePreset(&types.MigrationPreset{
+		Name:    "gpu",
+		Options: types.MigrationOptions{PreservePV: true, Timeout: 900},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"migrations": [
+		{"pod_name": "a", "pod_namespace": "default", "source_node": "node-a", "target_node": "node-b", "preset": "gpu", "preserve_pv": false},
+		{"pod_name": "b", "pod_namespace": "default", "source_node": "node-a", "target_node": "node-b", "preset": "gpu"}
+	]}`
+	c := testContext(body)
+	var req types.BatchMigrationRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.prepareBatchRequest(&req, c.MustGet(gin.BodyBytesKey).([]byte)); err != nil {
+		t.Fatalf("prepareBatchRequest: %v", err)
+	}
+
+	if req.Migrations[0].PreservePV {
+		t.Error("explicit preserve_pv false of the first child did not override the preset")
+	}
+	if !req.Migrations[1].PreservePV {
+		t.Error("second child did not take preserve_pv from the preset")
+	}
+	for i, child := range req.Migrations {
+		if child.Timeout != 900 {
+			t.Errorf("migrations[%d].timeout = %d, want the preset's 900", i, child.Timeout)
+		}
+	}
+}
+
+// Net savings can go down, so they are gauges, without the _total of a counter
+func TestPrometheusSavingsAreGauges(t *testing.T) {
+	h := newTestHandler(t)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	h.getPrometheusMetrics(c)
+
+	body := recorder.Body.String()
+	for _, name := range []string{"orchestrator_cpu_cores_saved", "orchestrator_memory_bytes_saved", "orchestrator_gpus_saved"} {
+		if !strings.Contains(body, "# TYPE "+name+" gauge\n") {
+			t.Errorf("%s is not exposed as a gauge", name)
+		}
+	}
+	if strings.Contains(body, "_saved_total") {
+		t.Error("savings exposed with the _total suffix of a counter")
+	}
+}
+
+// The one-shot CLI and the HTTP API prepare a request the same way
+func TestParseMigrationRequestMatchesBind(t *testing.T) {
+	h := newTestHandler(t)
+	if err := h.migrationController.SavePreset(&types.MigrationPreset{
+		Name:    "gpu",
+		Options: types.MigrationOptions{PreservePV: true, CheckpointSize: "5Gi"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	body := `{"pod_name": "a", "pod_namespace": "default", "source_node": "node-a", "target_node": "node-b", "preset": "gpu", "preserve_pv": false}`
+
+	parsed, err := h.ParseMigrationRequest([]byte(body))
+	if err != nil {
+		t.Fatalf("ParseMigrationRequest: %v", err)
+	}
+	bound, ok := h.bindMigrationRequest(testContext(body))
+	if !ok {
+		t.Fatal("bindMigrationRequest rejected the request")
+	}
+	if !reflect.DeepEqual(parsed, bound) {
+		t.Errorf("parsed %+v, bound %+v; want the same request", parsed, bound)
+	}
+	if parsed.Timeout != defaultMigrationTimeout || parsed.PreservePV || parsed.CheckpointSize != "5Gi" {
+		t.Errorf("parsed %+v, want the default timeout and the preset under the request's fields", parsed)
+	}
+
+	for body, want := range map[string]string{
+		`{"pod_name": `: "invalid request format",
+		`{"pod_name": "a", "pod_namespace": "default", "source_node": "node-a", "target_node": "node-b", "preset": "none"}`: "invalid preset",
+		`{"pod_name": "a", "pod_namespace": "default", "source_node": "node-a", "target_node": "node-a"}`:                   "validation failed",
+	} {
+		if _, err := h.ParseMigrationRequest([]byte(body)); err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("ParseMigrationRequest(%s) = %v, want %q", body, err, want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"ai-storage-orchestrator/pkg/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// problemContentType is the media type of RFC 7807 error responses
+	problemContentType = "application/problem+json"
+	// problemTypePrefix starts the type URI of every problem; the rest is derived
+	// from the title, so each kind of failure has a stable type
+	problemTypePrefix = "urn:ai-storage-orchestrator:problem:"
+)
+
+// problemDetails is an RFC 7807 error response. Code is an extension member naming
+// the controller error behind the problem, when there is one.
+type problemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+// problemClasses gives controller errors their HTTP status and error code
+var problemClasses = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{controller.ErrDraining, http.StatusServiceUnavailable, "draining"},
+	{controller.ErrPodNameInUse, http.StatusConflict, "pod_name_in_use"},
+	{controller.ErrDrainConflict, http.StatusConflict, "drain_conflict"},
+	{controller.ErrNoMatchingNode, http.StatusUnprocessableEntity, "no_matching_node"},
+	{controller.ErrSnapshotsDisabled, http.StatusUnprocessableEntity, "snapshots_disabled"},
+	{controller.ErrPolicyDenied, http.StatusForbidden, "policy_denied"},
+	{controller.ErrPolicyUnavailable, http.StatusServiceUnavailable, "policy_unavailable"},
+	{controller.ErrRecommendationNotFound, http.StatusNotFound, "recommendation_not_found"},
+	{controller.ErrRecommendationPromoted, http.StatusConflict, "recommendation_promoted"},
+}
+
+// newProblem builds the problem for a request
+func newProblem(c *gin.Context, status int, title, detail string) *problemDetails {
+	return &problemDetails{
+		Type:     problemTypePrefix + strings.ReplaceAll(strings.ToLower(title), " ", "-"),
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Request.URL.RequestURI(),
+	}
+}
+
+// renderProblem writes a problem as application/problem+json
+func renderProblem(c *gin.Context, problem *problemDetails) {
+	c.Header("Content-Type", problemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// writeProblem answers with an RFC 7807 problem
+func writeProblem(c *gin.Context, status int, title, detail string) {
+	renderProblem(c, newProblem(c, status, title, detail))
+}
+
+// writeProblemErr answers with the problem for err. Known controller errors carry
+// their error code, and their own status instead of the given fallback.
+func writeProblemErr(c *gin.Context, fallback int, title string, err error) {
+	problem := newProblem(c, fallback, title, err.Error())
+	for _, class := range problemClasses {
+		if errors.Is(err, class.err) {
+			problem.Status = class.status
+			problem.Code = class.code
+			break
+		}
+	}
+	renderProblem(c, problem)
+}
+
+// abortWithProblem answers with a problem and stops the handler chain
+func abortWithProblem(c *gin.Context, status int, title, detail string) {
+	writeProblem(c, status, title, detail)
+	c.Abort()
+}
+
+// routeNotFound answers unknown routes with a problem instead of gin's plain text
+func routeNotFound(c *gin.Context) {
+	writeProblem(c, http.StatusNotFound, "Route not found", "no endpoint matches "+c.Request.Method+" "+c.Request.URL.Path)
+}
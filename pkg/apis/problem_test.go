@@ -0,0 +1,62 @@
+package apis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Controller errors keep their status and code when wrapped with context
+func TestWriteProblemErrClasses(t *testing.T) {
+	for _, test := range []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{controller.ErrPolicyDenied, http.StatusForbidden, "policy_denied"},
+		{controller.ErrPolicyUnavailable, http.StatusServiceUnavailable, "policy_unavailable"},
+		{controller.ErrDraining, http.StatusServiceUnavailable, "draining"},
+		{controller.ErrPodNameInUse, http.StatusConflict, "pod_name_in_use"},
+		{controller.ErrDrainConflict, http.StatusConflict, "drain_conflict"},
+		{controller.ErrNoMatchingNode, http.StatusUnprocessableEntity, "no_matching_node"},
+		{controller.ErrSnapshotsDisabled, http.StatusUnprocessableEntity, "snapshots_disabled"},
+		{controller.ErrRecommendationNotFound, http.StatusNotFound, "recommendation_not_found"},
+		{controller.ErrRecommendationPromoted, http.StatusConflict, "recommendation_promoted"},
+		{errors.New("connection refused"), http.StatusInternalServerError, ""},
+	} {
+		err := fmt.Errorf("migrations[1]: %w", test.err)
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/migrations?x=1", nil)
+		writeProblemErr(c, http.StatusInternalServerError, "Failed to start migration", err)
+
+		if recorder.Code != test.status {
+			t.Errorf("%v: status %d, want %d", test.err, recorder.Code, test.status)
+		}
+		if contentType := recorder.Header().Get("Content-Type"); contentType != problemContentType {
+			t.Errorf("%v: Content-Type %q, want %q", test.err, contentType, problemContentType)
+		}
+		var problem problemDetails
+		if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("%v: decode problem: %v", test.err, err)
+		}
+		want := problemDetails{
+			Type:     problemTypePrefix + "failed-to-start-migration",
+			Title:    "Failed to start migration",
+			Status:   test.status,
+			Detail:   err.Error(),
+			Instance: "/api/v1/migrations?x=1",
+			Code:     test.code,
+		}
+		if problem != want {
+			t.Errorf("%v: problem %+v, want %+v", test.err, problem, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// prometheusContentType is the Prometheus text exposition format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// prometheusWriter renders metrics in the Prometheus text exposition format
+type prometheusWriter struct {
+	b strings.Builder
+}
+
+func (w *prometheusWriter) metric(name, kind, help string, value float64) {
+	w.header(name, kind, help)
+	fmt.Fprintf(&w.b, "%s %g\n", name, value)
+}
+
+func (w *prometheusWriter) header(name, kind, help string) {
+	fmt.Fprintf(&w.b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// getPrometheusMetrics handles GET /metrics, exposing the migration metrics for scraping
+func (h *Handler) getPrometheusMetrics(c *gin.Context) {
+	metrics := h.migrationController.GetMetrics()
+	w := &prometheusWriter{}
+
+	w.header("orchestrator_migrations_total", "counter", "Finished migrations by result.")
+	fmt.Fprintf(&w.b, "orchestrator_migrations_total{result=\"succeeded\"} %d\n", metrics.SuccessfulMigrations)
+	fmt.Fprintf(&w.b, "orchestrator_migrations_total{result=\"failed\"} %d\n", metrics.FailedMigrations)
+
+	w.metric("orchestrator_migration_average_duration_seconds", "gauge",
+		"Average duration of completed migrations.", metrics.AverageDuration.Seconds())
+	w.metric("orchestrator_new_pod_average_scheduling_latency_seconds", "gauge",
+		"Average time new pods spent Pending before being scheduled.", metrics.AverageSchedulingLatency.Seconds())
+	w.metric("orchestrator_new_pod_average_startup_latency_seconds", "gauge",
+		"Average time from scheduling until new pods' containers started.", metrics.AverageStartupLatency.Seconds())
+	// Net amounts go down when a migrated pod uses more than the original did
+	w.metric("orchestrator_cpu_cores_saved", "gauge",
+		"Net CPU cores reclaimed by completed migrations.", metrics.CPUCoresSaved)
+	w.metric("orchestrator_memory_bytes_saved", "gauge",
+		"Net memory bytes reclaimed by completed migrations.", float64(metrics.MemoryBytesSaved))
+	w.metric("orchestrator_gpus_saved", "gauge",
+		"Net GPUs' worth of utilization reclaimed by completed migrations.", metrics.GPUsSaved)
+	w.metric("orchestrator_source_node_average_cpu_relief_percentage_points", "gauge",
+		"Average drop in source node CPU utilization after completed migrations.", metrics.AverageSourceCPURelief)
+	w.metric("orchestrator_source_node_average_memory_relief_percentage_points", "gauge",
+		"Average drop in source node memory utilization after completed migrations.", metrics.AverageSourceMemoryRelief)
+	w.metric("orchestrator_container_average_cpu_waste_cores", "gauge",
+		"Average requested minus used CPU cores of migrated containers.", metrics.AverageCPUWaste)
+	w.metric("orchestrator_container_average_memory_waste_bytes", "gauge",
+		"Average requested minus used memory bytes of migrated containers.", float64(metrics.AverageMemoryWaste))
+	w.metric("orchestrator_cpu_savings_percentage", "gauge",
+		"CPU savings of the most recent completed migration.", metrics.CPUSavings)
+	w.metric("orchestrator_memory_savings_percentage", "gauge",
+		"Memory savings of the most recent completed migration.", metrics.MemorySavings)
+	w.metric("orchestrator_gpu_savings_percentage", "gauge",
+		"GPU savings of the most recent completed migration of a GPU pod.", metrics.GPUSavings)
+
+	w.metric("orchestrator_effective_concurrency", "gauge",
+		"Migrations allowed to execute at once after adapting to API server throttling.", float64(metrics.EffectiveConcurrency))
+	w.metric("orchestrator_api_throttled_requests_total", "counter",
+		"Kubernetes API requests answered with 429 Too Many Requests.", float64(metrics.APIThrottledRequests))
+	w.metric("orchestrator_log_messages_suppressed_total", "counter",
+		"Migration log lines held back from the process log by sampling.", float64(metrics.LogMessagesSuppressed))
+
+	namespaces := make([]string, 0, len(metrics.ActiveByNamespace))
+	for namespace := range metrics.ActiveByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	w.header("orchestrator_active_migrations", "gauge", "Migrations holding an execution slot, by namespace.")
+	for _, namespace := range namespaces {
+		fmt.Fprintf(&w.b, "orchestrator_active_migrations{namespace=%q} %d\n", namespace, metrics.ActiveByNamespace[namespace])
+	}
+
+	w.header("orchestrator_migration_step_duration_seconds", "histogram",
+		"Duration of successfully completed migration steps.")
+	for _, histogram := range h.migrationController.GetStepDurationHistograms() {
+		for _, bucket := range histogram.Buckets {
+			fmt.Fprintf(&w.b, "orchestrator_migration_step_duration_seconds_bucket{step=%q,le=\"%g\"} %d\n",
+				histogram.Step, bucket.UpperBound.Seconds(), bucket.Count)
+		}
+		fmt.Fprintf(&w.b, "orchestrator_migration_step_duration_seconds_bucket{step=%q,le=\"+Inf\"} %d\n", histogram.Step, histogram.Count)
+		fmt.Fprintf(&w.b, "orchestrator_migration_step_duration_seconds_sum{step=%q} %g\n", histogram.Step, histogram.Sum.Seconds())
+		fmt.Fprintf(&w.b, "orchestrator_migration_step_duration_seconds_count{step=%q} %d\n", histogram.Step, histogram.Count)
+	}
+
+	c.Data(http.StatusOK, prometheusContentType, []byte(w.b.String()))
+}
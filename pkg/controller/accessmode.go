@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ValidateAccessMode checks that mode is a PersistentVolumeClaim access mode
+func ValidateAccessMode(mode string) error {
+	switch corev1.PersistentVolumeAccessMode(mode) {
+	case corev1.ReadWriteOnce, corev1.ReadOnlyMany, corev1.ReadWriteMany, corev1.ReadWriteOncePod:
+		return nil
+	}
+	return fmt.Errorf("invalid access mode %q: must be ReadWriteOnce, ReadOnlyMany, ReadWriteMany or ReadWriteOncePod", mode)
+}
+
+// blockOnlyProvisioners are provisioners known to offer only single-node volumes, so
+// a ReadWriteMany checkpoint PVC on them would never bind
+var blockOnlyProvisioners = map[string]bool{
+	"ebs.csi.aws.com":                 true,
+	"kubernetes.io/aws-ebs":           true,
+	"pd.csi.storage.gke.io":           true,
+	"kubernetes.io/gce-pd":            true,
+	"disk.csi.azure.com":              true,
+	"kubernetes.io/azure-disk":        true,
+	"cinder.csi.openstack.org":        true,
+	"kubernetes.io/cinder":            true,
+	"rancher.io/local-path":           true,
+	"kubernetes.io/no-provisioner":    true,
+	"rbd.csi.ceph.com":                true,
+	"kubernetes.io/rbd":               true,
+	"topolvm.io":                      true,
+	"local.csi.openebs.io":            true,
+	"openebs.io/local":                true,
+	"diskplugin.csi.alibabacloud.com": true,
+}
+
+// checkCheckpointAccessMode fails if the checkpoint access mode is too new for the
+// cluster, or needs multi-node access that the default storage class is known not
+// to provide. Unknown provisioners are given the benefit of the doubt.
+func (mc *MigrationController) checkCheckpointAccessMode(ctx context.Context, job *MigrationJob, mode corev1.PersistentVolumeAccessMode) error {
+	if mode == corev1.ReadWriteOncePod {
+		if err := mc.requireFeature(FeatureReadWriteOncePod); err != nil {
+			return fmt.Errorf("checkpoint access mode %s: %w", mode, err)
+		}
+		return nil
+	}
+	if mode != corev1.ReadWriteMany && mode != corev1.ReadOnlyMany {
+		return nil
+	}
+
+	class, err := mc.k8sClient.GetDefaultStorageClass(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up default storage class: %w", err)
+	}
+	if class == nil {
+		mc.logf(job, "Warning: No default storage class found, cannot verify %s support", mode)
+		return nil
+	}
+	if blockOnlyProvisioners[class.Provisioner] {
+		return fmt.Errorf("checkpoint access mode %s is not supported by default storage class %s (provisioner %s)",
+			mode, class.Name, class.Provisioner)
+	}
+	return nil
+}
+
+// checkpointAccessMode returns the access mode requested for the job's checkpoint,
+// falling back to the configured default
+func (mc *MigrationController) checkpointAccessMode(job *MigrationJob) corev1.PersistentVolumeAccessMode {
+	if job.Request.CheckpointAccessMode != "" {
+		return corev1.PersistentVolumeAccessMode(job.Request.CheckpointAccessMode)
+	}
+	return mc.config.checkpointAccessMode
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkNodeArchitecture fails if the target node's CPU architecture or OS differs
+// from the source node's, since single-arch images would crash-loop there with exec
+// format errors. Requests for multi-arch images can opt out with allow_arch_mismatch.
+// Nodes without the well-known labels are not compared.
+func (mc *MigrationController) checkNodeArchitecture(ctx context.Context, sourceNode, targetNode string) error {
+	source, err := mc.k8sClient.GetNode(ctx, sourceNode)
+	if err != nil {
+		return fmt.Errorf("failed to get source node: %w", err)
+	}
+	target, err := mc.k8sClient.GetNode(ctx, targetNode)
+	if err != nil {
+		return fmt.Errorf("failed to get target node: %w", err)
+	}
+
+	for _, label := range []string{corev1.LabelArchStable, corev1.LabelOSStable} {
+		sourceValue, sourceSet := source.Labels[label]
+		targetValue, targetSet := target.Labels[label]
+		if sourceSet && targetSet && sourceValue != targetValue {
+			return fmt.Errorf("target node %s has %s=%s but source node %s has %s=%s; set allow_arch_mismatch for multi-arch images",
+				targetNode, label, targetValue, sourceNode, label, sourceValue)
+		}
+	}
+	return nil
+}
@@ -389,13 +389,29 @@ func (ac *AutoscalingController) GetMetrics() *types.AutoscalingMetrics {
 	return &metrics
 }
 
-// ListAutoscalers returns all autoscalers
-func (ac *AutoscalingController) ListAutoscalers() []*types.AutoscalingResponse {
+// AutoscalerNamespace returns the namespace of the autoscaled workload
+func (ac *AutoscalingController) AutoscalerNamespace(autoscalerID string) (string, bool) {
+	ac.autoscalersMux.RLock()
+	defer ac.autoscalersMux.RUnlock()
+
+	job, exists := ac.autoscalers[autoscalerID]
+	if !exists {
+		return "", false
+	}
+	return job.Request.WorkloadNamespace, true
+}
+
+// ListAutoscalers returns the autoscalers of the namespaces accepted by allowed, or
+// all autoscalers when allowed is nil
+func (ac *AutoscalingController) ListAutoscalers(allowed func(namespace string) bool) []*types.AutoscalingResponse {
 	ac.autoscalersMux.RLock()
 	defer ac.autoscalersMux.RUnlock()
 
 	result := make([]*types.AutoscalingResponse, 0, len(ac.autoscalers))
 	for _, job := range ac.autoscalers {
+		if allowed != nil && !allowed(job.Request.WorkloadNamespace) {
+			continue
+		}
 		result = append(result, &types.AutoscalingResponse{
 			AutoscalingID: job.ID,
 			Status:        job.Status,
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"log"
+	"time"
+)
+
+// runBackpressure adapts the execution slots to API server throttling. Every interval
+// it counts the requests the API server answered with 429: at or above the threshold
+// the slots are halved, down to one, and after a quiet interval they grow back by one
+// until they reach the configured maximum. Running migrations are never stopped; a
+// lower limit only holds back the queued ones.
+func (mc *MigrationController) runBackpressure() {
+	ticker := time.NewTicker(mc.config.backpressureInterval)
+	defer ticker.Stop()
+
+	last := mc.k8sClient.ThrottledRequests()
+	for range ticker.C {
+		total := mc.k8sClient.ThrottledRequests()
+		throttled := total - last
+		last = total
+
+		limit := mc.slots.currentLimit()
+		next := backpressureLimit(limit, throttled, mc.config.backpressureThreshold, mc.config.maxConcurrentMigrations)
+		if next == limit {
+			continue
+		}
+
+		mc.slots.setLimit(next)
+		if next < limit {
+			log.Printf("Backpressure: API server throttled %d requests in %s, lowering concurrent migrations from %d to %d",
+				throttled, mc.config.backpressureInterval, limit, next)
+		} else {
+			log.Printf("Backpressure: API server throttling subsided, raising concurrent migrations from %d to %d", limit, next)
+		}
+	}
+}
+
+// backpressureLimit returns the execution slots for the next interval given the
+// requests throttled in the last one: half of limit, at least one, at or above the
+// threshold, one more, at most maximum, after a quiet interval, and limit otherwise
+func backpressureLimit(limit int, throttled int64, threshold, maximum int) int {
+	switch {
+	case throttled >= int64(threshold):
+		return max(limit/2, 1)
+	case throttled == 0:
+		return min(limit+1, maximum)
+	}
+	return limit
+}
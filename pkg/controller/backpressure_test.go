@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBackpressureLimit(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		limit     int
+		throttled int64
+		want      int
+	}{
+		{name: "throttled at the threshold halves", limit: 8, throttled: 5, want: 4},
+		{name: "throttled above the threshold halves", limit: 5, throttled: 50, want: 2},
+		{name: "halving stops at one", limit: 1, throttled: 50, want: 1},
+		{name: "quiet interval grows by one", limit: 4, want: 5},
+		{name: "growth stops at the maximum", limit: 10, want: 10},
+		{name: "limit above a lowered maximum is capped", limit: 12, want: 10},
+		{name: "some throttling below the threshold holds", limit: 4, throttled: 4, want: 4},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := backpressureLimit(test.limit, test.throttled, 5, 10); got != test.want {
+				t.Errorf("backpressureLimit(%d, %d, 5, 10) = %d, want %d", test.limit, test.throttled, got, test.want)
+			}
+		})
+	}
+}
+
+// slotJob returns a distinct job; the scheduler has no namespace caps in these tests
+func slotJob(i int) *MigrationJob {
+	job := testJob()
+	job.ID = fmt.Sprintf("migration-%d", i)
+	return job
+}
+
+func TestSlotSchedulerSetLimit(t *testing.T) {
+	s := newSlotScheduler(3, 0, nil, nil)
+	var running []*MigrationJob
+	for i := 0; i < 3; i++ {
+		job := slotJob(i)
+		if err := s.acquire(context.Background(), job); err != nil {
+			t.Fatal(err)
+		}
+		running = append(running, job)
+	}
+
+	// Lowering the limit stops nothing that runs
+	s.setLimit(1)
+	s.mu.Lock()
+	held := len(s.running)
+	s.mu.Unlock()
+	if held != 3 {
+		t.Fatalf("%d jobs hold a slot after lowering the limit, want the 3 running", held)
+	}
+
+	granted := make(chan *MigrationJob, 2)
+	for i := 3; i < 5; i++ {
+		job := slotJob(i)
+		go func() {
+			if err := s.acquire(context.Background(), job); err == nil {
+				granted <- job
+			}
+		}()
+	}
+	waitForWaiters := func(n int) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			s.mu.Lock()
+			waiting := len(s.waiting)
+			s.mu.Unlock()
+			if waiting == n {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%d jobs waiting, want %d", waiting, n)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waitForWaiters(2)
+
+	// Running jobs finishing under the lowered limit free no slot for the queue
+	s.release(running[0])
+	s.release(running[1])
+	select {
+	case job := <-granted:
+		t.Fatalf("%s granted a slot with 1 running at limit 1", job.ID)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Raising the limit dispatches the queued jobs right away
+	s.setLimit(3)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-granted:
+		case <-time.After(10 * time.Second):
+			t.Fatal("queued job not dispatched after raising the limit")
+		}
+	}
+	if limit := s.currentLimit(); limit != 3 {
+		t.Errorf("currentLimit = %d, want 3", limit)
+	}
+}
@@ -0,0 +1,377 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// defaultBatchMaxUnavailable applies when a batch does not set max_unavailable
+var defaultBatchMaxUnavailable = intstr.FromString("25%")
+
+// batchJob tracks a batch migration; fields are guarded by batchesMux
+type batchJob struct {
+	ID             string
+	Request        *types.BatchMigrationRequest
+	MaxUnavailable int
+	PodDelay       time.Duration
+	Source         string
+	StartTime      time.Time
+	EndTime        *time.Time
+	children       []*batchChild // in request order
+	order          []*batchChild // in the order they may start
+}
+
+// batchChild is one migration of a batch; job is nil while the child is queued
+type batchChild struct {
+	request   *types.MigrationRequest
+	selection *types.NodeSelection // how the target node was picked, if by selector
+	job       *MigrationJob
+	deps      []*batchChild // must complete before this child starts
+	skipped   string        // why the child will never start
+}
+
+// ResolveMaxUnavailable turns a batch's max_unavailable into a pod count for a batch
+// of the given size. Percentages round down, and at least one pod may be unavailable.
+func ResolveMaxUnavailable(value *intstr.IntOrString, total int) (int, error) {
+	if value == nil {
+		value = &defaultBatchMaxUnavailable
+	}
+	count, err := intstr.GetScaledValueFromIntOrPercent(value, total, false)
+	if err != nil {
+		return 0, fmt.Errorf("invalid max_unavailable %q: %w", value.String(), err)
+	}
+	if count < 0 {
+		return 0, fmt.Errorf("max_unavailable must be non-negative, got %q", value.String())
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count, nil
+}
+
+// StartBatch starts a batch migration. Children are launched in order while fewer
+// than MaxUnavailable of them are mid-migration, independently of the global
+// concurrency limit, so a batch never takes down too much of an application at once.
+func (mc *MigrationController) StartBatch(req *types.BatchMigrationRequest) (*types.BatchMigrationResponse, error) {
+	return mc.startBatch(req, "")
+}
+
+// startBatch starts a batch, recording what it was created for
+func (mc *MigrationController) startBatch(req *types.BatchMigrationRequest, source string) (*types.BatchMigrationResponse, error) {
+	if err := mc.acceptingWork(); err != nil {
+		return nil, err
+	}
+	if len(req.Migrations) == 0 {
+		return nil, fmt.Errorf("a batch needs at least one migration")
+	}
+	maxUnavailable, err := ResolveMaxUnavailable(req.MaxUnavailable, len(req.Migrations))
+	if err != nil {
+		return nil, err
+	}
+	if req.PodDelay < 0 {
+		return nil, fmt.Errorf("pod_delay must be non-negative, got %d", req.PodDelay)
+	}
+
+	order, deps, err := ResolveBatchOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*types.MigrationRequest, len(req.Migrations))
+	selections := make([]*types.NodeSelection, len(req.Migrations))
+	for i := range req.Migrations {
+		child, selection, err := mc.resolveTargetNode(&req.Migrations[i])
+		if err != nil {
+			return nil, fmt.Errorf("migrations[%d]: %w", i, err)
+		}
+		if err := mc.checkSnapshotConfigured(child); err != nil {
+			return nil, fmt.Errorf("migrations[%d]: %w", i, err)
+		}
+		requests[i], selections[i] = child, selection
+	}
+
+	batch := &batchJob{
+		ID:             fmt.Sprintf("batch-%s", uuid.New().String()[:8]),
+		Request:        req,
+		MaxUnavailable: maxUnavailable,
+		PodDelay:       time.Duration(req.PodDelay) * time.Second,
+		Source:         source,
+		StartTime:      time.Now(),
+	}
+	for i := range req.Migrations {
+		batch.children = append(batch.children, &batchChild{request: requests[i], selection: selections[i]})
+	}
+	for i, child := range batch.children {
+		for _, dep := range deps[i] {
+			child.deps = append(child.deps, batch.children[dep])
+		}
+	}
+	for _, i := range order {
+		batch.order = append(batch.order, batch.children[i])
+	}
+
+	mc.batchesMux.Lock()
+	mc.batches[batch.ID] = batch
+	mc.batchesMux.Unlock()
+
+	log.Printf("Batch %s: migrating %d pods with at most %d unavailable, %s between starts",
+		batch.ID, len(batch.children), maxUnavailable, batch.PodDelay)
+	go mc.runBatch(batch)
+
+	return mc.GetBatch(batch.ID)
+}
+
+// runBatch launches the batch's children in dependency order, waiting for one to
+// finish whenever the number of unavailable children reaches the limit, and spacing
+// starts by PodDelay. A child starts once its dependencies completed, and is skipped
+// if one of them did not.
+func (mc *MigrationController) runBatch(batch *batchJob) {
+	finished := make(chan struct{}, len(batch.children))
+
+	var lastStart time.Time
+	pending := append([]*batchChild{}, batch.order...)
+	for len(pending) > 0 {
+		next := -1
+		for i := 0; i < len(pending); i++ {
+			ready, failed := mc.batchDependenciesSettled(pending[i])
+			if failed != "" {
+				mc.skipBatchChild(batch, pending[i], failed)
+				pending = append(pending[:i], pending[i+1:]...)
+				i--
+				continue
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		if next < 0 {
+			// Every remaining child waits for a dependency that is still migrating
+			<-finished
+			continue
+		}
+		child := pending[next]
+		pending = append(pending[:next], pending[next+1:]...)
+
+		if !lastStart.IsZero() {
+			if wait := batch.PodDelay - time.Since(lastStart); wait > 0 {
+				if err := sleepContext(mc.stopped, wait); err != nil {
+					// Nothing more starts once the orchestrator stopped
+					for _, rest := range append(pending, child) {
+						mc.skipBatchChild(batch, rest, "orchestrator stopped")
+					}
+					break
+				}
+			}
+		}
+		for mc.batchUnavailable(batch) >= batch.MaxUnavailable {
+			<-finished
+		}
+		lastStart = time.Now()
+
+		// The policy judges the child as it starts, which may be long after submission
+		decision, err := mc.checkPolicy(child.request, child.selection)
+		if err != nil {
+			mc.skipBatchChild(batch, child, err.Error())
+			continue
+		}
+		job, err := mc.launchMigration(child.request, child.selection, decision)
+		if err != nil {
+			mc.skipBatchChild(batch, child, err.Error())
+			continue
+		}
+		mc.batchesMux.Lock()
+		child.job = job
+		mc.batchesMux.Unlock()
+		mc.logf(job, "Started as part of batch %s", batch.ID)
+
+		go func() {
+			<-job.done
+			finished <- struct{}{}
+		}()
+	}
+
+	for _, child := range batch.children {
+		if child.job != nil {
+			<-child.job.done
+		}
+	}
+
+	endTime := time.Now()
+	mc.batchesMux.Lock()
+	batch.EndTime = &endTime
+	mc.batchesMux.Unlock()
+	log.Printf("Batch %s: all %d migrations finished", batch.ID, len(batch.children))
+}
+
+// batchDependenciesSettled reports whether all dependencies of a child completed, or
+// why one of them never will
+func (mc *MigrationController) batchDependenciesSettled(child *batchChild) (ready bool, failed string) {
+	mc.batchesMux.RLock()
+	defer mc.batchesMux.RUnlock()
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	ready = true
+	for _, dep := range child.deps {
+		name := batchPodName(dep.request)
+		if dep.skipped != "" {
+			return false, fmt.Sprintf("dependency %s was skipped", name)
+		}
+		if dep.job == nil {
+			ready = false
+			continue
+		}
+		select {
+		case <-dep.job.done:
+			if !dep.job.Status.Succeeded() {
+				return false, fmt.Sprintf("dependency %s ended %s", name, dep.job.Status)
+			}
+		default:
+			ready = false
+		}
+	}
+	return ready, ""
+}
+
+// skipBatchChild gives up on a child that can never start
+func (mc *MigrationController) skipBatchChild(batch *batchJob, child *batchChild, reason string) {
+	mc.batchesMux.Lock()
+	child.skipped = reason
+	mc.batchesMux.Unlock()
+	log.Printf("Batch %s: skipping %s: %s", batch.ID, batchPodName(child.request), reason)
+}
+
+// batchUnavailable counts the children that are mid-migration
+func (mc *MigrationController) batchUnavailable(batch *batchJob) int {
+	mc.batchesMux.RLock()
+	defer mc.batchesMux.RUnlock()
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	return mc.batchUnavailableLocked(batch)
+}
+
+// batchUnavailableLocked counts the children whose pod is down or may still go down:
+// its original deleted while the new pod is not ready yet. Children only start while
+// few enough are, so a child counts from its start until its step shows the window
+// is over or will never open. Both batchesMux and migrationsMux must be held.
+func (mc *MigrationController) batchUnavailableLocked(batch *batchJob) int {
+	unavailable := 0
+	for _, child := range batch.children {
+		if child.job != nil && mc.childMayBeUnavailableLocked(child.job) {
+			unavailable++
+		}
+	}
+	return unavailable
+}
+
+// childMayBeUnavailableLocked decides from its step whether a migration's pod is or
+// may yet be down. Safe mode never deletes the original, and otherwise the original
+// is only deleted before the new pod is ready with delete_original_first, which is
+// known once the pod was captured. migrationsMux must be held.
+func (mc *MigrationController) childMayBeUnavailableLocked(job *MigrationJob) bool {
+	select {
+	case <-job.done:
+		return false
+	default:
+	}
+	if mc.config.safeMode {
+		return false
+	}
+	switch job.Details.CurrentStep {
+	case "", stepCaptureState:
+		// Not planned yet
+		return true
+	case stepCheckpoint, stepPrePull, stepCreatePod:
+		return job.Details.DeleteOriginalFirst
+	default:
+		// The new pod is ready
+		return false
+	}
+}
+
+// BatchNamespaces returns the namespaces of the batch's pods, nil for an unknown batch
+func (mc *MigrationController) BatchNamespaces(batchID string) []string {
+	mc.batchesMux.RLock()
+	defer mc.batchesMux.RUnlock()
+
+	batch, exists := mc.batches[batchID]
+	if !exists {
+		return nil
+	}
+	namespaces := make([]string, 0, len(batch.children))
+	for _, child := range batch.children {
+		namespaces = append(namespaces, child.request.PodNamespace)
+	}
+	return namespaces
+}
+
+// GetBatch returns the current state of a batch migration
+func (mc *MigrationController) GetBatch(batchID string) (*types.BatchMigrationResponse, error) {
+	mc.batchesMux.RLock()
+	defer mc.batchesMux.RUnlock()
+
+	batch, exists := mc.batches[batchID]
+	if !exists {
+		return nil, fmt.Errorf("batch %s not found", batchID)
+	}
+
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	response := &types.BatchMigrationResponse{
+		BatchID:        batch.ID,
+		Status:         types.BatchStatusRunning,
+		MaxUnavailable: batch.MaxUnavailable,
+		Unavailable:    mc.batchUnavailableLocked(batch),
+		PodDelay:       int(batch.PodDelay / time.Second),
+		Source:         batch.Source,
+		StartTime:      batch.StartTime,
+		EndTime:        batch.EndTime,
+	}
+	for _, child := range batch.order {
+		response.Order = append(response.Order, batchPodName(child.request))
+	}
+
+	failed := false
+	for _, child := range batch.children {
+		entry := types.BatchMigrationChild{
+			PodName:       child.request.PodName,
+			PodNamespace:  child.request.PodNamespace,
+			SkippedReason: child.skipped,
+		}
+		for _, dep := range child.deps {
+			entry.DependsOn = append(entry.DependsOn, batchPodName(dep.request))
+		}
+		if child.skipped != "" {
+			failed = true
+		}
+		if child.job != nil {
+			entry.MigrationID = child.job.ID
+			entry.Status = child.job.Status
+			entry.CurrentStep = child.job.Details.CurrentStep
+			if child.job.Status == types.MigrationStatusFailed || child.job.Status == types.MigrationStatusCancelled {
+				failed = true
+			}
+		}
+		response.Children = append(response.Children, entry)
+	}
+
+	if batch.EndTime != nil {
+		response.Status = types.BatchStatusCompleted
+		if failed {
+			response.Status = types.BatchStatusFailed
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,38 @@
+package controller
+
+import "testing"
+
+func TestChildMayBeUnavailable(t *testing.T) {
+	for _, test := range []struct {
+		name                string
+		safeMode            bool
+		step                string
+		deleteOriginalFirst bool
+		done                bool
+		want                bool
+	}{
+		{name: "queued", step: "", want: true},
+		{name: "capturing", step: stepCaptureState, want: true},
+		{name: "checkpointing with the original kept", step: stepCheckpoint, want: false},
+		{name: "creating with the original kept", step: stepCreatePod, want: false},
+		{name: "checkpointing before deleting the original", step: stepCheckpoint, deleteOriginalFirst: true, want: true},
+		{name: "creating after deleting the original", step: stepCreatePod, deleteOriginalFirst: true, want: true},
+		{name: "verifying the ready new pod", step: stepVerify, deleteOriginalFirst: true, want: false},
+		{name: "cutting over", step: stepCutover, want: false},
+		{name: "finished", step: stepCreatePod, deleteOriginalFirst: true, done: true, want: false},
+		{name: "safe mode", safeMode: true, step: stepCaptureState, want: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			mc, _ := newTestController(t, func(config *MigrationConfig) { config.SafeMode = test.safeMode })
+			job := testJob()
+			job.Details.CurrentStep = test.step
+			job.Details.DeleteOriginalFirst = test.deleteOriginalFirst
+			if test.done {
+				close(job.done)
+			}
+			if got := mc.childMayBeUnavailableLocked(job); got != test.want {
+				t.Errorf("childMayBeUnavailableLocked = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
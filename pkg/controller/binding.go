@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// checkpointBindTimeout bounds the wait for an Immediate checkpoint PVC to bind
+const checkpointBindTimeout = 2 * time.Minute
+
+// planCheckpointBinding decides how the checkpoint PVC is placed. The PVC uses the
+// default storage class. With WaitForFirstConsumer binding, the volume is normally
+// provisioned where the scheduler puts the first pod using it; a new pod pinned by
+// nodeName never passes the scheduler, so the PVC would stay Pending forever. The
+// target node is therefore handed to the provisioner up front, which also keeps a
+// scheduler-placed pod (pinned to the target by node affinity) consistent with it.
+func (mc *MigrationController) planCheckpointBinding(ctx context.Context, job *MigrationJob) (*types.CheckpointBinding, error) {
+	class, err := mc.k8sClient.GetDefaultStorageClass(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up default storage class: %w", err)
+	}
+	if class == nil {
+		return &types.CheckpointBinding{
+			Outcome: types.CheckpointBindingUnverified,
+			Message: "no default storage class, the PVC binds to a matching pre-provisioned volume if any",
+		}, nil
+	}
+
+	// An unset binding mode means Immediate
+	mode := storagev1.VolumeBindingImmediate
+	if class.VolumeBindingMode != nil {
+		mode = *class.VolumeBindingMode
+	}
+	binding := &types.CheckpointBinding{
+		StorageClass: class.Name,
+		BindingMode:  string(mode),
+	}
+	if mode == storagev1.VolumeBindingWaitForFirstConsumer {
+		binding.SelectedNode = job.Request.TargetNode
+	}
+	return binding, nil
+}
+
+// verifyCheckpointBinding completes the binding record once the PVC exists. A
+// WaitForFirstConsumer PVC stays Pending until the new pod uses it, so there is
+// nothing to wait for. An Immediate PVC is bound wherever the provisioner chose;
+// volumes with node affinity (local or zonal storage) must be reachable from the
+// target node, or the new pod could never mount the checkpoint.
+func (mc *MigrationController) verifyCheckpointBinding(ctx context.Context, job *MigrationJob, claimName string, binding *types.CheckpointBinding) error {
+	if binding.StorageClass == "" {
+		return nil
+	}
+	if binding.SelectedNode != "" {
+		binding.Outcome = types.CheckpointBindingDeferred
+		binding.Message = fmt.Sprintf("volume is provisioned for node %s when the new pod starts", binding.SelectedNode)
+		return nil
+	}
+
+	pv, err := mc.k8sClient.WaitForPersistentVolumeClaimBound(ctx, job.Request.PodNamespace, claimName, checkpointBindTimeout)
+	if err != nil {
+		return err
+	}
+	binding.VolumeName = pv.Name
+
+	node, err := mc.k8sClient.GetNode(ctx, job.Request.TargetNode)
+	if err != nil {
+		return fmt.Errorf("failed to get target node %s: %w", job.Request.TargetNode, err)
+	}
+	accessible, err := k8s.VolumeAccessibleFrom(pv, node)
+	if err != nil {
+		return fmt.Errorf("failed to check node affinity of volume %s: %w", pv.Name, err)
+	}
+	if !accessible {
+		return fmt.Errorf("checkpoint volume %s of storage class %s is not reachable from target node %s; use a WaitForFirstConsumer class for topology-constrained storage",
+			pv.Name, binding.StorageClass, job.Request.TargetNode)
+	}
+
+	binding.Outcome = types.CheckpointBindingBound
+	binding.Message = fmt.Sprintf("volume %s is reachable from node %s", pv.Name, job.Request.TargetNode)
+	return nil
+}
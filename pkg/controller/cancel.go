@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// CancelMigrationsToNode cancels every pending or running migration whose target is
+// node and returns the cancelled migration IDs. Each cancelled migration stops at its
+// next step, rolls back what it created and ends as cancelled. Migrations already
+// cutting over are left to finish.
+func (mc *MigrationController) CancelMigrationsToNode(node string) []string {
+	var cancelled []*MigrationJob
+
+	mc.migrationsMux.Lock()
+	for _, job := range mc.migrations {
+		if job.Request.TargetNode != node {
+			continue
+		}
+		if mc.requestCancelLocked(job, fmt.Sprintf("migrations to node %s were cancelled", node)) {
+			cancelled = append(cancelled, job)
+		}
+	}
+	mc.migrationsMux.Unlock()
+
+	ids := make([]string, 0, len(cancelled))
+	for _, job := range cancelled {
+		mc.logf(job, "Cancellation requested: migrations to node %s were cancelled", node)
+		ids = append(ids, job.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// CancelMigration cancels a single pending or running migration. Like
+// CancelMigrationsToNode, the migration rolls back and ends as cancelled.
+func (mc *MigrationController) CancelMigration(migrationID, reason string) error {
+	mc.migrationsMux.Lock()
+	job, exists := mc.migrations[migrationID]
+	if !exists {
+		mc.migrationsMux.Unlock()
+		return fmt.Errorf("migration %s not found", migrationID)
+	}
+	if !mc.requestCancelLocked(job, reason) {
+		status := job.Status
+		mc.migrationsMux.Unlock()
+		return fmt.Errorf("migration %s cannot be cancelled in status %s", migrationID, status)
+	}
+	mc.migrationsMux.Unlock()
+
+	mc.logf(job, "Cancellation requested: %s", reason)
+	return nil
+}
+
+// requestCancelLocked records the reason and cancels the job's context if the job is
+// still pending or running and not already cancelled; migrationsMux must be held
+func (mc *MigrationController) requestCancelLocked(job *MigrationJob, reason string) bool {
+	if job.cancelReason != "" {
+		return false
+	}
+	if job.Status != types.MigrationStatusPending && job.Status != types.MigrationStatusRunning {
+		return false
+	}
+	job.cancelReason = reason
+	if job.cancel != nil {
+		job.cancel()
+	}
+	return true
+}
+
+// beginCutover moves a job to awaiting_cutover unless it was cancelled. Holding
+// migrationsMux for both makes the check and the transition atomic with respect
+// to cancellation.
+func (mc *MigrationController) beginCutover(job *MigrationJob) error {
+	mc.migrationsMux.Lock()
+	defer mc.migrationsMux.Unlock()
+
+	if job.cancelReason != "" {
+		return fmt.Errorf("cancelled: %s", job.cancelReason)
+	}
+	return mc.transitionLocked(job, types.MigrationStatusAwaitingCutover)
+}
+
+// cancellationReason returns why the job was cancelled, or "" if it was not
+func (mc *MigrationController) cancellationReason(job *MigrationJob) string {
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+	return job.cancelReason
+}
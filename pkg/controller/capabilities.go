@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// Features probed on the cluster, each gating a behavior of the orchestrator
+const (
+	FeatureReadWriteOncePod = "read_write_once_pod"
+	FeaturePolicyV1         = "policy_v1"
+)
+
+// A failed probe is retried after capabilityProbeBackoff, doubling with every
+// further failure up to maxCapabilityProbeBackoff
+const (
+	capabilityProbeBackoff    = 10 * time.Second
+	maxCapabilityProbeBackoff = 5 * time.Minute
+)
+
+// clusterFeature describes how a feature is detected: from the server version, from
+// a served API group version, or both
+type clusterFeature struct {
+	name         string
+	minVersion   string
+	groupVersion string
+}
+
+// clusterFeatures are probed in this order. Feature gates cannot be read through the
+// API, so version-based features assume the gate's default for that release.
+var clusterFeatures = []clusterFeature{
+	{name: FeatureReadWriteOncePod, minVersion: "1.27"}, // beta, enabled by default
+	{name: FeaturePolicyV1, groupVersion: "policy/v1"},
+}
+
+// ClusterConnection returns the cluster the controller operates against
+func (mc *MigrationController) ClusterConnection() types.ClusterConnection {
+	return mc.k8sClient.Connection()
+}
+
+// ClusterCapabilities returns the cached result of probing the API server. A failed
+// probe is tried again by the first call after its backoff.
+func (mc *MigrationController) ClusterCapabilities() *types.ClusterCapabilities {
+	mc.capabilitiesMux.Lock()
+	defer mc.capabilitiesMux.Unlock()
+
+	if mc.capabilities == nil || (mc.capabilities.Error != "" && !time.Now().Before(mc.probeRetryAt)) {
+		mc.capabilities = mc.probeCapabilities()
+		if mc.capabilities.Error == "" {
+			mc.probeFailures = 0
+		} else {
+			mc.probeFailures++
+			mc.probeRetryAt = mc.capabilities.ProbedAt.Add(probeBackoff(mc.probeFailures))
+		}
+	}
+	capabilities := *mc.capabilities
+	capabilities.Features = append([]types.ClusterFeature(nil), mc.capabilities.Features...)
+	return &capabilities
+}
+
+// probeCapabilities asks the API server for its version and served API groups
+func (mc *MigrationController) probeCapabilities() *types.ClusterCapabilities {
+	capabilities := &types.ClusterCapabilities{ProbedAt: time.Now()}
+
+	info, err := mc.k8sClient.GetServerVersion()
+	if err != nil {
+		capabilities.Error = fmt.Sprintf("failed to get server version: %v", err)
+		log.Printf("Cluster capability probe: %s", capabilities.Error)
+		return capabilities
+	}
+	capabilities.ServerVersion = info.GitVersion
+	capabilities.Platform = info.Platform
+
+	serverVersion, err := utilversion.ParseGeneric(info.GitVersion)
+	if err != nil {
+		capabilities.Error = fmt.Sprintf("failed to parse server version %q: %v", info.GitVersion, err)
+		log.Printf("Cluster capability probe: %s", capabilities.Error)
+		return capabilities
+	}
+
+	served, err := mc.k8sClient.GetServerGroupVersions()
+	if err != nil {
+		capabilities.Error = fmt.Sprintf("failed to list API groups: %v", err)
+		log.Printf("Cluster capability probe: %s", capabilities.Error)
+		return capabilities
+	}
+
+	for _, feature := range clusterFeatures {
+		result := types.ClusterFeature{Name: feature.name, Available: true}
+		if feature.minVersion != "" && serverVersion.LessThan(utilversion.MustParseGeneric(feature.minVersion)) {
+			result.Available = false
+			result.Reason = fmt.Sprintf("requires Kubernetes %s or newer, cluster runs %s", feature.minVersion, info.GitVersion)
+		}
+		if feature.groupVersion != "" && !served[feature.groupVersion] {
+			result.Available = false
+			result.Reason = fmt.Sprintf("API %s is not served by the cluster", feature.groupVersion)
+		}
+		capabilities.Features = append(capabilities.Features, result)
+	}
+
+	log.Printf("Cluster capability probe: Kubernetes %s on %s", info.GitVersion, info.Platform)
+	return capabilities
+}
+
+// requireFeature returns an error explaining why the cluster lacks a feature. When
+// the cluster could not be probed the feature is assumed to be available, so an
+// unreachable discovery endpoint never blocks a migration on its own.
+func (mc *MigrationController) requireFeature(name string) error {
+	capabilities := mc.ClusterCapabilities()
+	for _, feature := range capabilities.Features {
+		if feature.Name == name && !feature.Available {
+			return fmt.Errorf("cluster does not support %s: %s", name, feature.Reason)
+		}
+	}
+	return nil
+}
+
+// probeBackoff returns how long to wait before probing again after failures
+// consecutive failed probes
+func probeBackoff(failures int) time.Duration {
+	backoff := capabilityProbeBackoff
+	for i := 1; i < failures && backoff < maxCapabilityProbeBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxCapabilityProbeBackoff {
+		return maxCapabilityProbeBackoff
+	}
+	return backoff
+}
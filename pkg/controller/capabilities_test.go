@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// reprobe drops the cached probe so that the next call probes the fake cluster as
+// it is now. Call settleProbe before changing the fake cluster.
+func reprobe(mc *MigrationController) {
+	mc.capabilitiesMux.Lock()
+	mc.capabilities = nil
+	mc.probeFailures = 0
+	mc.probeRetryAt = time.Time{}
+	mc.capabilitiesMux.Unlock()
+}
+
+// settleProbe waits for the probe the controller starts with, so that it does not
+// run against a fake cluster being changed
+func settleProbe(mc *MigrationController) {
+	mc.ClusterCapabilities()
+}
+
+func TestClusterCapabilitiesGateFeatures(t *testing.T) {
+	mc, _ := newTestController(t, nil)
+
+	capabilities := mc.ClusterCapabilities()
+	if capabilities.Error != "" || capabilities.ServerVersion != "v1.28.0" {
+		t.Fatalf("capabilities = %+v, want a probe of v1.28.0", capabilities)
+	}
+	if err := mc.requireFeature(FeatureReadWriteOncePod); err != nil {
+		t.Errorf("ReadWriteOncePod on 1.28: %v", err)
+	}
+	if err := mc.requireFeature(FeaturePolicyV1); err != nil {
+		t.Errorf("policy/v1 served: %v", err)
+	}
+}
+
+// Without a decision the failed probe is reused until its backoff ends, instead of
+// every caller asking the API server again
+func TestClusterCapabilitiesProbeBackoff(t *testing.T) {
+	mc, clientset := newTestController(t, nil)
+	settleProbe(mc)
+	probes := 0
+	clientset.PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+		probes++
+		return true, nil, errors.New("connection refused")
+	})
+	reprobe(mc)
+
+	for i := 0; i < 5; i++ {
+		if capabilities := mc.ClusterCapabilities(); !strings.Contains(capabilities.Error, "connection refused") {
+			t.Fatalf("capabilities error = %q, want the failed probe", capabilities.Error)
+		}
+	}
+	if probes != 1 {
+		t.Fatalf("probed %d times within the backoff, want 1", probes)
+	}
+
+	mc.capabilitiesMux.Lock()
+	mc.probeRetryAt = time.Now().Add(-time.Second)
+	mc.capabilitiesMux.Unlock()
+	mc.ClusterCapabilities()
+	if probes != 2 {
+		t.Fatalf("probed %d times after the backoff, want 2", probes)
+	}
+	mc.capabilitiesMux.Lock()
+	wait := mc.probeRetryAt.Sub(mc.capabilities.ProbedAt)
+	mc.capabilitiesMux.Unlock()
+	if wait != 2*capabilityProbeBackoff {
+		t.Errorf("backoff after 2 failures = %s, want %s", wait, 2*capabilityProbeBackoff)
+	}
+}
+
+func TestProbeBackoff(t *testing.T) {
+	for failures, want := range map[int]time.Duration{
+		1:  capabilityProbeBackoff,
+		2:  2 * capabilityProbeBackoff,
+		3:  4 * capabilityProbeBackoff,
+		50: maxCapabilityProbeBackoff,
+	} {
+		if got := probeBackoff(failures); got != want {
+			t.Errorf("probeBackoff(%d) = %s, want %s", failures, got, want)
+		}
+	}
+}
+
+// A cluster whose budgets cannot be read must not have its pods deleted as if no
+// budget protected them
+func TestDisruptionBudgetCheckFailsClosed(t *testing.T) {
+	mc, clientset := newTestController(t, fullMode, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+	settleProbe(mc)
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: "v1"}}
+	reprobe(mc)
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+
+	report := mc.ValidateMigration(context.Background(), testRequest("app", "node-a", "node-b"))
+	for _, check := range report.Checks {
+		if check.Name == preflightDisruptionBudget && (check.Passed || !strings.Contains(check.Message, "cannot check pod disruption budgets")) {
+			t.Errorf("preflight check = %+v, want it failed", check)
+		}
+	}
+
+	runMigration(t, mc, req)
+	for _, action := range clientset.Actions() {
+		if del, ok := action.(k8stesting.DeleteAction); ok && del.GetName() == "app" {
+			t.Fatal("original pod deleted without checking its budgets")
+		}
+	}
+}
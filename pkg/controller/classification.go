@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// SetClassificationPolicy replaces the policy deciding which containers are migrated;
+// migrations that already captured their container states are not affected
+func (mc *MigrationController) SetClassificationPolicy(policy types.ClassificationPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	policy.SuccessExitCodes = append([]int32{}, policy.SuccessExitCodes...)
+	policy.SidecarImagePatterns = append([]string{}, policy.SidecarImagePatterns...)
+
+	mc.policyMux.Lock()
+	mc.classification = policy
+	mc.policyMux.Unlock()
+	return nil
+}
+
+// ClassificationPolicy returns the policy currently used to classify containers
+func (mc *MigrationController) ClassificationPolicy() types.ClassificationPolicy {
+	mc.policyMux.RLock()
+	defer mc.policyMux.RUnlock()
+
+	policy := mc.classification
+	policy.SuccessExitCodes = append([]int32{}, policy.SuccessExitCodes...)
+	policy.SidecarImagePatterns = append([]string{}, policy.SidecarImagePatterns...)
+	return policy
+}
+
+// classificationPolicyFor returns the policy a migration classifies its containers
+// with, dropping sidecar exclusion when the request asks to keep sidecars
+func (mc *MigrationController) classificationPolicyFor(req *types.MigrationRequest) types.ClassificationPolicy {
+	policy := mc.ClassificationPolicy()
+	if req.IncludeSidecars {
+		policy.SidecarImagePatterns = nil
+	}
+	if len(req.MigrateCompletedContainers) > 0 {
+		policy.MigrateCompletedContainers = append(append([]string(nil), policy.MigrateCompletedContainers...), req.MigrateCompletedContainers...)
+	}
+	return policy
+}
+
+// LoadClassificationPolicyFile loads the policy from a JSON file. Fields missing from
+// the file keep their defaults.
+func (mc *MigrationController) LoadClassificationPolicyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read classification policy file: %w", err)
+	}
+
+	policy := types.DefaultClassificationPolicy()
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return fmt.Errorf("failed to parse classification policy file: %w", err)
+	}
+	return mc.SetClassificationPolicy(policy)
+}
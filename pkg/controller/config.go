@@ -0,0 +1,390 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/policy"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MigrationConfig holds the tunable settings of the migration controller
+type MigrationConfig struct {
+	// Size of the PVC created for checkpoints, as a Kubernetes quantity (e.g. "1Gi"),
+	// used when the pod's memory usage is unknown
+	CheckpointSize string
+	// Checkpoints are sized from the pod's measured memory usage plus this margin
+	// (percent), clamped to [CheckpointMinSize, CheckpointMaxSize]
+	CheckpointSizeMargin float64
+	CheckpointMinSize    string
+	CheckpointMaxSize    string
+	// Access mode of checkpoint PVCs; ReadWriteMany lets source and target node use the
+	// checkpoint at the same time
+	CheckpointAccessMode string
+	// How long to wait for the optimized pod to become Ready
+	PodReadyTimeout time.Duration
+	// How often to poll the new pod when its readiness cannot be watched
+	PodReadyPollInterval time.Duration
+	// Added to PodReadyTimeout when a migrated container has a PostStart hook, which
+	// holds the container in Waiting until it returns
+	PostStartHookAllowance time.Duration
+	// How long to let the new pod settle before collecting post-migration metrics
+	MetricsStabilizationDelay time.Duration
+	// Number of migrations allowed to execute at the same time; others wait as pending
+	MaxConcurrentMigrations int
+	// Cap on executing migrations of pods in one namespace, 0 for none, with
+	// per-namespace overrides, so one tenant cannot take every slot
+	MaxConcurrentPerNamespace  int
+	NamespaceConcurrencyLimits map[string]int
+	// Number of background workers collecting post-migration metrics
+	MetricsWorkers int
+	// How often expired checkpoint PVCs are looked for and deleted
+	CheckpointReconcileInterval time.Duration
+	// How often to re-check a PodDisruptionBudget that blocks deleting the original pod
+	PDBRetryInterval time.Duration
+	// CPU or memory utilization (percent of allocatable) of the target node after a
+	// migration above which a pressure warning is logged
+	NodePressureThreshold float64
+	// Perform every step except deleting the original pod, leaving both pods running
+	SafeMode bool
+	// What to do about containers restarted more than RestartCountThreshold times:
+	// ignore, skip them, or prioritize migrating their pods
+	RestartCountPolicy    string
+	RestartCountThreshold int32
+	// Label keys removed from the optimized pod, so it is not adopted or deleted by
+	// the original pod's controller
+	StrippedLabels []string
+	// How often the pressure observer scans nodes and records the migrations it would
+	// recommend, 0 to disable it, and the CPU and memory utilization (percent of
+	// allocatable) at which it considers a node under pressure
+	ObserveInterval        time.Duration
+	ObserveCPUThreshold    float64
+	ObserveMemoryThreshold float64
+	// Tag the pods, Deployments and PVCs a migration creates with a cleanup finalizer
+	// and the migration ID, so objects of an interrupted migration are found and
+	// removed after a restart
+	CleanupFinalizers bool
+	// How many times a migration whose target node was picked by target_node_selector
+	// moves to another matching node when its target stops being Ready while the new
+	// pod is being created, 0 to fail right away
+	MaxNodeReselections int
+	// Adaptive backpressure: every BackpressureInterval, the execution slots are halved
+	// (down to one) when the API server answered at least BackpressureThreshold
+	// requests with 429 Too Many Requests, and grow back by one after an interval
+	// without any, up to MaxConcurrentMigrations. A threshold of 0 disables it.
+	BackpressureThreshold int
+	BackpressureInterval  time.Duration
+	// Directory each finished migration's rationale is written to as
+	// <migration id>.json for research analysis; empty disables it
+	ResultsDir string
+	// Directory the manifest of each original pod is written to as
+	// <namespace>/<migration id>.yaml when a request sets snapshot_original
+	SnapshotDir string
+	// URL of an external policy service (OPA data API style) asked to allow or deny
+	// every migration before it starts; empty disables the check
+	PolicyEndpoint string
+	// Whether migrations run (open) or are refused (closed) when the policy service
+	// gives no decision
+	PolicyFailureMode string
+	// Name of the metrics provider, recorded in the sampling methodology of results
+	MetricsProvider string
+	// Most migrations kept in memory; beyond it the terminal migrations that finished
+	// longest ago are forgotten. 0 keeps every migration.
+	MaxTrackedMigrations int
+	// Drain requests for a node within this long of the start of a drain of it still
+	// running join that drain instead of starting another. 0 disables coalescing.
+	DrainCoalesceWindow time.Duration
+	// Within each LogSampleWindow, only the first and then every LogSampleRate-th
+	// migration log line of the same kind is written to the process log. 0 or 1
+	// writes every line.
+	LogSampleRate   int
+	LogSampleWindow time.Duration
+	// Template naming new pods when a request gives neither new_pod_name nor
+	// new_pod_name_template
+	PodNameTemplate string
+	// What to do when only some containers of the new pod become ready: rollback or
+	// keep the pod, ending partially_degraded
+	PartialReadinessPolicy string
+	// What to do when the original pod changed after the migration was planned:
+	// abort, or replan when the new pod has not been created yet
+	SpecDriftPolicy string
+	// What to do when a checkpoint fails its integrity check before restore: fail,
+	// or cold_restart without it
+	CheckpointIntegrityPolicy string
+}
+
+// DefaultMigrationConfig returns the configuration used when no overrides are given
+func DefaultMigrationConfig() MigrationConfig {
+	return MigrationConfig{
+		CheckpointSize:              "1Gi", // Default 1GB for checkpoint storage
+		CheckpointSizeMargin:        25,
+		CheckpointMinSize:           "256Mi",
+		CheckpointMaxSize:           "10Gi",
+		CheckpointAccessMode:        string(corev1.ReadWriteOnce),
+		PodReadyTimeout:             5 * time.Minute,
+		PodReadyPollInterval:        2 * time.Second,
+		PostStartHookAllowance:      time.Minute,
+		MetricsStabilizationDelay:   30 * time.Second,
+		MaxConcurrentMigrations:     5,
+		MetricsWorkers:              10,
+		PDBRetryInterval:            10 * time.Second,
+		CheckpointReconcileInterval: 5 * time.Minute,
+		NodePressureThreshold:       85,
+		SafeMode:                    true,
+		RestartCountPolicy:          RestartPolicyIgnore,
+		RestartCountThreshold:       5,
+		StrippedLabels:              DefaultStrippedLabels,
+		ObserveCPUThreshold:         85,
+		ObserveMemoryThreshold:      85,
+		MaxNodeReselections:         2,
+		BackpressureThreshold:       10,
+		BackpressureInterval:        10 * time.Second,
+		MaxTrackedMigrations:        10000,
+		DrainCoalesceWindow:         30 * time.Second,
+		LogSampleWindow:             time.Minute,
+		PodNameTemplate:             DefaultPodNameTemplate,
+		PartialReadinessPolicy:      PartialReadinessRollback,
+		SpecDriftPolicy:             SpecDriftAbort,
+		CheckpointIntegrityPolicy:   CheckpointIntegrityFail,
+		PolicyFailureMode:           PolicyFailClosed,
+	}
+}
+
+// ParseNamespaceLimits parses a comma-separated list of namespace=limit pairs
+func ParseNamespaceLimits(value string) (map[string]int, error) {
+	limits := make(map[string]int)
+	if strings.TrimSpace(value) == "" {
+		return limits, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		namespace, limit, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || namespace == "" {
+			return nil, fmt.Errorf("invalid namespace limit %q: expected namespace=limit", pair)
+		}
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit for namespace %s: %w", namespace, err)
+		}
+		limits[namespace] = n
+	}
+	return limits, nil
+}
+
+// validatedMigrationConfig is the parsed form of MigrationConfig used at runtime
+type validatedMigrationConfig struct {
+	checkpointSize              resource.Quantity
+	checkpointSizeMargin        float64
+	checkpointMinSize           resource.Quantity
+	checkpointMaxSize           resource.Quantity
+	checkpointAccessMode        corev1.PersistentVolumeAccessMode
+	podReadyTimeout             time.Duration
+	podReadyPollInterval        time.Duration
+	postStartHookAllowance      time.Duration
+	metricsStabilizationDelay   time.Duration
+	maxConcurrentMigrations     int
+	maxConcurrentPerNamespace   int
+	namespaceLimits             map[string]int
+	metricsWorkers              int
+	pdbRetryInterval            time.Duration
+	checkpointReconcileInterval time.Duration
+	nodePressureThreshold       float64
+	safeMode                    bool
+	restartCountPolicy          string
+	restartCountThreshold       int32
+	strippedLabels              []string
+	observeInterval             time.Duration
+	observeCPUThreshold         float64
+	observeMemoryThreshold      float64
+	cleanupFinalizers           bool
+	maxNodeReselections         int
+	backpressureThreshold       int
+	backpressureInterval        time.Duration
+	resultsDir                  string
+	metricsProvider             string
+	maxTrackedMigrations        int
+	drainCoalesceWindow         time.Duration
+	logSampleRate               int
+	logSampleWindow             time.Duration
+	podNameTemplate             string
+	partialReadinessPolicy      string
+	specDriftPolicy             string
+	checkpointIntegrityPolicy   string
+	snapshotDir                 string
+	policyEndpoint              string
+	policyFailureMode           string
+}
+
+// validate parses and checks all settings so misconfiguration fails at startup
+// instead of on the first migration
+func (c MigrationConfig) validate() (*validatedMigrationConfig, error) {
+	checkpointSize, err := resource.ParseQuantity(c.CheckpointSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint size %q: %w", c.CheckpointSize, err)
+	}
+	if checkpointSize.Sign() <= 0 {
+		return nil, fmt.Errorf("checkpoint size must be positive, got %q", c.CheckpointSize)
+	}
+	if c.CheckpointSizeMargin < 0 {
+		return nil, fmt.Errorf("checkpoint size margin must be non-negative, got %g", c.CheckpointSizeMargin)
+	}
+	checkpointMinSize, err := resource.ParseQuantity(c.CheckpointMinSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint min size %q: %w", c.CheckpointMinSize, err)
+	}
+	checkpointMaxSize, err := resource.ParseQuantity(c.CheckpointMaxSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint max size %q: %w", c.CheckpointMaxSize, err)
+	}
+	if checkpointMinSize.Sign() <= 0 || checkpointMinSize.Cmp(checkpointMaxSize) > 0 {
+		return nil, fmt.Errorf("checkpoint min size must be positive and not above max size, got %q and %q", c.CheckpointMinSize, c.CheckpointMaxSize)
+	}
+	if err := ValidateAccessMode(c.CheckpointAccessMode); err != nil {
+		return nil, fmt.Errorf("checkpoint %w", err)
+	}
+	if c.PodReadyTimeout <= 0 {
+		return nil, fmt.Errorf("pod ready timeout must be positive, got %s", c.PodReadyTimeout)
+	}
+	if c.PodReadyPollInterval <= 0 || c.PodReadyPollInterval > c.PodReadyTimeout {
+		return nil, fmt.Errorf("pod ready poll interval must be positive and at most the pod ready timeout, got %s", c.PodReadyPollInterval)
+	}
+	if c.PostStartHookAllowance < 0 {
+		return nil, fmt.Errorf("post-start hook allowance must be non-negative, got %s", c.PostStartHookAllowance)
+	}
+	if c.MetricsStabilizationDelay < 0 {
+		return nil, fmt.Errorf("metrics stabilization delay must be non-negative, got %s", c.MetricsStabilizationDelay)
+	}
+	if c.MaxConcurrentMigrations < 1 {
+		return nil, fmt.Errorf("max concurrent migrations must be at least 1, got %d", c.MaxConcurrentMigrations)
+	}
+	if c.MaxConcurrentPerNamespace < 0 {
+		return nil, fmt.Errorf("max concurrent migrations per namespace must be non-negative, got %d", c.MaxConcurrentPerNamespace)
+	}
+	namespaceLimits := make(map[string]int, len(c.NamespaceConcurrencyLimits))
+	for namespace, limit := range c.NamespaceConcurrencyLimits {
+		if limit < 0 {
+			return nil, fmt.Errorf("concurrency limit of namespace %s must be non-negative, got %d", namespace, limit)
+		}
+		namespaceLimits[namespace] = limit
+	}
+	if c.MetricsWorkers < 1 {
+		return nil, fmt.Errorf("metrics workers must be at least 1, got %d", c.MetricsWorkers)
+	}
+	if c.PDBRetryInterval <= 0 {
+		return nil, fmt.Errorf("PDB retry interval must be positive, got %s", c.PDBRetryInterval)
+	}
+	if c.CheckpointReconcileInterval <= 0 {
+		return nil, fmt.Errorf("checkpoint reconcile interval must be positive, got %s", c.CheckpointReconcileInterval)
+	}
+	if c.NodePressureThreshold <= 0 || c.NodePressureThreshold > 100 {
+		return nil, fmt.Errorf("node pressure threshold must be in (0, 100], got %g", c.NodePressureThreshold)
+	}
+	if err := validateRestartPolicy(c.RestartCountPolicy); err != nil {
+		return nil, err
+	}
+	if c.RestartCountThreshold < 0 {
+		return nil, fmt.Errorf("restart count threshold must be non-negative, got %d", c.RestartCountThreshold)
+	}
+	for _, key := range c.StrippedLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid stripped label key %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	if c.ObserveInterval < 0 {
+		return nil, fmt.Errorf("observe interval must be non-negative, got %s", c.ObserveInterval)
+	}
+	if c.ObserveCPUThreshold <= 0 || c.ObserveCPUThreshold > 100 {
+		return nil, fmt.Errorf("observe CPU threshold must be in (0, 100], got %g", c.ObserveCPUThreshold)
+	}
+	if c.ObserveMemoryThreshold <= 0 || c.ObserveMemoryThreshold > 100 {
+		return nil, fmt.Errorf("observe memory threshold must be in (0, 100], got %g", c.ObserveMemoryThreshold)
+	}
+	if c.MaxNodeReselections < 0 {
+		return nil, fmt.Errorf("max node reselections must be non-negative, got %d", c.MaxNodeReselections)
+	}
+	if c.MaxTrackedMigrations < 0 {
+		return nil, fmt.Errorf("max tracked migrations must be non-negative, got %d", c.MaxTrackedMigrations)
+	}
+	if c.DrainCoalesceWindow < 0 {
+		return nil, fmt.Errorf("drain coalesce window must be non-negative, got %s", c.DrainCoalesceWindow)
+	}
+	if err := ValidatePodNameTemplate(c.PodNameTemplate); err != nil {
+		return nil, fmt.Errorf("invalid pod name template: %w", err)
+	}
+	if err := validatePartialReadinessPolicy(c.PartialReadinessPolicy); err != nil {
+		return nil, err
+	}
+	if err := validateSpecDriftPolicy(c.SpecDriftPolicy); err != nil {
+		return nil, err
+	}
+	if err := validateCheckpointIntegrityPolicy(c.CheckpointIntegrityPolicy); err != nil {
+		return nil, err
+	}
+	if c.PolicyEndpoint != "" {
+		if err := policy.ValidateEndpoint(c.PolicyEndpoint); err != nil {
+			return nil, err
+		}
+	}
+	if err := validatePolicyFailureMode(c.PolicyFailureMode); err != nil {
+		return nil, err
+	}
+	if c.LogSampleRate < 0 {
+		return nil, fmt.Errorf("log sample rate must be non-negative, got %d", c.LogSampleRate)
+	}
+	if c.LogSampleRate > 1 && c.LogSampleWindow <= 0 {
+		return nil, fmt.Errorf("log sample window must be positive, got %s", c.LogSampleWindow)
+	}
+	if c.BackpressureThreshold < 0 {
+		return nil, fmt.Errorf("backpressure threshold must be non-negative, got %d", c.BackpressureThreshold)
+	}
+	if c.BackpressureThreshold > 0 && c.BackpressureInterval <= 0 {
+		return nil, fmt.Errorf("backpressure interval must be positive, got %s", c.BackpressureInterval)
+	}
+
+	return &validatedMigrationConfig{
+		checkpointSize:              checkpointSize,
+		checkpointSizeMargin:        c.CheckpointSizeMargin,
+		checkpointMinSize:           checkpointMinSize,
+		checkpointMaxSize:           checkpointMaxSize,
+		checkpointAccessMode:        corev1.PersistentVolumeAccessMode(c.CheckpointAccessMode),
+		podReadyTimeout:             c.PodReadyTimeout,
+		podReadyPollInterval:        c.PodReadyPollInterval,
+		postStartHookAllowance:      c.PostStartHookAllowance,
+		metricsStabilizationDelay:   c.MetricsStabilizationDelay,
+		maxConcurrentMigrations:     c.MaxConcurrentMigrations,
+		maxConcurrentPerNamespace:   c.MaxConcurrentPerNamespace,
+		namespaceLimits:             namespaceLimits,
+		metricsWorkers:              c.MetricsWorkers,
+		pdbRetryInterval:            c.PDBRetryInterval,
+		checkpointReconcileInterval: c.CheckpointReconcileInterval,
+		nodePressureThreshold:       c.NodePressureThreshold,
+		safeMode:                    c.SafeMode,
+		restartCountPolicy:          c.RestartCountPolicy,
+		restartCountThreshold:       c.RestartCountThreshold,
+		strippedLabels:              append([]string{}, c.StrippedLabels...),
+		observeInterval:             c.ObserveInterval,
+		observeCPUThreshold:         c.ObserveCPUThreshold,
+		observeMemoryThreshold:      c.ObserveMemoryThreshold,
+		cleanupFinalizers:           c.CleanupFinalizers,
+		maxNodeReselections:         c.MaxNodeReselections,
+		backpressureThreshold:       c.BackpressureThreshold,
+		backpressureInterval:        c.BackpressureInterval,
+		resultsDir:                  c.ResultsDir,
+		metricsProvider:             c.MetricsProvider,
+		maxTrackedMigrations:        c.MaxTrackedMigrations,
+		drainCoalesceWindow:         c.DrainCoalesceWindow,
+		logSampleRate:               c.LogSampleRate,
+		logSampleWindow:             c.LogSampleWindow,
+		podNameTemplate:             c.PodNameTemplate,
+		partialReadinessPolicy:      c.PartialReadinessPolicy,
+		specDriftPolicy:             c.SpecDriftPolicy,
+		checkpointIntegrityPolicy:   c.CheckpointIntegrityPolicy,
+		snapshotDir:                 c.SnapshotDir,
+		policyEndpoint:              c.PolicyEndpoint,
+		policyFailureMode:           c.PolicyFailureMode,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"runtime"
+	"sort"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// DebugState dumps the controller's internal maps and queues
+func (mc *MigrationController) DebugState() *types.ControllerDebugState {
+	state := &types.ControllerDebugState{
+		Timestamp:        time.Now(),
+		Goroutines:       runtime.NumGoroutine(),
+		Migrations:       []types.DebugMigration{},
+		PodsInFlight:     []string{},
+		SourceNodeCounts: make(map[string]int),
+		TargetNodeCounts: make(map[string]int),
+		ActiveBatches:    []string{},
+		SinkQueueDepth:   len(mc.sinkQueue),
+	}
+
+	mc.batchesMux.RLock()
+	for id, batch := range mc.batches {
+		if batch.EndTime == nil {
+			state.ActiveBatches = append(state.ActiveBatches, id)
+		}
+	}
+	mc.batchesMux.RUnlock()
+	sort.Strings(state.ActiveBatches)
+
+	mc.migrationsMux.RLock()
+	for _, job := range mc.migrations {
+		finished := false
+		select {
+		case <-job.done:
+			finished = true
+		default:
+		}
+
+		pod := job.Request.PodNamespace + "/" + job.Request.PodName
+		state.Migrations = append(state.Migrations, types.DebugMigration{
+			ID:           job.ID,
+			Status:       job.Status,
+			Pod:          pod,
+			SourceNode:   job.Request.SourceNode,
+			TargetNode:   job.Request.TargetNode,
+			CurrentStep:  job.Details.CurrentStep,
+			CancelReason: job.cancelReason,
+			Finished:     finished,
+			Age:          time.Since(job.StartTime).Round(time.Second).String(),
+			LogEntries:   len(job.logs),
+		})
+		if !finished {
+			state.PodsInFlight = append(state.PodsInFlight, pod)
+			state.SourceNodeCounts[job.Request.SourceNode]++
+			state.TargetNodeCounts[job.Request.TargetNode]++
+		}
+	}
+	mc.migrationsMux.RUnlock()
+
+	sort.Slice(state.Migrations, func(i, j int) bool { return state.Migrations[i].ID < state.Migrations[j].ID })
+	sort.Strings(state.PodsInFlight)
+	state.Scheduler = mc.slots.debugState()
+	return state
+}
+
+// debugState snapshots the scheduler's slots and queue
+func (s *slotScheduler) debugState() types.DebugScheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := types.DebugScheduler{
+		Limit:              s.limit,
+		Running:            []string{},
+		Protected:          []string{},
+		Waiting:            []types.DebugWaiter{},
+		RunningByNamespace: make(map[string]int, len(s.namespaceRunning)),
+	}
+	for id := range s.running {
+		state.Running = append(state.Running, id)
+	}
+	for id := range s.protected {
+		state.Protected = append(state.Protected, id)
+	}
+	sort.Strings(state.Running)
+	sort.Strings(state.Protected)
+	for _, waiter := range s.waiting {
+		state.Waiting = append(state.Waiting, types.DebugWaiter{
+			MigrationID: waiter.job.ID,
+			Namespace:   waiter.job.Request.PodNamespace,
+			Priority:    waiter.job.Request.Priority,
+		})
+	}
+	for namespace, count := range s.namespaceRunning {
+		state.RunningByNamespace[namespace] = count
+	}
+	return state
+}
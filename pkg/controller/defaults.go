@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// DefaultedRequest returns a copy of a validated request with the defaults the
+// controller would apply filled in, so callers can see what will actually run. It
+// does not touch the cluster; the new pod name stays empty when it is generated at
+// creation time.
+func (mc *MigrationController) DefaultedRequest(req *types.MigrationRequest) *types.MigrationRequest {
+	defaulted := *req
+	job := &MigrationJob{Request: &defaulted}
+
+	defaulted.Placement = effectivePlacement(&defaulted)
+	if defaulted.PreservePV && !defaulted.ForceRestart {
+		defaulted.CheckpointAccessMode = string(mc.checkpointAccessMode(job))
+	}
+	if defaulted.CaptureLogs && defaulted.CaptureLogLines == 0 {
+		defaulted.CaptureLogLines = DefaultCaptureLogLines
+	}
+	if defaulted.EnsureService {
+		defaulted.ServiceName = stableServiceName(job)
+	}
+	defaulted.SuccessCriteria = successCriteria(&defaulted)
+	if defaulted.SuccessCriteria == types.SuccessCriteriaStabilityWindow {
+		defaulted.StabilityWindowSeconds = int(stabilityWindow(&defaulted) / time.Second)
+	}
+	if defaulted.SampleUsage {
+		defaulted.SampleInterval, defaulted.SampleDuration = usageSamplingWindow(&defaulted.MigrationOptions)
+	}
+	return &defaulted
+}
+
+// effectivePlacement returns how the new pod is placed: a Deployment always goes
+// through the scheduler, otherwise nodeName is pinned unless scheduler was requested
+func effectivePlacement(req *types.MigrationRequest) string {
+	if req.WrapInDeployment {
+		return types.PlacementScheduler
+	}
+	if req.Placement == "" {
+		return types.PlacementNodeName
+	}
+	return req.Placement
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ErrContainersCrashLooping ends the wait for a new pod early: some of its containers
+// are ready and all others are crash-looping, so waiting longer would not change how
+// its partial readiness is handled
+var ErrContainersCrashLooping = errors.New("containers of the new pod are crash-looping")
+
+// Policies for a new pod whose containers only partly became ready
+const (
+	// PartialReadinessRollback fails and rolls back the migration, as for a pod that
+	// never became ready
+	PartialReadinessRollback = "rollback"
+	// PartialReadinessKeep goes on with the degraded pod, ending partially_degraded
+	PartialReadinessKeep = "keep"
+)
+
+// validatePartialReadinessPolicy checks a partial readiness policy name
+func validatePartialReadinessPolicy(policy string) error {
+	switch policy {
+	case PartialReadinessRollback, PartialReadinessKeep:
+		return nil
+	}
+	return fmt.Errorf("partial readiness policy must be %s or %s, got %q",
+		PartialReadinessRollback, PartialReadinessKeep, policy)
+}
+
+// acceptPartialReadiness inspects a new pod that did not become ready, in time or
+// because its unready containers are crash-looping, and records the readiness of
+// each of its containers. When some are ready and others are not, the keep policy
+// lets the migration go on with the unready ones recorded as degraded; it returns
+// whether it does.
+func (mc *MigrationController) acceptPartialReadiness(ctx context.Context, job *MigrationJob) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+	if err != nil {
+		return false
+	}
+
+	readiness := k8s.ContainerReadiness(pod)
+	var ready, unready []string
+	for _, container := range readiness {
+		if container.Ready {
+			ready = append(ready, container.Name)
+		} else {
+			unready = append(unready, container.Name)
+		}
+	}
+	mc.migrationsMux.Lock()
+	job.Details.ContainerReadiness = readiness
+	mc.migrationsMux.Unlock()
+
+	if len(ready) == 0 || len(unready) == 0 {
+		return false
+	}
+	if mc.config.partialReadinessPolicy != PartialReadinessKeep {
+		mc.logf(job, "Only %d/%d containers became ready (%s not ready); rolling back under the %s policy",
+			len(ready), len(readiness), strings.Join(unready, ", "), PartialReadinessRollback)
+		return false
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.DegradedContainers = unready
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "Warning: Keeping partially ready pod %s: containers %s are not ready",
+		job.Details.NewPodName, strings.Join(unready, ", "))
+	return true
+}
+
+// watchCrashLoops returns a context derived from ctx that is cancelled, with an
+// ErrContainersCrashLooping error as its cause, once the new pod is partly ready with
+// its other containers crash-looping. The returned function stops the watch.
+func (mc *MigrationController) watchCrashLoops(ctx context.Context, job *MigrationJob) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	namespace, name := job.Request.PodNamespace, job.Details.NewPodName
+	tolerated := job.Request.TolerateUnreadyContainers
+
+	go func() {
+		ticker := time.NewTicker(mc.config.podReadyPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pod, err := mc.k8sClient.GetPod(ctx, namespace, name)
+				if err != nil {
+					continue
+				}
+				if crashing := crashLoopingContainers(pod, tolerated); len(crashing) > 0 {
+					cancel(fmt.Errorf("%w: %s", ErrContainersCrashLooping, strings.Join(crashing, ", ")))
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() { cancel(nil) }
+}
+
+// crashLoopingContainers returns the unready containers of a pod when at least one
+// container is ready and every unready one not tolerated is in CrashLoopBackOff
+func crashLoopingContainers(pod *corev1.Pod, tolerated []string) []string {
+	skip := make(map[string]bool, len(tolerated))
+	for _, name := range tolerated {
+		skip[name] = true
+	}
+
+	ready := 0
+	var crashing []string
+	for _, container := range k8s.ContainerReadiness(pod) {
+		switch {
+		case container.Ready:
+			ready++
+		case skip[container.Name]:
+		case container.Reason == "CrashLoopBackOff":
+			crashing = append(crashing, container.Name)
+		default:
+			return nil
+		}
+	}
+	if ready == 0 {
+		return nil
+	}
+	return crashing
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestMigrationStatusSucceeded(t *testing.T) {
+	for status, want := range map[types.MigrationStatus]bool{
+		types.MigrationStatusCompleted:         true,
+		types.MigrationStatusCompletedSafe:     true,
+		types.MigrationStatusPartiallyDegraded: true,
+		types.MigrationStatusFailed:            false,
+		types.MigrationStatusCancelled:         false,
+		types.MigrationStatusRunning:           false,
+		types.MigrationStatusAwaitingCutover:   false,
+	} {
+		if got := status.Succeeded(); got != want {
+			t.Errorf("%s.Succeeded() = %v, want %v", status, got, want)
+		}
+	}
+}
+
+// startCrashLooping has every pod created after the original start with container
+// "worker" crash-looping and the others ready, as the kubelet would report it
+func startCrashLooping(clientset *fake.Clientset) {
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+		for _, container := range pod.Spec.Containers {
+			status := corev1.ContainerStatus{
+				Name:  container.Name,
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.Now()}},
+			}
+			if container.Name == "worker" {
+				status.Ready = false
+				status.RestartCount = 4
+				status.State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+			}
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, status)
+		}
+		return false, nil, nil
+	})
+}
+
+// The readiness timeout is far longer than the test may take: a pod settled partly
+// ready must be decided on without waiting it out
+func TestPartialReadinessDecidedBeforeTimeout(t *testing.T) {
+	for _, test := range []struct {
+		policy string
+		want   types.MigrationStatus
+	}{
+		{PartialReadinessKeep, types.MigrationStatusPartiallyDegraded},
+		{PartialReadinessRollback, types.MigrationStatusFailed},
+	} {
+		t.Run(test.policy, func(t *testing.T) {
+			mc, clientset := newTestController(t, func(config *MigrationConfig) {
+				config.SafeMode = false
+				config.PartialReadinessPolicy = test.policy
+			}, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main", "worker"))
+			startCrashLooping(clientset)
+			req := testRequest("app", "node-a", "node-b")
+			req.Timeout = 600
+			req.ForceIgnorePDB = true
+
+			started := time.Now()
+			response := runMigration(t, mc, req)
+			if response.Status != test.want {
+				t.Fatalf("status = %s (%s), want %s", response.Status, response.Message, test.want)
+			}
+			if elapsed := time.Since(started); elapsed > 10*time.Second {
+				t.Errorf("took %s, want the crash loop noticed right away", elapsed)
+			}
+			if test.want == types.MigrationStatusPartiallyDegraded {
+				if !reflect.DeepEqual(response.Details.DegradedContainers, []string{"worker"}) {
+					t.Errorf("degraded containers = %v, want [worker]", response.Details.DegradedContainers)
+				}
+			} else if failure := response.Details.Failure; failure == nil || !strings.Contains(failure.Error, "crash-looping: worker") {
+				t.Errorf("failure = %+v, want the crash-looping container", failure)
+			}
+		})
+	}
+}
+
+func TestCrashLoopingContainers(t *testing.T) {
+	pod := testPod("app", "node-b", "main", "worker", "agent")
+	for i := range pod.Status.ContainerStatuses {
+		status := &pod.Status.ContainerStatuses[i]
+		if status.Name != "main" {
+			status.Ready = false
+			status.State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+		}
+	}
+	if got := crashLoopingContainers(pod, nil); !reflect.DeepEqual(got, []string{"worker", "agent"}) {
+		t.Errorf("crashLoopingContainers = %v, want [worker agent]", got)
+	}
+	if got := crashLoopingContainers(pod, []string{"agent"}); !reflect.DeepEqual(got, []string{"worker"}) {
+		t.Errorf("crashLoopingContainers tolerating agent = %v, want [worker]", got)
+	}
+
+	// A container still starting may yet become ready
+	pod.Status.ContainerStatuses[2].State.Waiting.Reason = "ContainerCreating"
+	if got := crashLoopingContainers(pod, nil); got != nil {
+		t.Errorf("crashLoopingContainers with a starting container = %v, want nil", got)
+	}
+	// Nothing is partly ready when no container is
+	pod.Status.ContainerStatuses[0].Ready = false
+	if got := crashLoopingContainers(pod, []string{"agent"}); got != nil {
+		t.Errorf("crashLoopingContainers with no ready container = %v, want nil", got)
+	}
+}
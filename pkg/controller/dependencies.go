@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// ResolveBatchOrder checks the dependencies of a batch and returns the indexes of its
+// migrations in the order they may start: every migration after the ones it depends
+// on, otherwise in request order. deps[i] lists the indexes migration i depends on.
+// Unknown or ambiguous pod references and dependency cycles are rejected.
+func ResolveBatchOrder(req *types.BatchMigrationRequest) (order []int, deps [][]int, err error) {
+	total := len(req.Migrations)
+	deps = make([][]int, total)
+	for i, dependency := range req.Dependencies {
+		pod, err := batchPodIndex(req, dependency.Pod)
+		if err != nil {
+			return nil, nil, fmt.Errorf("dependencies[%d].pod: %w", i, err)
+		}
+		for _, ref := range dependency.DependsOn {
+			dep, err := batchPodIndex(req, ref)
+			if err != nil {
+				return nil, nil, fmt.Errorf("dependencies[%d].depends_on: %w", i, err)
+			}
+			if dep == pod {
+				return nil, nil, fmt.Errorf("dependencies[%d]: pod %s cannot depend on itself", i, dependency.Pod)
+			}
+			deps[pod] = append(deps[pod], dep)
+		}
+	}
+
+	// Kahn's algorithm, always taking the earliest ready migration so independent
+	// migrations keep their request order
+	remaining := make([]int, total)
+	for i := range deps {
+		remaining[i] = len(deps[i])
+	}
+	placed := make([]bool, total)
+	for len(order) < total {
+		next := -1
+		for i := 0; i < total; i++ {
+			if !placed[i] && remaining[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var cycle []string
+			for i := 0; i < total; i++ {
+				if !placed[i] {
+					cycle = append(cycle, batchPodName(&req.Migrations[i]))
+				}
+			}
+			return nil, nil, fmt.Errorf("dependency cycle among pods %s", strings.Join(cycle, ", "))
+		}
+		placed[next] = true
+		order = append(order, next)
+		for i := range deps {
+			for _, dep := range deps[i] {
+				if dep == next {
+					remaining[i]--
+				}
+			}
+		}
+	}
+	return order, deps, nil
+}
+
+// batchPodIndex finds the migration of a batch a pod reference points at. References
+// are namespace/name, or a bare name when only one pod of the batch has it.
+func batchPodIndex(req *types.BatchMigrationRequest, ref string) (int, error) {
+	namespace, name, qualified := strings.Cut(ref, "/")
+	if !qualified {
+		namespace, name = "", ref
+	}
+
+	match := -1
+	for i := range req.Migrations {
+		migration := &req.Migrations[i]
+		if migration.PodName != name || (qualified && migration.PodNamespace != namespace) {
+			continue
+		}
+		if match >= 0 {
+			return 0, fmt.Errorf("pod %q is ambiguous, use namespace/name", ref)
+		}
+		match = i
+	}
+	if match < 0 {
+		return 0, fmt.Errorf("pod %q is not part of the batch", ref)
+	}
+	return match, nil
+}
+
+// batchPodName formats the pod of a batch migration as namespace/name
+func batchPodName(req *types.MigrationRequest) string {
+	return req.PodNamespace + "/" + req.PodName
+}
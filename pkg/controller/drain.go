@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// mirrorPodAnnotation marks static pods managed by the kubelet
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// ErrDrainConflict is returned when a drain request would join a running drain of
+// the same node that was started with different options
+var ErrDrainConflict = errors.New("node is being drained with different options")
+
+// nodeDrain is the latest drain of a node, guarded by drainsMux
+type nodeDrain struct {
+	request types.DrainRequest
+	batchID string        // empty until the batch has started
+	started chan struct{} // closed once the batch started or failed to
+}
+
+// StartDrain migrates every eligible pod on node to the drain's target node as one
+// batch, honouring its max_unavailable and pod_delay pacing. A request arriving within
+// the coalesce window of a drain of the same node still running, e.g. from a retrying
+// client, joins that drain and gets its batch back with coalesced set; one whose
+// options differ fails with ErrDrainConflict instead.
+func (mc *MigrationController) StartDrain(node string, req *types.DrainRequest, requestID string) (*types.BatchMigrationResponse, error) {
+	if err := mc.acceptingWork(); err != nil {
+		return nil, err
+	}
+
+	// Only the node's entry is claimed under drainsMux; pods are listed and the batch
+	// started outside it, so drains of other nodes are not held up
+	mc.drainsMux.Lock()
+	for {
+		drain, ok := mc.drains[node]
+		if !ok || mc.config.drainCoalesceWindow == 0 {
+			break
+		}
+		if drain.batchID == "" {
+			// Another request is starting a drain of the node; join it once it did
+			mc.drainsMux.Unlock()
+			<-drain.started
+			mc.drainsMux.Lock()
+			continue
+		}
+		if !mc.drainRunning(drain) {
+			break
+		}
+		mc.drainsMux.Unlock()
+		if !reflect.DeepEqual(drain.request, *req) {
+			return nil, fmt.Errorf("%w: batch %s drains node %s to %s", ErrDrainConflict, drain.batchID, node, drain.request.TargetNode)
+		}
+		log.Printf("Drain of node %s: joining batch %s started within the last %s", node, drain.batchID, mc.config.drainCoalesceWindow)
+		response, err := mc.GetBatch(drain.batchID)
+		if err != nil {
+			return nil, err
+		}
+		response.Coalesced = true
+		return response, nil
+	}
+	drain := &nodeDrain{request: *req, started: make(chan struct{})}
+	mc.drains[node] = drain
+	mc.drainsMux.Unlock()
+
+	response, err := mc.startDrainBatch(node, req, requestID)
+
+	mc.drainsMux.Lock()
+	if err != nil {
+		if mc.drains[node] == drain {
+			delete(mc.drains, node)
+		}
+	} else {
+		drain.batchID = response.BatchID
+	}
+	close(drain.started)
+	mc.drainsMux.Unlock()
+	return response, err
+}
+
+// startDrainBatch starts a batch migrating the drainable pods of node
+func (mc *MigrationController) startDrainBatch(node string, req *types.DrainRequest, requestID string) (*types.BatchMigrationResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pods, err := mc.k8sClient.ListPodsOnNode(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &types.BatchMigrationRequest{
+		MaxUnavailable: req.MaxUnavailable,
+		PodDelay:       req.PodDelay,
+	}
+	for i := range pods {
+		if !drainable(&pods[i]) {
+			continue
+		}
+		batch.Migrations = append(batch.Migrations, types.MigrationRequest{
+			PodName:          pods[i].Name,
+			PodNamespace:     pods[i].Namespace,
+			SourceNode:       node,
+			TargetNode:       req.TargetNode,
+			RequestID:        requestID,
+			MigrationOptions: req.MigrationOptions,
+		})
+	}
+	if len(batch.Migrations) == 0 {
+		return nil, fmt.Errorf("node %s has no pods to migrate", node)
+	}
+
+	return mc.startBatch(batch, fmt.Sprintf("drain of node %s", node))
+}
+
+// drainRunning reports whether a started drain can be joined: it began within the
+// coalesce window and has not finished. drainsMux must be held.
+func (mc *MigrationController) drainRunning(drain *nodeDrain) bool {
+	mc.batchesMux.RLock()
+	defer mc.batchesMux.RUnlock()
+	batch, exists := mc.batches[drain.batchID]
+	return exists && batch.EndTime == nil && time.Since(batch.StartTime) < mc.config.drainCoalesceWindow
+}
+
+// drainable reports whether a pod should be migrated off its node. DaemonSet and
+// static pods are bound to the node, and finished or terminating pods have nothing
+// left to migrate.
+func drainable(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, mirror := pod.Annotations[mirrorPodAnnotation]; mirror {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	return true
+}
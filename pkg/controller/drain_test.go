@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// holdingClientset holds its first listing of the pods of a node until release is
+// closed, with held closed meanwhile. Unlike a blocking reactor of the fake, it
+// holds up no other call.
+type holdingClientset struct {
+	*fake.Clientset
+	selector      string
+	held, release chan struct{}
+	once          sync.Once
+}
+
+func newHoldingClientset(clientset *fake.Clientset, node string) *holdingClientset {
+	return &holdingClientset{
+		Clientset: clientset,
+		selector:  fields.OneTermEqualSelector("spec.nodeName", node).String(),
+		held:      make(chan struct{}),
+		release:   make(chan struct{}),
+	}
+}
+
+func (c *holdingClientset) CoreV1() corev1client.CoreV1Interface {
+	return holdingCoreV1{CoreV1Interface: c.Clientset.CoreV1(), holder: c}
+}
+
+type holdingCoreV1 struct {
+	corev1client.CoreV1Interface
+	holder *holdingClientset
+}
+
+func (c holdingCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return holdingPods{PodInterface: c.CoreV1Interface.Pods(namespace), holder: c.holder}
+}
+
+type holdingPods struct {
+	corev1client.PodInterface
+	holder *holdingClientset
+}
+
+func (p holdingPods) List(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error) {
+	if opts.FieldSelector == p.holder.selector {
+		p.holder.once.Do(func() {
+			close(p.holder.held)
+			<-p.holder.release
+		})
+	}
+	return p.PodInterface.List(ctx, opts)
+}
+
+// drainRequest drains to node-b, pausing between pods so that the drain is still
+// running when later requests arrive
+func drainRequest() *types.DrainRequest {
+	return &types.DrainRequest{
+		TargetNode:       "node-b",
+		PodDelay:         60,
+		MigrationOptions: types.MigrationOptions{Timeout: 60, SuccessCriteria: types.SuccessCriteriaPodCreated},
+	}
+}
+
+func TestStartDrainCoalescesRequestsWhileStarting(t *testing.T) {
+	clientset := newHoldingClientset(newTestClientset(testNode("node-a"), testNode("node-b"), testNode("node-c"),
+		testPod("app", "node-a", "main"), testPod("db", "node-a", "main")), "node-a")
+	held, release := clientset.held, clientset.release
+	mc := newTestControllerFor(t, clientset, fullMode)
+	defer mc.MarkStopped()
+
+	type result struct {
+		response *types.BatchMigrationResponse
+		err      error
+	}
+	results := make(chan result, 2)
+	start := func() {
+		response, err := mc.StartDrain("node-a", drainRequest(), "")
+		results <- result{response, err}
+	}
+	go start()
+	<-held
+	go start()
+
+	// The node's drain being started holds up neither the lock nor other nodes
+	done := make(chan struct{})
+	go func() {
+		mc.StartDrain("node-c", drainRequest(), "")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("drain of node-c waited for the drain of node-a")
+	}
+	select {
+	case <-results:
+		t.Fatal("a request returned before the drain it joins started")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	first, second := <-results, <-results
+	if first.err != nil || second.err != nil {
+		t.Fatalf("StartDrain: %v, %v", first.err, second.err)
+	}
+	if first.response.BatchID != second.response.BatchID {
+		t.Errorf("batches %s and %s started for one node, want one", first.response.BatchID, second.response.BatchID)
+	}
+	if first.response.Coalesced == second.response.Coalesced {
+		t.Errorf("coalesced = %v and %v, want only the joining request coalesced", first.response.Coalesced, second.response.Coalesced)
+	}
+}
+
+func TestStartDrainConflictingRequest(t *testing.T) {
+	mc, _ := newTestController(t, fullMode, testNode("node-a"), testNode("node-b"), testNode("node-c"),
+		testPod("app", "node-a", "main"), testPod("db", "node-a", "main"))
+	defer mc.MarkStopped()
+
+	if _, err := mc.StartDrain("node-a", drainRequest(), ""); err != nil {
+		t.Fatal(err)
+	}
+	other := drainRequest()
+	other.TargetNode = "node-c"
+	if _, err := mc.StartDrain("node-a", other, ""); !errors.Is(err, ErrDrainConflict) {
+		t.Errorf("drain to another node: %v, want %v", err, ErrDrainConflict)
+	}
+	retried, err := mc.StartDrain("node-a", drainRequest(), "")
+	if err != nil || !retried.Coalesced {
+		t.Errorf("retried drain: %+v, %v; want it coalesced", retried, err)
+	}
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Policies for an original pod changed between planning a migration and acting on
+// the plan
+const (
+	// SpecDriftAbort fails and rolls back the migration
+	SpecDriftAbort = "abort"
+	// SpecDriftReplan plans the migration again from the changed pod and goes on,
+	// where the new pod has not been created yet
+	SpecDriftReplan = "replan"
+)
+
+// maxSpecDriftReplans bounds how often one migration is planned again, so a pod
+// edited continuously cannot keep it from ever finishing
+const maxSpecDriftReplans = 3
+
+// validateSpecDriftPolicy checks a spec drift policy name
+func validateSpecDriftPolicy(policy string) error {
+	switch policy {
+	case SpecDriftAbort, SpecDriftReplan:
+		return nil
+	}
+	return fmt.Errorf("spec drift policy must be %s or %s, got %q", SpecDriftAbort, SpecDriftReplan, policy)
+}
+
+// podSpecHash hashes what a migration plan is made from: the pod's identity, labels
+// and spec. The node name and ephemeral containers are left out, as the cluster sets
+// them without anyone editing the pod.
+func podSpecHash(pod *corev1.Pod) (string, error) {
+	spec := pod.Spec.DeepCopy()
+	spec.NodeName = ""
+	spec.EphemeralContainers = nil
+	data, err := json.Marshal(struct {
+		UID    string            `json:"uid"`
+		Labels map[string]string `json:"labels"`
+		Spec   *corev1.PodSpec   `json:"spec"`
+	}{string(pod.UID), pod.Labels, spec})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// checkApprovedSpec compares the pod a migration captured with the pod the caller
+// reviewed, if it named one, and with the pod its plan was approved on by the
+// policy service. A reviewed pod must not have changed. The captured plan is
+// already a new one, so under the replan policy the migration goes on with it once
+// the policy service approves it as well.
+func (mc *MigrationController) checkApprovedSpec(job *MigrationJob) error {
+	if expected := job.Request.ExpectedPodSpecHash; expected != "" && expected != job.Details.PodSpecHash {
+		return mc.specDrifted(job, stepCaptureState, expected, job.Details.PodSpecHash, false)
+	}
+	decision := job.Details.PolicyDecision
+	if decision == nil || decision.PodSpecHash == "" || decision.PodSpecHash == job.Details.PodSpecHash {
+		return nil
+	}
+	if err := mc.specDrifted(job, stepCaptureState, decision.PodSpecHash, job.Details.PodSpecHash, true); err != nil {
+		return err
+	}
+	return mc.approveReplan(job)
+}
+
+// checkSpecDrift reads the original pod again before step and compares it with the
+// pod the migration was planned from. A changed pod is planned again under the
+// replan policy when replannable is set, and replanned reports it; otherwise the
+// migration must stop.
+func (mc *MigrationController) checkSpecDrift(job *MigrationJob, step string, replannable bool) (replanned bool, err error) {
+	pod, err := mc.k8sClient.GetPod(job.ctx, job.Request.PodNamespace, job.Request.PodName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get pod: %w", err)
+	}
+	current, err := podSpecHash(pod)
+	if err != nil {
+		return false, err
+	}
+	if current == job.Details.PodSpecHash {
+		return false, nil
+	}
+	if err := mc.specDrifted(job, step, job.Details.PodSpecHash, current, replannable); err != nil {
+		return false, err
+	}
+	if err := mc.captureContainerStates(job); err != nil {
+		return false, err
+	}
+	return true, mc.approveReplan(job)
+}
+
+// approveReplan asks the policy service about a migration planned again, as its
+// approval was given for the old plan. The new decision replaces the old one.
+func (mc *MigrationController) approveReplan(job *MigrationJob) error {
+	if mc.policy == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(job.ctx, 30*time.Second)
+	defer cancel()
+
+	decision, err := mc.decidePolicy(ctx, job.Request, job.Details.Plan)
+	mc.migrationsMux.Lock()
+	job.Details.PolicyDecision = decision
+	mc.migrationsMux.Unlock()
+	if err != nil {
+		return fmt.Errorf("new plan not approved: %w", err)
+	}
+	mc.logf(job, "Policy service approved the new plan")
+	return nil
+}
+
+// specDrifted records a changed pod in the job's details and returns an error unless
+// the migration is to be planned again
+func (mc *MigrationController) specDrifted(job *MigrationJob, step, planned, current string, replannable bool) error {
+	drift := types.SpecDrift{
+		Step:        step,
+		DetectedAt:  time.Now(),
+		PlannedHash: planned,
+		CurrentHash: current,
+		Action:      SpecDriftAbort,
+	}
+	mc.migrationsMux.Lock()
+	if replannable && mc.config.specDriftPolicy == SpecDriftReplan && job.Request.ExpectedPodSpecHash == "" &&
+		replans(job.Details.SpecDrifts) < maxSpecDriftReplans {
+		drift.Action = SpecDriftReplan
+	}
+	job.Details.SpecDrifts = append(job.Details.SpecDrifts, drift)
+	mc.migrationsMux.Unlock()
+
+	if drift.Action == SpecDriftReplan {
+		mc.logf(job, "Pod spec changed since it was planned (hash %.12s, now %.12s); planning again", planned, current)
+		return nil
+	}
+	return fmt.Errorf("pod spec changed since it was planned (hash %.12s, now %.12s)", planned, current)
+}
+
+// replans counts the changes of a pod that were planned again
+func replans(drifts []types.SpecDrift) int {
+	count := 0
+	for _, drift := range drifts {
+		if drift.Action == SpecDriftReplan {
+			count++
+		}
+	}
+	return count
+}
+
+// discardCheckpoint deletes the checkpoint PVC taken for a plan that was replaced,
+// so that the next attempt takes one for the new plan
+func (mc *MigrationController) discardCheckpoint(job *MigrationJob) {
+	mc.deleteCreatedObjects(job.ctx, job, "Replan", false)
+
+	mc.migrationsMux.Lock()
+	job.Details.PVClaimName = ""
+	job.Details.CheckpointPath = ""
+	job.Details.CheckpointBinding = nil
+	job.Details.CheckpointVerification = nil
+	mc.migrationsMux.Unlock()
+}
@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// relabel edits the pod in the fake cluster the way someone editing it would. It
+// goes through the tracker, as reactors run under the clientset's lock.
+func relabel(t *testing.T, clientset *fake.Clientset, name string) {
+	t.Helper()
+	pods := corev1.SchemeGroupVersion.WithResource("pods")
+	obj, err := clientset.Tracker().Get(pods, testNamespace, name)
+	if err != nil {
+		t.Errorf("get pod %s: %v", name, err)
+		return
+	}
+	pod := obj.(*corev1.Pod).DeepCopy()
+	pod.Labels["edited"] = "true"
+	if err := clientset.Tracker().Update(pods, pod, testNamespace); err != nil {
+		t.Errorf("update pod %s: %v", name, err)
+	}
+}
+
+func replanMode(config *MigrationConfig) {
+	config.SafeMode = false
+	config.SpecDriftPolicy = SpecDriftReplan
+}
+
+func TestSpecDriftExpectedHashMismatch(t *testing.T) {
+	mc, clientset := newTestController(t, replanMode, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ExpectedPodSpecHash = "0123456789abcdef"
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusFailed {
+		t.Fatalf("status = %s (%s), want failed", response.Status, response.Message)
+	}
+	// A reviewed pod is never planned again, whatever the policy
+	drifts := response.Details.SpecDrifts
+	if len(drifts) != 1 || drifts[0].Action != SpecDriftAbort || drifts[0].PlannedHash != req.ExpectedPodSpecHash {
+		t.Errorf("spec drifts = %+v, want one abort from the expected hash", drifts)
+	}
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "create" {
+			t.Errorf("unexpected %s of %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+}
+
+func TestSpecDriftExpectedHashMatch(t *testing.T) {
+	mc, _ := newTestController(t, replanMode, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+	expected, err := podSpecHash(testPod("app", "node-a", "main"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ForceIgnorePDB = true
+	req.ExpectedPodSpecHash = expected
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+	}
+}
+
+// A plan made again after the pod changed needs the policy service's approval
+// like the one it replaces
+func TestSpecDriftReplanAsksPolicyAgain(t *testing.T) {
+	var clientset *fake.Clientset
+	var mu sync.Mutex
+	var hashes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input types.PolicyInput `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode policy input: %v", err)
+		}
+		mu.Lock()
+		hashes = append(hashes, body.Input.Plan.PodSpecHash)
+		first := len(hashes) == 1
+		mu.Unlock()
+		if first {
+			// Someone edits the pod right after it was approved
+			relabel(t, clientset, "app")
+			w.Write([]byte(`{"result": {"allow": true}}`))
+			return
+		}
+		w.Write([]byte(`{"result": {"allow": false, "reason": "edited pods stay put"}}`))
+	}))
+	defer server.Close()
+
+	mc, cs := newTestController(t, func(config *MigrationConfig) {
+		replanMode(config)
+		config.PolicyEndpoint = server.URL
+	}, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+	clientset = cs
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusFailed {
+		t.Fatalf("status = %s (%s), want failed", response.Status, response.Message)
+	}
+	if failure := response.Details.Failure; failure == nil || !strings.Contains(failure.Error, "edited pods stay put") {
+		t.Errorf("failure = %+v, want the policy's reason", failure)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(hashes) != 2 || hashes[0] == hashes[1] {
+		t.Fatalf("policy asked about hashes %v, want the approved and the changed pod", hashes)
+	}
+	if decision := response.Details.PolicyDecision; decision == nil || decision.Allowed || decision.PodSpecHash != hashes[1] {
+		t.Errorf("policy decision = %+v, want the denial of the new plan", decision)
+	}
+}
+
+// A checkpoint taken for the old plan is replaced when the pod changes before the
+// new pod is created
+func TestSpecDriftReplanRetakesCheckpoint(t *testing.T) {
+	mc, clientset := newTestController(t, replanMode, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+	var claims []string
+	clientset.PrependReactor("create", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		claim := action.(k8stesting.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+		claims = append(claims, claim.Name)
+		if len(claims) == 1 {
+			relabel(t, clientset, "app")
+		}
+		return false, nil, nil
+	})
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ForceIgnorePDB = true
+	req.PreservePV = true
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+	}
+	drifts := response.Details.SpecDrifts
+	if len(drifts) != 1 || drifts[0].Action != SpecDriftReplan || drifts[0].Step != stepCreatePod {
+		t.Fatalf("spec drifts = %+v, want one replan before create_pod", drifts)
+	}
+	if len(claims) != 2 || claims[0] == claims[1] {
+		t.Fatalf("checkpoint claims created = %v, want two of different names", claims)
+	}
+	if response.Details.PVClaimName != claims[1] {
+		t.Errorf("pv_claim_name = %q, want the second checkpoint %q", response.Details.PVClaimName, claims[1])
+	}
+	deleted := false
+	for _, action := range clientset.Actions() {
+		if del, ok := action.(k8stesting.DeleteAction); ok && action.GetResource().Resource == "persistentvolumeclaims" {
+			deleted = deleted || del.GetName() == claims[0]
+		}
+	}
+	if !deleted {
+		t.Errorf("checkpoint %s of the old plan not deleted", claims[0])
+	}
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"log"
+	"sort"
+	"time"
+)
+
+// trackMigrationLocked adds a job to the tracked migrations and, past the configured
+// cap, forgets the terminal migrations that finished longest ago. Migrations still
+// in progress are never evicted, so the cap can be exceeded while they run.
+// migrationsMux must be held for writing.
+func (mc *MigrationController) trackMigrationLocked(job *MigrationJob) {
+	mc.migrations[job.ID] = job
+
+	limit := mc.config.maxTrackedMigrations
+	excess := len(mc.migrations) - limit
+	if limit == 0 || excess <= 0 {
+		return
+	}
+
+	var finished []*MigrationJob
+	for _, tracked := range mc.migrations {
+		if len(migrationTransitions[tracked.Status]) == 0 && tracked.Details.EndTime != nil {
+			finished = append(finished, tracked)
+		}
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].Details.EndTime.Before(*finished[j].Details.EndTime)
+	})
+	if len(finished) > excess {
+		finished = finished[:excess]
+	}
+	for _, evicted := range finished {
+		delete(mc.migrations, evicted.ID)
+		log.Printf("Migration %s: evicted, %d migrations tracked (limit %d); finished %s as %s",
+			evicted.ID, len(mc.migrations), limit, evicted.Details.EndTime.Format(time.RFC3339), evicted.Status)
+	}
+}
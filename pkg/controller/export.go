@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// sinkQueueSize bounds the records waiting to be written to the migration sink
+const sinkQueueSize = 256
+
+// exportRecord queues a finished migration for the sink without blocking the
+// migration; records are dropped with a log line when the queue is full
+func (mc *MigrationController) exportRecord(record *types.MigrationRecord) {
+	select {
+	case mc.sinkQueue <- record:
+	default:
+		log.Printf("Migration %s: sink queue full, dropping record", record.MigrationID)
+	}
+}
+
+// runSinkWriter writes queued records to the sink; failures are logged and never
+// affect migrations
+func (mc *MigrationController) runSinkWriter() {
+	for record := range mc.sinkQueue {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := mc.sink.Write(ctx, record); err != nil {
+			log.Printf("Migration %s: failed to export record: %v", record.MigrationID, err)
+		}
+		cancel()
+	}
+}
+
+// buildRecord flattens the final summary of a migration into an export record
+func buildRecord(summary *types.MigrationSummary) *types.MigrationRecord {
+	endTime := summary.EndTime
+	durationMs := summary.Duration.Milliseconds()
+	record := &types.MigrationRecord{
+		MigrationID:        summary.MigrationID,
+		RequestID:          summary.RequestID,
+		PodName:            summary.PodName,
+		PodNamespace:       summary.PodNamespace,
+		SourceNode:         summary.SourceNode,
+		TargetNode:         summary.TargetNode,
+		NewPodName:         summary.NewPodName,
+		Status:             summary.Status,
+		StartTime:          summary.StartTime,
+		EndTime:            &endTime,
+		DurationMs:         &durationMs,
+		ContainersTotal:    summary.ContainersTotal,
+		ContainersMigrated: summary.ContainersMigrated,
+		CPUSavings:         summary.CPUSavings,
+		MemorySavings:      summary.MemorySavings,
+		GPUSavings:         summary.GPUSavings,
+		CheckpointPVC:      summary.CheckpointPVC,
+	}
+
+	if original := summary.OriginalResources; original != nil {
+		record.OriginalCPU = &original.CPUUsage
+		record.OriginalMemory = &original.MemoryUsage
+	}
+	if optimized := summary.OptimizedResources; optimized != nil {
+		record.OptimizedCPU = &optimized.CPUUsage
+		record.OptimizedMemory = &optimized.MemoryUsage
+	}
+	if summary.GPUSavings != nil {
+		record.OriginalGPU = &summary.OriginalResources.GPUUsage
+		record.OptimizedGPU = &summary.OptimizedResources.GPUUsage
+	}
+	return record
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+)
+
+// faultSteps are the steps at which a failure can be injected, in execution order
+var faultSteps = []string{stepCaptureState, stepCheckpoint, stepCreatePod, stepVerify, stepCutover}
+
+// NormalizeFaultStep maps a requested injection point such as "create-pod" to its
+// step name, failing for unknown steps
+func NormalizeFaultStep(step string) (string, error) {
+	normalized := strings.ReplaceAll(step, "-", "_")
+	for _, known := range faultSteps {
+		if normalized == known {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("unknown step %q for fault injection: must be one of %s", step, strings.Join(faultSteps, ", "))
+}
+
+// injectedFault fails the step if the request asked for a failure there. It is
+// called once the step's own work succeeded, so the rollback path runs against the
+// real objects the step created.
+func (mc *MigrationController) injectedFault(job *MigrationJob, step string) error {
+	if job.Request.FailAt != step {
+		return nil
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.InjectedFault = step
+	mc.migrationsMux.Unlock()
+
+	mc.logf(job, "Injecting failure at step %s as requested", step)
+	return fmt.Errorf("injected failure at step %s", step)
+}
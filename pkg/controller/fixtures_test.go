@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/metrics"
+	"ai-storage-orchestrator/pkg/sink"
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+const testNamespace = "default"
+
+// newTestController creates a controller on a fake clientset holding objects.
+// configure, if not nil, adjusts the configuration first.
+func newTestController(t testing.TB, configure func(*MigrationConfig), objects ...runtime.Object) (*MigrationController, *fake.Clientset) {
+	t.Helper()
+	clientset := newTestClientset(objects...)
+	return newTestControllerFor(t, clientset, configure), clientset
+}
+
+// newTestClientset returns a fake clientset holding objects. The fake API server is
+// Kubernetes 1.28 serving policy/v1.
+func newTestClientset(objects ...runtime.Object) *fake.Clientset {
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1"},
+		{GroupVersion: "policy/v1", APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets", Namespaced: true, Kind: "PodDisruptionBudget"}}},
+	}
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.28.0", Platform: "linux/amd64"}
+	return clientset
+}
+
+// newTestControllerFor creates a controller on clientset, e.g. a fake one wrapped to
+// intercept calls. configure, if not nil, adjusts the configuration first.
+func newTestControllerFor(t testing.TB, clientset kubernetes.Interface, configure func(*MigrationConfig)) *MigrationController {
+	t.Helper()
+	client := k8s.NewClientForClientsets(clientset, metricsfake.NewSimpleClientset())
+
+	config := DefaultMigrationConfig()
+	config.PodReadyPollInterval = 10 * time.Millisecond
+	config.MetricsStabilizationDelay = 0
+	if configure != nil {
+		configure(&config)
+	}
+	mc, err := NewMigrationController(client, metrics.NewMetricsServerProvider(client), sink.NoopSink{}, config)
+	if err != nil {
+		t.Fatalf("NewMigrationController: %v", err)
+	}
+	return mc
+}
+
+// testNode returns a ready, schedulable node
+func testNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelArchStable: "amd64"}},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+			},
+		},
+	}
+}
+
+// testPod returns a running pod on node as read back from the API server, with one
+// running container per name
+func testPod(name, node string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         testNamespace,
+			UID:               "0f0e3f44-4c3a-4f4b-9c55-5a1c2e7a9f10",
+			ResourceVersion:   "4711",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			Labels:            map[string]string{"app": name},
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container, Image: container + ":1.0"})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  container,
+			Ready: true,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-time.Hour))}},
+		})
+	}
+	return pod
+}
+
+// testClaim returns a bound PVC with the given access mode
+func testClaim(name string, mode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec:       corev1.PersistentVolumeClaimSpec{AccessModes: []corev1.PersistentVolumeAccessMode{mode}},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase:       corev1.ClaimBound,
+			AccessModes: []corev1.PersistentVolumeAccessMode{mode},
+		},
+	}
+}
+
+// testRequest returns a request migrating pod from source to target
+func testRequest(pod, source, target string) *types.MigrationRequest {
+	return &types.MigrationRequest{
+		PodName:      pod,
+		PodNamespace: testNamespace,
+		SourceNode:   source,
+		TargetNode:   target,
+		MigrationOptions: types.MigrationOptions{
+			Timeout: 60,
+		},
+	}
+}
+
+// testJob returns a job of a migration not started through the controller
+func testJob() *MigrationJob {
+	return &MigrationJob{
+		ID:         "migration-test",
+		ctx:        context.Background(),
+		Request:    testRequest("app", "node-a", "node-b"),
+		Details:    &types.MigrationDetails{},
+		logUpdated: make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// runMigration starts a migration and waits for it to finish
+func runMigration(t testing.TB, mc *MigrationController, req *types.MigrationRequest) *types.MigrationResponse {
+	t.Helper()
+	started, err := mc.StartMigration(req)
+	if err != nil {
+		t.Fatalf("StartMigration: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	response, finished, err := mc.WaitForMigration(ctx, started.MigrationID)
+	if err != nil {
+		t.Fatalf("WaitForMigration: %v", err)
+	}
+	if !finished {
+		t.Fatalf("migration %s did not finish, status %s", started.MigrationID, response.Status)
+	}
+	return response
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"fmt"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// ValidateContainerGrouping checks that every group names at least one container and
+// that no container belongs to more than one group
+func ValidateContainerGrouping(groups [][]string) error {
+	seen := make(map[string]int)
+	for i, group := range groups {
+		if len(group) == 0 {
+			return fmt.Errorf("container_grouping[%d] is empty", i)
+		}
+		for _, name := range group {
+			if name == "" {
+				return fmt.Errorf("container_grouping[%d] contains an empty container name", i)
+			}
+			if previous, exists := seen[name]; exists {
+				return fmt.Errorf("container %s appears in container_grouping[%d] and container_grouping[%d]", name, previous, i)
+			}
+			seen[name] = i
+		}
+	}
+	return nil
+}
+
+// applyContainerGrouping keeps every member of a group when any member is kept, so
+// tightly coupled containers are never split by the optimization. States are updated
+// in place and one decision is returned per group.
+func applyContainerGrouping(states []types.ContainerState, groups [][]string) []types.ContainerGroupDecision {
+	index := make(map[string]int, len(states))
+	for i, state := range states {
+		index[state.Name] = i
+	}
+
+	decisions := make([]types.ContainerGroupDecision, 0, len(groups))
+	for _, group := range groups {
+		decision := types.ContainerGroupDecision{Containers: group}
+
+		for _, name := range group {
+			i, exists := index[name]
+			if !exists {
+				decision.Missing = append(decision.Missing, name)
+				continue
+			}
+			if states[i].ShouldMigrate {
+				decision.Kept = true
+			}
+		}
+
+		if decision.Kept {
+			for _, name := range group {
+				i, exists := index[name]
+				if exists && !states[i].ShouldMigrate {
+					states[i].ShouldMigrate = true
+					states[i].Reason = fmt.Sprintf("migrate with its container group (was: %s)", states[i].Reason)
+					decision.Promoted = append(decision.Promoted, name)
+				}
+			}
+		}
+
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
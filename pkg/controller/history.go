@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"ai-storage-orchestrator/pkg/sink"
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// PodMigrationHistory returns the migrations of a pod kept in memory, oldest first.
+// A migrated pod gets a new name, so the history follows the pod back through the
+// migrations that created it.
+func (mc *MigrationController) PodMigrationHistory(namespace, name string) []types.PodMigrationEntry {
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	names := map[string]bool{name: true}
+	for grown := true; grown; {
+		grown = false
+		for _, job := range mc.migrations {
+			if job.Request.PodNamespace == namespace && names[job.Details.NewPodName] && !names[job.Request.PodName] {
+				names[job.Request.PodName] = true
+				grown = true
+			}
+		}
+	}
+
+	var history []types.PodMigrationEntry
+	for _, job := range mc.migrations {
+		if job.Request.PodNamespace != namespace || !names[job.Request.PodName] {
+			continue
+		}
+		history = append(history, types.PodMigrationEntry{
+			MigrationID: job.ID,
+			Status:      job.Status,
+			PodName:     job.Request.PodName,
+			NewPodName:  job.Details.NewPodName,
+			SourceNode:  job.Request.SourceNode,
+			TargetNode:  job.Request.TargetNode,
+			StartTime:   job.StartTime,
+			EndTime:     job.Details.EndTime,
+		})
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].StartTime.Before(history[j].StartTime)
+	})
+	return history
+}
+
+// GetMetricsSummary aggregates the records of the migrations accepted by allowed that
+// ended in [from, to). They are read from the sink when it can be read back, so
+// migrations from before a restart or evicted from memory still count; otherwise
+// only the migrations still in memory are counted.
+func (mc *MigrationController) GetMetricsSummary(ctx context.Context, from, to time.Time, allowed func(namespace string) bool) (*types.MetricsSummary, error) {
+	report := &types.MetricsSummary{From: from, To: to, Source: types.MetricsSourceMemory}
+	var records []*types.MigrationRecord
+	if reader, ok := mc.sink.(sink.RecordReader); ok {
+		var err error
+		records, err = reader.Records(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		report.Source = types.MetricsSourceSink
+	} else {
+		records = mc.finishedRecords(from, to)
+	}
+
+	var totalDuration time.Duration
+	var cpuSavings, memorySavings float64
+	var cpuMeasured, memoryMeasured int
+	for _, record := range records {
+		if !allowed(record.PodNamespace) {
+			continue
+		}
+
+		report.TotalMigrations++
+		switch {
+		case record.Status.Succeeded():
+			report.SuccessfulMigrations++
+			if record.DurationMs != nil {
+				totalDuration += time.Duration(*record.DurationMs) * time.Millisecond
+			}
+		case record.Status == types.MigrationStatusFailed:
+			report.FailedMigrations++
+		case record.Status == types.MigrationStatusCancelled:
+			report.CancelledMigrations++
+		}
+
+		if record.OriginalCPU == nil || record.OptimizedCPU == nil {
+			continue
+		}
+		report.MeasuredMigrations++
+		if record.CPUSavings != nil {
+			cpuSavings += *record.CPUSavings
+			cpuMeasured++
+		}
+		if record.MemorySavings != nil {
+			memorySavings += *record.MemorySavings
+			memoryMeasured++
+		}
+		report.CPUCoresSaved += *record.OriginalCPU - *record.OptimizedCPU
+		if record.OriginalMemory != nil && record.OptimizedMemory != nil {
+			report.MemoryBytesSaved += *record.OriginalMemory - *record.OptimizedMemory
+		}
+		if record.OriginalGPU != nil && record.OptimizedGPU != nil {
+			report.GPUsSaved += *record.OriginalGPU - *record.OptimizedGPU
+		}
+	}
+
+	if report.TotalMigrations > 0 {
+		report.SuccessRate = float64(report.SuccessfulMigrations) / float64(report.TotalMigrations) * 100
+	}
+	if report.SuccessfulMigrations > 0 {
+		report.AverageDuration = totalDuration / time.Duration(report.SuccessfulMigrations)
+	}
+	if cpuMeasured > 0 {
+		report.CPUSavings = cpuSavings / float64(cpuMeasured)
+	}
+	if memoryMeasured > 0 {
+		report.MemorySavings = memorySavings / float64(memoryMeasured)
+	}
+	return report, nil
+}
+
+// finishedRecords builds the records of the migrations in memory that ended in
+// [from, to), as they are exported to the sink
+func (mc *MigrationController) finishedRecords(from, to time.Time) []*types.MigrationRecord {
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	var records []*types.MigrationRecord
+	for _, job := range mc.migrations {
+		summary := job.summary
+		if summary == nil || summary.EndTime.Before(from) || !summary.EndTime.Before(to) {
+			continue
+		}
+		records = append(records, buildRecord(summary))
+	}
+	return records
+}
@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// recordSink keeps the records written to it and reads them back
+type recordSink struct {
+	mu      sync.Mutex
+	records map[string]*types.MigrationRecord
+	err     error // returned by Records when set
+}
+
+func (s *recordSink) Write(ctx context.Context, record *types.MigrationRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records == nil {
+		s.records = make(map[string]*types.MigrationRecord)
+	}
+	s.records[record.MigrationID] = record
+	return nil
+}
+
+func (s *recordSink) Records(ctx context.Context, from, to time.Time) ([]*types.MigrationRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	var records []*types.MigrationRecord
+	for _, record := range s.records {
+		if !record.EndTime.Before(from) && record.EndTime.Before(to) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (s *recordSink) Close() error { return nil }
+
+func (s *recordSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.records)
+}
+
+// runSummaryMigrations runs one migration that completes and one that fails
+func runSummaryMigrations(t *testing.T, mc *MigrationController) {
+	t.Helper()
+	ok := testRequest("app", "node-a", "node-b")
+	ok.SuccessCriteria = types.SuccessCriteriaPodCreated
+	if response := runMigration(t, mc, ok); !response.Status.Succeeded() {
+		t.Fatalf("status = %s (%s), want success", response.Status, response.Message)
+	}
+	failing := testRequest("db", "node-a", "node-b")
+	failing.ExpectedPodSpecHash = "0123456789abcdef"
+	if response := runMigration(t, mc, failing); response.Status != types.MigrationStatusFailed {
+		t.Fatalf("status = %s (%s), want failed", response.Status, response.Message)
+	}
+}
+
+func allNamespaces(string) bool { return true }
+
+// Migrations no longer in memory, e.g. from before a restart, still count when the
+// sink can be read back
+func TestMetricsSummaryReadsSink(t *testing.T) {
+	mc, _ := newTestController(t, nil, testNode("node-a"), testNode("node-b"),
+		testPod("app", "node-a", "main"), testPod("db", "node-a", "main"))
+	records := &recordSink{}
+	mc.sink = records
+	runSummaryMigrations(t, mc)
+	waitFor(t, "the records to be exported", func() bool { return records.count() == 2 })
+
+	mc.migrationsMux.Lock()
+	mc.migrations = make(map[string]*MigrationJob)
+	mc.migrationsMux.Unlock()
+
+	summary, err := mc.GetMetricsSummary(context.Background(), time.Now().Add(-time.Hour), time.Now(), allNamespaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Source != types.MetricsSourceSink {
+		t.Errorf("source = %q, want %q", summary.Source, types.MetricsSourceSink)
+	}
+	if summary.TotalMigrations != 2 || summary.SuccessfulMigrations != 1 || summary.FailedMigrations != 1 {
+		t.Errorf("total/successful/failed = %d/%d/%d, want 2/1/1",
+			summary.TotalMigrations, summary.SuccessfulMigrations, summary.FailedMigrations)
+	}
+
+	// An empty window is all zeros rather than an error
+	empty, err := mc.GetMetricsSummary(context.Background(), time.Now().Add(time.Hour), time.Now().Add(2*time.Hour), allNamespaces)
+	if err != nil || empty.TotalMigrations != 0 || empty.SuccessRate != 0 {
+		t.Errorf("empty window = %+v, %v; want zeros", empty, err)
+	}
+
+	records.mu.Lock()
+	records.err = errors.New("connection refused")
+	records.mu.Unlock()
+	if _, err := mc.GetMetricsSummary(context.Background(), time.Now().Add(-time.Hour), time.Now(), allNamespaces); err == nil {
+		t.Error("an unreadable sink did not fail the summary")
+	}
+}
+
+func TestMetricsSummaryWithoutReadableSink(t *testing.T) {
+	mc, _ := newTestController(t, nil, testNode("node-a"), testNode("node-b"),
+		testPod("app", "node-a", "main"), testPod("db", "node-a", "main"))
+	runSummaryMigrations(t, mc)
+
+	summary, err := mc.GetMetricsSummary(context.Background(), time.Now().Add(-time.Hour), time.Now(), allNamespaces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Source != types.MetricsSourceMemory || summary.TotalMigrations != 2 || summary.SuccessRate != 50 {
+		t.Errorf("summary = %+v, want both migrations from memory", summary)
+	}
+}
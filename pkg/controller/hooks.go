@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// lifecycleHooks lists the containers of a pod with PostStart or PreStop hooks.
+// The new pod is a copy of the original, so kept containers keep their hooks as is.
+func lifecycleHooks(pod *corev1.Pod, states []types.ContainerState) []types.ContainerHooks {
+	migrated := make(map[string]bool, len(states))
+	for _, state := range states {
+		migrated[state.Name] = state.ShouldMigrate
+	}
+
+	var hooks []types.ContainerHooks
+	for _, container := range pod.Spec.Containers {
+		if container.Lifecycle == nil {
+			continue
+		}
+		entry := types.ContainerHooks{
+			Container: container.Name,
+			PostStart: hookKind(container.Lifecycle.PostStart),
+			PreStop:   hookKind(container.Lifecycle.PreStop),
+			Migrated:  migrated[container.Name],
+		}
+		if entry.PostStart != "" || entry.PreStop != "" {
+			hooks = append(hooks, entry)
+		}
+	}
+	return hooks
+}
+
+// hookKind names how a lifecycle hook is run, or returns "" when there is none
+func hookKind(handler *corev1.LifecycleHandler) string {
+	switch {
+	case handler == nil:
+		return ""
+	case handler.Exec != nil:
+		return "exec"
+	case handler.HTTPGet != nil:
+		return "http_get"
+	case handler.TCPSocket != nil:
+		return "tcp_socket"
+	default:
+		return "unknown"
+	}
+}
+
+// hasPostStartHook reports whether a kept container has a PostStart hook, which
+// delays its start and so the new pod's readiness
+func hasPostStartHook(hooks []types.ContainerHooks) bool {
+	for _, hook := range hooks {
+		if hook.Migrated && hook.PostStart != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPreStopHook reports whether any container of the original pod has a PreStop hook
+func hasPreStopHook(hooks []types.ContainerHooks) bool {
+	for _, hook := range hooks {
+		if hook.PreStop != "" {
+			return true
+		}
+	}
+	return false
+}
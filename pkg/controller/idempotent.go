@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// findMigratedPod looks for a completed earlier migration of the pod of a request:
+// the original pod is gone, and a ready pod the orchestrator created for it, labeled
+// with and running on the requested target node (or a node matching the target node
+// selector), exists in its namespace. It returns nil when there is none or the
+// cluster cannot tell, leaving the request to run as a new migration.
+func (mc *MigrationController) findMigratedPod(ctx context.Context, req *types.MigrationRequest) *corev1.Pod {
+	if _, err := mc.k8sClient.GetPod(ctx, req.PodNamespace, req.PodName); !apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	pods, err := mc.k8sClient.ListMigratedPods(ctx, req.PodNamespace, req.PodName)
+	if err != nil {
+		return nil
+	}
+	for i := range pods {
+		pod := &pods[i]
+		node := pod.Spec.NodeName
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || !podReady(pod) {
+			continue
+		}
+		if node == "" || pod.Labels[k8s.TargetNodeLabel] != node {
+			continue
+		}
+		if req.TargetNode != "" && node != req.TargetNode {
+			continue
+		}
+		if len(req.TargetNodeSelector) > 0 && !mc.nodeMatches(ctx, node, req.TargetNodeSelector) {
+			continue
+		}
+		return pod
+	}
+	return nil
+}
+
+// nodeMatches reports whether a node's labels match a node selector
+func (mc *MigrationController) nodeMatches(ctx context.Context, name string, selector map[string]string) bool {
+	node, err := mc.k8sClient.GetNode(ctx, name)
+	if err != nil {
+		return false
+	}
+	return labels.SelectorFromSet(selector).Matches(labels.Set(node.Labels))
+}
+
+// podReady reports whether a pod's Ready condition is true
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// recordAlreadyMigrated registers a migration that is satisfied by an existing
+// migrated pod. It completes right away without touching the cluster and, as it
+// migrated nothing, stays out of the metrics and exports.
+func (mc *MigrationController) recordAlreadyMigrated(req *types.MigrationRequest, pod *corev1.Pod) *MigrationJob {
+	now := time.Now()
+	duration := time.Duration(0)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	req.TargetNode = pod.Spec.NodeName
+	job := &MigrationJob{
+		ID:        fmt.Sprintf("migration-%s", uuid.New().String()[:8]),
+		Request:   req,
+		Status:    types.MigrationStatusCompleted,
+		StartTime: now,
+		Details: &types.MigrationDetails{
+			RequestID:       req.RequestID,
+			StartTime:       now,
+			EndTime:         &now,
+			Duration:        &duration,
+			NewPodName:      pod.Name,
+			AlreadyMigrated: true,
+		},
+		ctx:        ctx,
+		cancel:     cancel,
+		logUpdated: make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	close(job.done)
+
+	mc.migrationsMux.Lock()
+	mc.trackMigrationLocked(job)
+	mc.migrationsMux.Unlock()
+
+	mc.logf(job, "Pod %s/%s was already migrated to node %s as %s, nothing to do",
+		req.PodNamespace, req.PodName, pod.Spec.NodeName, pod.Name)
+	return job
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Policies for a checkpoint that fails its integrity check before the new pod
+// restores from it
+const (
+	// CheckpointIntegrityFail fails and rolls back the migration
+	CheckpointIntegrityFail = "fail"
+	// CheckpointIntegrityColdRestart creates the new pod without the checkpoint, as
+	// with force_restart
+	CheckpointIntegrityColdRestart = "cold_restart"
+)
+
+// CheckpointChecksumAnnotation holds the checksum of a checkpoint PVC as created
+const CheckpointChecksumAnnotation = "migration.ai-storage/checkpoint-checksum"
+
+// validateCheckpointIntegrityPolicy checks a checkpoint integrity policy name
+func validateCheckpointIntegrityPolicy(policy string) error {
+	switch policy {
+	case CheckpointIntegrityFail, CheckpointIntegrityColdRestart:
+		return nil
+	}
+	return fmt.Errorf("checkpoint integrity policy must be %s or %s, got %q",
+		CheckpointIntegrityFail, CheckpointIntegrityColdRestart, policy)
+}
+
+// checkpointChecksum fingerprints a checkpoint: the claim's identity, size and
+// access modes and, when bound, the identity and capacity of the volume behind it.
+// A claim that was replaced, resized or rebound no longer holds what was written.
+func checkpointChecksum(pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "claim=%s/%s uid=%s", pvc.Namespace, pvc.Name, pvc.UID)
+	if pvc.Spec.StorageClassName != nil {
+		fmt.Fprintf(&b, " class=%s", *pvc.Spec.StorageClassName)
+	}
+	request := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	fmt.Fprintf(&b, " request=%s modes=%v", request.String(), pvc.Spec.AccessModes)
+	if pv != nil {
+		capacity := pv.Spec.Capacity[corev1.ResourceStorage]
+		fmt.Fprintf(&b, " volume=%s volume_uid=%s capacity=%s", pv.Name, pv.UID, capacity.String())
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkpointVolume returns the volume a checkpoint PVC is bound to, or nil when it
+// is not bound yet, as with WaitForFirstConsumer storage classes
+func (mc *MigrationController) checkpointVolume(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolume, error) {
+	if pvc.Spec.VolumeName == "" {
+		return nil, nil
+	}
+	pv, err := mc.k8sClient.GetPersistentVolume(ctx, pvc.Spec.VolumeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume %s: %w", pvc.Spec.VolumeName, err)
+	}
+	return pv, nil
+}
+
+// recordCheckpointChecksum stores the checksum of a newly created checkpoint on its
+// PVC and in the details
+func (mc *MigrationController) recordCheckpointChecksum(ctx context.Context, job *MigrationJob, claimName string) error {
+	pvc, err := mc.k8sClient.GetPersistentVolumeClaim(ctx, job.Request.PodNamespace, claimName)
+	if err != nil {
+		return fmt.Errorf("failed to get checkpoint PVC %s: %w", claimName, err)
+	}
+	pv, err := mc.checkpointVolume(ctx, pvc)
+	if err != nil {
+		return err
+	}
+	checksum := checkpointChecksum(pvc, pv)
+	if err := mc.k8sClient.AnnotatePersistentVolumeClaim(ctx, job.Request.PodNamespace, claimName, CheckpointChecksumAnnotation, checksum); err != nil {
+		return fmt.Errorf("failed to store checksum on checkpoint PVC %s: %w", claimName, err)
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.CheckpointVerification = &types.CheckpointVerification{Checksum: checksum, VolumeBound: pv != nil}
+	mc.migrationsMux.Unlock()
+	return nil
+}
+
+// verifyCheckpoint checks a checkpoint against the checksum stored when it was
+// created, right before the new pod restores from it. It returns whether the new
+// pod mounts the checkpoint: a corrupt one fails the migration, or under the
+// cold_restart policy is left out so the containers start without it.
+func (mc *MigrationController) verifyCheckpoint(job *MigrationJob, claimName string) (bool, error) {
+	verification := job.Details.CheckpointVerification
+	if verification == nil {
+		return true, nil
+	}
+
+	reason := mc.checkpointCorruption(job, claimName, verification)
+	now := time.Now()
+	mc.migrationsMux.Lock()
+	verification.VerifiedAt = &now
+	verification.Passed = reason == ""
+	verification.Reason = reason
+	verification.Action = types.CheckpointActionRestore
+	if reason != "" {
+		verification.Action = types.CheckpointActionFail
+		if mc.config.checkpointIntegrityPolicy == CheckpointIntegrityColdRestart {
+			verification.Action = types.CheckpointActionColdRestart
+		}
+	}
+	mc.migrationsMux.Unlock()
+
+	switch verification.Action {
+	case types.CheckpointActionRestore:
+		mc.logf(job, "Checkpoint PVC %s passed its integrity check", claimName)
+		return true, nil
+	case types.CheckpointActionColdRestart:
+		job.Details.CheckpointSkippedReason = "checkpoint failed its integrity check: " + reason
+		mc.logf(job, "Warning: checkpoint PVC %s failed its integrity check (%s); starting containers without it", claimName, reason)
+		return false, nil
+	default:
+		return false, fmt.Errorf("checkpoint integrity check failed: %s", reason)
+	}
+}
+
+// checkpointCorruption compares a checkpoint PVC with its recorded checksum and says
+// what is wrong with it, or returns "" when it is intact
+func (mc *MigrationController) checkpointCorruption(job *MigrationJob, claimName string, verification *types.CheckpointVerification) string {
+	ctx, cancel := context.WithTimeout(job.ctx, 30*time.Second)
+	defer cancel()
+
+	pvc, err := mc.k8sClient.GetPersistentVolumeClaim(ctx, job.Request.PodNamespace, claimName)
+	if err != nil {
+		return fmt.Sprintf("failed to get checkpoint PVC %s: %v", claimName, err)
+	}
+	switch {
+	case pvc.DeletionTimestamp != nil:
+		return "checkpoint PVC is being deleted"
+	case pvc.Status.Phase == corev1.ClaimLost:
+		return "checkpoint PVC lost its volume"
+	case pvc.Annotations[CheckpointChecksumAnnotation] != verification.Checksum:
+		return "checksum stored on the checkpoint PVC does not match the one recorded at creation"
+	}
+
+	// A volume bound only since then, for the new pod, was not part of the checksum
+	var pv *corev1.PersistentVolume
+	if verification.VolumeBound {
+		if pv, err = mc.checkpointVolume(ctx, pvc); err != nil {
+			return err.Error()
+		}
+		if pv == nil {
+			return "checkpoint PVC is no longer bound to its volume"
+		}
+	}
+	if checkpointChecksum(pvc, pv) != verification.Checksum {
+		return "checkpoint PVC or its volume changed since the checkpoint was created"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestCheckpointIntegrity(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		tamper     func(*corev1.PersistentVolumeClaim)
+		wantAction string
+		wantErr    bool
+	}{
+		{name: "intact", policy: CheckpointIntegrityFail, wantAction: types.CheckpointActionRestore},
+		{
+			name:   "replaced claim fails",
+			policy: CheckpointIntegrityFail,
+			tamper: func(pvc *corev1.PersistentVolumeClaim) {
+				pvc.UID = k8stypes.UID("replaced")
+			},
+			wantAction: types.CheckpointActionFail,
+			wantErr:    true,
+		},
+		{
+			name:   "lost claim restarts cold",
+			policy: CheckpointIntegrityColdRestart,
+			tamper: func(pvc *corev1.PersistentVolumeClaim) {
+				pvc.Status.Phase = corev1.ClaimLost
+			},
+			wantAction: types.CheckpointActionColdRestart,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := testClaim("checkpoint-app", corev1.ReadWriteOnce)
+			claim.UID = "original"
+			mc, clientset := newTestController(t, func(config *MigrationConfig) {
+				config.CheckpointIntegrityPolicy = tt.policy
+			}, claim)
+			job := testJob()
+
+			if err := mc.recordCheckpointChecksum(context.Background(), job, claim.Name); err != nil {
+				t.Fatalf("recordCheckpointChecksum: %v", err)
+			}
+			if tt.tamper != nil {
+				pvcs := clientset.CoreV1().PersistentVolumeClaims(testNamespace)
+				stored, err := pvcs.Get(context.Background(), claim.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				tt.tamper(stored)
+				if _, err := pvcs.Update(context.Background(), stored, metav1.UpdateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			restore, err := mc.verifyCheckpoint(job, claim.Name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyCheckpoint error = %v, want error %v", err, tt.wantErr)
+			}
+			verification := job.Details.CheckpointVerification
+			if verification.Action != tt.wantAction {
+				t.Errorf("action = %s, want %s", verification.Action, tt.wantAction)
+			}
+			if restore != (tt.wantAction == types.CheckpointActionRestore) || verification.Passed != restore {
+				t.Errorf("restore = %v, passed = %v for action %s", restore, verification.Passed, verification.Action)
+			}
+		})
+	}
+}
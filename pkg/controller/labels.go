@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultStrippedLabels are labels set by workload controllers on the pods they own.
+// On a standalone migrated pod they are meaningless, and some of them make the
+// original owner adopt or garbage-collect the pod.
+var DefaultStrippedLabels = []string{
+	"pod-template-hash",
+	"controller-revision-hash",
+	"statefulset.kubernetes.io/pod-name",
+	"apps.kubernetes.io/pod-index",
+	"batch.kubernetes.io/controller-uid",
+	"batch.kubernetes.io/job-name",
+	"controller-uid",
+	"job-name",
+}
+
+// ParseLabelKeys parses a comma-separated list of label keys
+func ParseLabelKeys(value string) []string {
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// stripLabels removes the given label keys from a pod and returns the keys it had
+func stripLabels(pod *corev1.Pod, keys []string) []string {
+	var stripped []string
+	for _, key := range keys {
+		if _, exists := pod.Labels[key]; exists {
+			delete(pod.Labels, key)
+			stripped = append(stripped, key)
+		}
+	}
+	sort.Strings(stripped)
+	return stripped
+}
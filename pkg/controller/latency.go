@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// podStartupTimes returns when a pod was scheduled and when its last container
+// started; zero values mean the event has not happened yet
+func podStartupTimes(pod *corev1.Pod) (scheduled, started time.Time) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionTrue {
+			scheduled = condition.LastTransitionTime.Time
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		var startedAt time.Time
+		switch {
+		case status.State.Running != nil:
+			startedAt = status.State.Running.StartedAt.Time
+		case status.State.Terminated != nil:
+			startedAt = status.State.Terminated.StartedAt.Time
+		default:
+			// A container that has not started yet means the pod has not started
+			return scheduled, time.Time{}
+		}
+		if startedAt.After(started) {
+			started = startedAt
+		}
+	}
+	return scheduled, started
+}
+
+// recordStartupLatency splits the new pod's startup into the time it spent Pending
+// before being scheduled and the time from scheduling until all its containers
+// started. It returns a description of the phase the pod is stuck in when it never
+// got past it, for failure messages.
+func (mc *MigrationController) recordStartupLatency(ctx context.Context, job *MigrationJob) string {
+	pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+	if err != nil {
+		return ""
+	}
+
+	created := pod.CreationTimestamp.Time
+	scheduled, started := podStartupTimes(pod)
+	if scheduled.IsZero() {
+		reason := "new pod was never scheduled"
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Message != "" {
+				reason = fmt.Sprintf("%s: %s", reason, condition.Message)
+			}
+		}
+		return reason
+	}
+
+	schedulingLatency := scheduled.Sub(created)
+	mc.migrationsMux.Lock()
+	job.Details.SchedulingLatency = &schedulingLatency
+	mc.migrationsMux.Unlock()
+
+	if started.IsZero() {
+		return fmt.Sprintf("new pod was scheduled after %s but its containers never started", schedulingLatency.Round(time.Second))
+	}
+
+	startupLatency := started.Sub(scheduled)
+	mc.migrationsMux.Lock()
+	job.Details.StartupLatency = &startupLatency
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "New pod %s was scheduled after %s and started %s later",
+		pod.Name, schedulingLatency.Round(time.Millisecond), startupLatency.Round(time.Millisecond))
+	return ""
+}
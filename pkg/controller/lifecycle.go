@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// ErrDraining is returned when new work is submitted after shutdown has begun
+var ErrDraining = errors.New("orchestrator is shutting down and not accepting new migrations")
+
+// inFlightPollInterval is how often WaitForInFlight rechecks the remaining work
+const inFlightPollInterval = time.Second
+
+// Lifecycle returns the current lifecycle state
+func (mc *MigrationController) Lifecycle() types.LifecycleState {
+	mc.lifecycleMux.RLock()
+	defer mc.lifecycleMux.RUnlock()
+	return mc.lifecycle
+}
+
+// BeginDrain stops accepting new migrations, batches and drains. Work already
+// started keeps running.
+func (mc *MigrationController) BeginDrain() {
+	mc.lifecycleMux.Lock()
+	defer mc.lifecycleMux.Unlock()
+	if mc.lifecycle == types.LifecycleRunning {
+		mc.lifecycle = types.LifecycleDraining
+	}
+}
+
+// MarkStopped records that the process has finished shutting down. Background
+// waits still going, such as batch pacing, webhook retries and post-migration
+// metrics collection, end right away.
+func (mc *MigrationController) MarkStopped() {
+	mc.lifecycleMux.Lock()
+	mc.lifecycle = types.LifecycleStopped
+	mc.lifecycleMux.Unlock()
+
+	mc.stop()
+	mc.webhooks.Close()
+}
+
+// acceptingWork returns ErrDraining once shutdown has begun
+func (mc *MigrationController) acceptingWork() error {
+	if mc.Lifecycle() != types.LifecycleRunning {
+		return ErrDraining
+	}
+	return nil
+}
+
+// Status reports the lifecycle state and how much work is still in progress
+func (mc *MigrationController) Status() *types.OrchestratorStatus {
+	status := &types.OrchestratorStatus{State: mc.Lifecycle()}
+
+	mc.batchesMux.RLock()
+	for _, batch := range mc.batches {
+		if batch.EndTime == nil {
+			status.InFlightBatches++
+		}
+	}
+	mc.batchesMux.RUnlock()
+
+	mc.migrationsMux.RLock()
+	for _, job := range mc.migrations {
+		if len(migrationTransitions[job.Status]) > 0 {
+			status.InFlightMigrations++
+		}
+	}
+	mc.migrationsMux.RUnlock()
+
+	return status
+}
+
+// WaitForInFlight blocks until every migration and batch has finished or ctx is done
+func (mc *MigrationController) WaitForInFlight(ctx context.Context) error {
+	ticker := time.NewTicker(inFlightPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status := mc.Status()
+		if status.InFlightMigrations == 0 && status.InFlightBatches == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// waitFor polls condition until it holds, failing the test after a few seconds
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStopInterruptsMetricsStabilization(t *testing.T) {
+	mc, _ := newTestController(t, func(config *MigrationConfig) {
+		config.SafeMode = false
+		config.MetricsStabilizationDelay = time.Hour
+	}, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ForceIgnorePDB = true
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusCompleted || !response.Details.MetricsPending {
+		t.Fatalf("status = %s, metrics pending = %v, want completed with metrics pending",
+			response.Status, response.Details.MetricsPending)
+	}
+
+	mc.MarkStopped()
+	waitFor(t, "post-migration metrics", func() bool {
+		// Details are shared with the collection, so they are read under its lock
+		mc.migrationsMux.RLock()
+		defer mc.migrationsMux.RUnlock()
+		return !mc.migrations[response.MigrationID].Details.MetricsPending
+	})
+	entries, _, _, err := mc.GetMigrationLogs(response.MigrationID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interrupted := false
+	for _, entry := range entries {
+		interrupted = interrupted || strings.Contains(entry.Message, "interrupted while waiting for metrics to stabilize")
+	}
+	if !interrupted {
+		t.Error("no log entry of the interrupted stabilization delay")
+	}
+}
+
+func TestStopEndsBatchPacing(t *testing.T) {
+	mc, _ := newTestController(t, fullMode, testNode("node-a"), testNode("node-b"),
+		testPod("app", "node-a", "main"), testPod("db", "node-a", "main"))
+	var migrations []types.MigrationRequest
+	for _, name := range []string{"app", "db"} {
+		req := testRequest(name, "node-a", "node-b")
+		req.SuccessCriteria = types.SuccessCriteriaPodCreated
+		req.ForceIgnorePDB = true
+		migrations = append(migrations, *req)
+	}
+	started, err := mc.StartBatch(&types.BatchMigrationRequest{Migrations: migrations, PodDelay: 3600})
+	if err != nil {
+		t.Fatalf("StartBatch: %v", err)
+	}
+	waitFor(t, "the first child", func() bool {
+		batch, err := mc.GetBatch(started.BatchID)
+		return err == nil && batch.Children[0].Status.Succeeded()
+	})
+
+	mc.MarkStopped()
+	waitFor(t, "the batch to end", func() bool {
+		batch, err := mc.GetBatch(started.BatchID)
+		return err == nil && batch.EndTime != nil
+	})
+	batch, _ := mc.GetBatch(started.BatchID)
+	if child := batch.Children[1]; child.MigrationID != "" || child.SkippedReason != "orchestrator stopped" {
+		t.Errorf("second child = %+v, want it skipped unstarted", child)
+	}
+}
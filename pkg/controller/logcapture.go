@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+const (
+	// DefaultCaptureLogLines is the log tail length captured when a request does not set one
+	DefaultCaptureLogLines = 100
+	// MaxCaptureLogLines caps capture_log_lines in a request
+	MaxCaptureLogLines = 1000
+	// maxCapturedLogBytes bounds the log kept per container
+	maxCapturedLogBytes = 16 * 1024
+)
+
+// Reasons recorded on captured logs
+const (
+	captureReasonDropped = "dropped"
+	captureReasonFailure = "failure"
+)
+
+// captureOriginalLogs fetches the log tail of the given containers of the original pod.
+// Errors are recorded per container; capturing never fails the migration.
+func (mc *MigrationController) captureOriginalLogs(job *MigrationJob, containers []string, reason string) []types.CapturedLog {
+	// The job context may already be expired on failure paths
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tailLines := job.Request.CaptureLogLines
+	if tailLines <= 0 {
+		tailLines = DefaultCaptureLogLines
+	}
+
+	captured := make([]types.CapturedLog, 0, len(containers))
+	for _, container := range containers {
+		entry := types.CapturedLog{
+			Container: container,
+			Reason:    reason,
+			Timestamp: time.Now(),
+		}
+		logs, truncated, err := mc.k8sClient.GetContainerLogs(ctx, job.Request.PodNamespace, job.Request.PodName, container, tailLines, maxCapturedLogBytes)
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Log = logs
+			entry.Truncated = truncated
+		}
+		captured = append(captured, entry)
+	}
+
+	mc.logf(job, "Captured logs of %d container(s) from original pod (%s)", len(captured), reason)
+	return captured
+}
+
+// captureDroppedContainerLogs keeps the logs of containers that are not carried
+// over to the new pod, before the original pod is deleted
+func (mc *MigrationController) captureDroppedContainerLogs(job *MigrationJob) {
+	if !job.Request.CaptureLogs {
+		return
+	}
+
+	var dropped []string
+	for _, state := range job.Details.ContainerStates {
+		if !state.ShouldMigrate {
+			dropped = append(dropped, state.Name)
+		}
+	}
+	if len(dropped) == 0 {
+		return
+	}
+
+	captured := mc.captureOriginalLogs(job, dropped, captureReasonDropped)
+
+	mc.migrationsMux.Lock()
+	job.Details.CapturedLogs = append(job.Details.CapturedLogs, captured...)
+	mc.migrationsMux.Unlock()
+}
+
+// captureFailureLogs keeps the logs of every container of the original pod when a
+// migration fails after its containers were inspected
+func (mc *MigrationController) captureFailureLogs(job *MigrationJob) []types.CapturedLog {
+	if !job.Request.CaptureLogs || len(job.Details.ContainerStates) == 0 {
+		return nil
+	}
+
+	containers := make([]string, 0, len(job.Details.ContainerStates))
+	for _, state := range job.Details.ContainerStates {
+		containers = append(containers, state.Name)
+	}
+	return mc.captureOriginalLogs(job, containers, captureReasonFailure)
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+// logSampler thins out repetitive log lines during failure storms. Lines are grouped
+// by class, the format string of the message: within each window the first line of a
+// class is written, then every rate-th one, each carrying how many were held back
+// since the last written line. Suppressed lines are still counted.
+type logSampler struct {
+	rate   int
+	window time.Duration
+
+	mu         sync.Mutex
+	classes    map[string]*logClass
+	suppressed int64 // lines held back over the process lifetime
+}
+
+// logClass counts the lines of one class in the current window
+type logClass struct {
+	windowStart time.Time
+	seen        int64
+	suppressed  int64 // since the last written line
+}
+
+func newLogSampler(rate int, window time.Duration) *logSampler {
+	return &logSampler{rate: rate, window: window, classes: make(map[string]*logClass)}
+}
+
+// admit reports whether a line of class should be written and, if so, how many lines
+// of the class were held back before it. A rate of 0 or 1 writes every line.
+func (s *logSampler) admit(class string, now time.Time) (bool, int64) {
+	if s.rate <= 1 {
+		return true, 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.classes[class]
+	if !ok || now.Sub(c.windowStart) >= s.window {
+		var held int64
+		if ok {
+			held = c.suppressed
+		}
+		s.classes[class] = &logClass{windowStart: now, seen: 1}
+		return true, held
+	}
+
+	c.seen++
+	if (c.seen-1)%int64(s.rate) == 0 {
+		held := c.suppressed
+		c.suppressed = 0
+		return true, held
+	}
+	c.suppressed++
+	s.suppressed++
+	return false, 0
+}
+
+// suppressedTotal returns the lines held back so far
+func (s *logSampler) suppressedTotal() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.suppressed
+}
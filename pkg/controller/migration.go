@@ -2,49 +2,237 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/metrics"
+	"ai-storage-orchestrator/pkg/policy"
+	"ai-storage-orchestrator/pkg/sink"
 	"ai-storage-orchestrator/pkg/types"
+	"ai-storage-orchestrator/pkg/webhook"
 	
 	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // MigrationController manages pod migrations with persistent volume optimization
 type MigrationController struct {
-	k8sClient      *k8s.Client
-	migrations     map[string]*MigrationJob
-	migrationsMux  sync.RWMutex
-	metrics        *types.MigrationMetrics
-	checkpointSize string // Default PV size for checkpoints
+	k8sClient       *k8s.Client
+	metricsProvider metrics.Provider
+	migrations      map[string]*MigrationJob
+	migrationsMux   sync.RWMutex
+	metrics         *types.MigrationMetrics // counters, guarded by metricsMux
+	// Guards metrics and the statistics below so that reading metrics never waits on
+	// migration progress. Taken last: nothing else is locked while it is held.
+	metricsMux      sync.Mutex
+	config          *validatedMigrationConfig
+	slots           *slotScheduler
+	metricsWorkers  chan struct{} // bounds concurrent post-migration metric collections
+	logSampler      *logSampler   // thins out repetitive migration log lines
+	presets         map[string]*types.MigrationPreset
+	presetsMux      sync.RWMutex
+	stepStats       map[string]*stepStats     // step duration history, guarded by metricsMux
+	stepHistograms  map[string]*stepHistogram // step duration distribution, guarded by metricsMux
+	schedulingStats stepStats                 // new pod scheduling latency history, guarded by metricsMux
+	startupStats    stepStats                 // new pod startup latency history, guarded by metricsMux
+	sourceRelief    reliefStats               // source node relief history, guarded by metricsMux
+	waste           wasteStats                // requested versus used resources of migrated containers, guarded by metricsMux
+	finishes        []finishEvent             // recent completions for rate metrics, guarded by metricsMux
+	batches         map[string]*batchJob
+	batchesMux      sync.RWMutex
+	drains          map[string]*nodeDrain // latest drain of each node, guarded by drainsMux
+	drainsMux       sync.Mutex
+	sink            sink.MigrationSink
+	sinkQueue       chan *types.MigrationRecord // finished migrations waiting to be exported
+	researchMux     sync.Mutex                  // serializes research record writes
+	webhooks        *webhook.Dispatcher
+	policy          *policy.Client             // gates migrations before they start; nil without one
+	capabilities    *types.ClusterCapabilities // cached API server probe, guarded by capabilitiesMux
+	capabilitiesMux sync.Mutex
+	probeFailures   int                        // consecutive failed capability probes, guarded by capabilitiesMux
+	probeRetryAt    time.Time                  // earliest next probe after a failure, guarded by capabilitiesMux
+	classification  types.ClassificationPolicy // decides which containers are migrated, guarded by policyMux
+	policyMux       sync.RWMutex
+	lifecycle       types.LifecycleState // running until shutdown begins, guarded by lifecycleMux
+	lifecycleMux    sync.RWMutex
+	stopped         context.Context // done once the controller stops, ending background waits
+	stop            context.CancelFunc
+	// Migrations recommended by the pressure observer, guarded by recommendationsMux
+	recommendations    []*types.Recommendation
+	lastObserveScan    time.Time
+	recommendationsMux sync.Mutex
 }
 
+// maxLogEntriesPerJob bounds the progress log kept in memory for each migration
+const maxLogEntriesPerJob = 500
+
 // MigrationJob represents an active migration job
 type MigrationJob struct {
-	ID          string
-	Request     *types.MigrationRequest
-	Status      types.MigrationStatus
-	Details     *types.MigrationDetails
-	StartTime   time.Time
-	ctx         context.Context
-	cancel      context.CancelFunc
+	ID        string
+	Request   *types.MigrationRequest
+	Status    types.MigrationStatus
+	Details   *types.MigrationDetails
+	StartTime time.Time
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	// Progress log, guarded by migrationsMux
+	logs       []types.MigrationLogEntry
+	logSeq     int64
+	logUpdated chan struct{} // closed and replaced whenever a log entry is appended
+	done       chan struct{} // closed once the migration reaches a terminal status
+
+	stepStarted  time.Time // start of Details.CurrentStep, guarded by migrationsMux
+	cancelReason string    // why the job was cancelled (e.g. preemption), guarded by migrationsMux
+
+	// How long to keep the checkpoint PVC after success, from the pod's annotation; 0 keeps it
+	checkpointRetention time.Duration
+
+	// Selector of an existing Service repointed at the new pod, restored on rollback
+	servicePreviousSelector map[string]string
+	serviceSelectorReplaced bool
+
+	// Objects removed by rollback as kind/name, and why the migration failed; both
+	// written by the migration goroutine before it reaches a terminal status
+	cleanedUp []string
+	failure   string
+
+	// Set once a migration that deletes the original pod first has deleted it, with
+	// the pod as it was read just before; written by the migration goroutine only
+	originalDeleted  bool
+	originalManifest *corev1.Pod
+
+	// Classification policy the containers were classified with, for research records;
+	// written by the migration goroutine only
+	classification types.ClassificationPolicy
+
+	// Name rendered from a pod name template, reserved until the migration finishes;
+	// guarded by migrationsMux
+	reservedPodName string
+
+	// Final summary, built on the terminal transition and replaced, never modified,
+	// once post-migration metrics arrive; guarded by migrationsMux
+	summary *types.MigrationSummary
 }
 
-// NewMigrationController creates a new migration controller
-func NewMigrationController(k8sClient *k8s.Client) *MigrationController {
-	return &MigrationController{
-		k8sClient:      k8sClient,
-		migrations:     make(map[string]*MigrationJob),
-		metrics:        &types.MigrationMetrics{},
-		checkpointSize: "1Gi", // Default 1GB for checkpoint storage
+// NewMigrationController creates a new migration controller, failing if the configuration is invalid
+func NewMigrationController(k8sClient *k8s.Client, metricsProvider metrics.Provider, migrationSink sink.MigrationSink, config MigrationConfig) (*MigrationController, error) {
+	validated, err := config.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid migration controller configuration: %w", err)
 	}
+	if validated.resultsDir != "" {
+		if err := os.MkdirAll(validated.resultsDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create results directory: %w", err)
+		}
+	}
+	var policyClient *policy.Client
+	if validated.policyEndpoint != "" {
+		policyClient = policy.NewClient(validated.policyEndpoint)
+	}
+	if validated.snapshotDir != "" {
+		if err := os.MkdirAll(validated.snapshotDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+	}
+
+	mc := &MigrationController{
+		k8sClient:       k8sClient,
+		metricsProvider: metricsProvider,
+		migrations:      make(map[string]*MigrationJob),
+		metrics:         &types.MigrationMetrics{},
+		config:          validated,
+		metricsWorkers:  make(chan struct{}, validated.metricsWorkers),
+		logSampler:      newLogSampler(validated.logSampleRate, validated.logSampleWindow),
+		presets:         make(map[string]*types.MigrationPreset),
+		stepStats:       make(map[string]*stepStats),
+		stepHistograms:  make(map[string]*stepHistogram),
+		batches:         make(map[string]*batchJob),
+		drains:          make(map[string]*nodeDrain),
+		sink:            migrationSink,
+		sinkQueue:       make(chan *types.MigrationRecord, sinkQueueSize),
+		webhooks:        webhook.NewDispatcher(),
+		policy:          policyClient,
+		classification:  types.DefaultClassificationPolicy(),
+		lifecycle:       types.LifecycleRunning,
+	}
+	mc.stopped, mc.stop = context.WithCancel(context.Background())
+	mc.slots = newSlotScheduler(validated.maxConcurrentMigrations, validated.maxConcurrentPerNamespace, validated.namespaceLimits, mc.handlePreemption)
+	go mc.runSinkWriter()
+	go mc.runCheckpointReconciler()
+	if validated.cleanupFinalizers {
+		// Objects left behind by a previous run are settled right away
+		go mc.reconcileTrackedResources()
+	}
+	go mc.ClusterCapabilities()
+	if validated.observeInterval > 0 {
+		go mc.runPressureObserver()
+	}
+	if validated.backpressureThreshold > 0 {
+		go mc.runBackpressure()
+	}
+
+	return mc, nil
 }
 
 // StartMigration initiates a new pod migration
 func (mc *MigrationController) StartMigration(req *types.MigrationRequest) (*types.MigrationResponse, error) {
+	if err := mc.acceptingWork(); err != nil {
+		return nil, err
+	}
+
+	// A retried request for a pod that was already migrated succeeds without a new migration
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	migrated := mc.findMigratedPod(ctx, req)
+	cancel()
+	if migrated != nil {
+		job := mc.recordAlreadyMigrated(req, migrated)
+		mc.migrationsMux.RLock()
+		defer mc.migrationsMux.RUnlock()
+		return mc.buildResponseLocked(job), nil
+	}
+
+	req, selection, err := mc.resolveTargetNode(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := mc.checkNewPodName(req); err != nil {
+		return nil, err
+	}
+	if err := mc.checkSnapshotConfigured(req); err != nil {
+		return nil, err
+	}
+	decision, err := mc.checkPolicy(req, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := mc.launchMigration(req, selection, decision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MigrationResponse{
+		MigrationID: job.ID,
+		Status:      types.MigrationStatusPending,
+		Message:     "Migration started",
+		Details:     job.Details,
+	}, nil
+}
+
+// launchMigration registers a new migration job and starts executing it in the
+// background. selection is how the target node was picked, nil if it was given, and
+// decision the policy service's verdict, nil without one. An explicit new pod name
+// is reserved as the job is registered, failing if another migration holds it.
+func (mc *MigrationController) launchMigration(req *types.MigrationRequest, selection *types.NodeSelection, decision *types.PolicyDecision) (*MigrationJob, error) {
 	// Generate unique migration ID
 	migrationID := fmt.Sprintf("migration-%s", uuid.New().String()[:8])
 	
@@ -60,44 +248,167 @@ func (mc *MigrationController) StartMigration(req *types.MigrationRequest) (*typ
 		Status:    types.MigrationStatusPending,
 		StartTime: time.Now(),
 		Details: &types.MigrationDetails{
+			RequestID: req.RequestID,
 			StartTime: time.Now(),
 		},
-		ctx:    ctx,
-		cancel: cancel,
+		ctx:        ctx,
+		cancel:     cancel,
+		logUpdated: make(chan struct{}),
+		done:       make(chan struct{}),
 	}
+	job.Details.TargetNodeSelection = selection
+	job.Details.PolicyDecision = decision
 
 	// Store migration job
 	mc.migrationsMux.Lock()
-	mc.migrations[migrationID] = job
+	if req.NewPodName != "" {
+		if err := mc.podNameReservedLocked(req.PodNamespace, req.NewPodName, nil); err != nil {
+			mc.migrationsMux.Unlock()
+			cancel()
+			return nil, err
+		}
+	}
+	mc.trackMigrationLocked(job)
 	mc.migrationsMux.Unlock()
 
+	if job.Details.TargetNodeSelection != nil {
+		mc.logf(job, "Selected target node %s for selector %v", req.TargetNode, req.TargetNodeSelector)
+	}
+	if decision != nil && decision.FailedOpen {
+		mc.logf(job, "Warning: Started without a policy decision: %s", decision.Error)
+	} else if decision != nil {
+		mc.logf(job, "Allowed by policy at %s", decision.Endpoint)
+	}
+
 	// Start migration in background
 	go mc.executeMigration(job)
 
-	return &types.MigrationResponse{
-		MigrationID: migrationID,
-		Status:      types.MigrationStatusPending,
-		Message:     "Migration started",
-		Details:     job.Details,
-	}, nil
+	return job, nil
 }
 
 // GetMigrationStatus returns the current status of a migration
 func (mc *MigrationController) GetMigrationStatus(migrationID string) (*types.MigrationResponse, error) {
 	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
 	job, exists := mc.migrations[migrationID]
-	mc.migrationsMux.RUnlock()
-	
 	if !exists {
 		return nil, fmt.Errorf("migration %s not found", migrationID)
 	}
 
-	return &types.MigrationResponse{
+	return mc.buildResponseLocked(job), nil
+}
+
+// WaitForMigration blocks until the migration reaches a terminal status or ctx is
+// done, and returns its status at that point. finished reports whether the returned
+// status is terminal; the migration itself is not affected by ctx.
+func (mc *MigrationController) WaitForMigration(ctx context.Context, migrationID string) (response *types.MigrationResponse, finished bool, err error) {
+	mc.migrationsMux.RLock()
+	job, exists := mc.migrations[migrationID]
+	mc.migrationsMux.RUnlock()
+	if !exists {
+		return nil, false, fmt.Errorf("migration %s not found", migrationID)
+	}
+
+	select {
+	case <-job.done:
+		finished = true
+	case <-ctx.Done():
+	}
+
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+	return mc.buildResponseLocked(job), finished, nil
+}
+
+// GetMigrationStatuses returns the status of several migrations under a single lock
+// acquisition. Unknown IDs are reported per entry instead of failing the whole lookup.
+func (mc *MigrationController) GetMigrationStatuses(migrationIDs []string) map[string]*types.BulkMigrationStatus {
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	result := make(map[string]*types.BulkMigrationStatus, len(migrationIDs))
+	for _, migrationID := range migrationIDs {
+		job, exists := mc.migrations[migrationID]
+		if !exists {
+			result[migrationID] = &types.BulkMigrationStatus{
+				Found: false,
+				Error: fmt.Sprintf("migration %s not found", migrationID),
+			}
+			continue
+		}
+		result[migrationID] = &types.BulkMigrationStatus{
+			Found:     true,
+			Migration: mc.buildResponseLocked(job),
+		}
+	}
+	return result
+}
+
+// MigrationNamespace returns the namespace of the migrated pod
+func (mc *MigrationController) MigrationNamespace(migrationID string) (string, bool) {
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	job, exists := mc.migrations[migrationID]
+	if !exists {
+		return "", false
+	}
+	return job.Request.PodNamespace, true
+}
+
+// buildResponseLocked builds the API response for a job; migrationsMux must be held
+func (mc *MigrationController) buildResponseLocked(job *MigrationJob) *types.MigrationResponse {
+	message := mc.getStatusMessage(job.Status)
+	if job.Status == types.MigrationStatusCompleted && job.Details.MetricsPending {
+		message = "Migration completed successfully, post-migration metrics pending"
+	}
+	if job.Status == types.MigrationStatusPartiallyDegraded {
+		message = fmt.Sprintf("Migration completed, but containers %s of the new pod are not ready", strings.Join(job.Details.DegradedContainers, ", "))
+	}
+	if (job.Status == types.MigrationStatusCompletedSafe || job.Status == types.MigrationStatusPartiallyDegraded) && job.Details.MetricsPending {
+		message += ", post-migration metrics pending"
+	}
+	if job.Details.AlreadyMigrated {
+		message = fmt.Sprintf("Pod was already migrated to %s as %s", job.Request.TargetNode, job.Details.NewPodName)
+	}
+
+	response := &types.MigrationResponse{
 		MigrationID: job.ID,
 		Status:      job.Status,
-		Message:     mc.getStatusMessage(job.Status),
+		Message:     message,
 		Details:     job.Details,
-	}, nil
+		Summary:     job.summary,
+	}
+	if job.Status == types.MigrationStatusPending {
+		response.QueueReason = mc.slots.queueReason(job.ID)
+		mc.estimateQueueWaitLocked(job, response)
+	}
+	return response
+}
+
+// estimateQueueWaitLocked fills in the queue position of a waiting job and, once
+// migrations have completed, when it is expected to start: every slot frees up
+// after an average migration, so the job starts after as many averages as it takes
+// the slots to work through the jobs ahead of it. migrationsMux must be held.
+func (mc *MigrationController) estimateQueueWaitLocked(job *MigrationJob, response *types.MigrationResponse) {
+	position := mc.slots.queuePosition(job.ID)
+	if position == 0 {
+		return
+	}
+	response.QueuePosition = position
+
+	mc.metricsMux.Lock()
+	average := mc.metrics.AverageDuration
+	mc.metricsMux.Unlock()
+	if average <= 0 {
+		return
+	}
+	slots := mc.slots.currentLimit()
+	wait := time.Duration((position+slots-1)/slots) * average
+	start := time.Now().Add(wait)
+	response.EstimatedWait = &wait
+	response.EstimatedStartTime = &start
 }
 
 // executeMigration performs the actual migration following the 3-step process from the paper
@@ -108,57 +419,270 @@ func (mc *MigrationController) executeMigration(job *MigrationJob) {
 		}
 	}()
 
-	log.Printf("Starting migration %s: %s/%s from %s to %s", 
-		job.ID, job.Request.PodNamespace, job.Request.PodName, 
+	mc.applyRestartPriorityPolicy(job)
+
+	// Wait for an execution slot; the job stays pending while queued
+	if err := mc.slots.acquire(job.ctx, job); err != nil {
+		mc.failMigration(job, fmt.Sprintf("Gave up waiting for a migration slot: %v", err))
+		return
+	}
+	defer mc.slots.release(job)
+
+	mc.logf(job, "Starting migration of %s/%s from %s to %s",
+		job.Request.PodNamespace, job.Request.PodName,
 		job.Request.SourceNode, job.Request.TargetNode)
 
 	// Update status to running
-	mc.updateJobStatus(job, types.MigrationStatusRunning)
+	if err := mc.updateJobStatus(job, types.MigrationStatusRunning); err != nil {
+		mc.logf(job, "Not starting migration: %v", err)
+		return
+	}
 
 	// Step 1: Capture container states and collect metrics
-	if err := mc.captureContainerStates(job); err != nil {
+	mc.beginStep(job, stepCaptureState)
+	err := mc.captureContainerStates(job)
+	if err == nil {
+		err = mc.injectedFault(job, stepCaptureState)
+	}
+	if err != nil {
 		mc.failMigration(job, fmt.Sprintf("Failed to capture container states: %v", err))
 		return
 	}
+	// The policy service approved a plan made when the request arrived
+	if err := mc.checkApprovedSpec(job); err != nil {
+		mc.failMigration(job, fmt.Sprintf("Not acting on the approved plan: %v", err))
+		return
+	}
+	// Keep a copy of the original pod outside the process before anything is changed
+	if job.Request.SnapshotOriginal {
+		if err := mc.snapshotOriginalPod(job); err != nil {
+			mc.failMigration(job, fmt.Sprintf("Failed to snapshot original pod: %v", err))
+			return
+		}
+	}
+	// Only a deleted original pod relieves the source node
+	if !mc.config.safeMode {
+		mc.measureSourceNodeBefore(job)
+	}
+
+	// Single-arch images crash-loop on a node of another architecture
+	if job.Request.AllowArchMismatch {
+		mc.logf(job, "Skipping node architecture check as requested")
+	} else if err := mc.checkNodeArchitecture(job.ctx, job.Request.SourceNode, job.Request.TargetNode); err != nil {
+		mc.failMigration(job, fmt.Sprintf("Node architecture check failed: %v", err))
+		return
+	}
 
-	// Step 2: Create checkpoint in Persistent Volume (if enabled)
+	// Fail early if the new objects would not fit in the namespace's quota
+	withCheckpoint := job.Request.PreservePV && !job.Request.ForceRestart
+	if err := mc.checkResourceQuota(job, withCheckpoint); err != nil {
+		mc.failMigration(job, fmt.Sprintf("Resource quota check failed: %v", err))
+		return
+	}
+
+	// Single-node volumes of the original pod are freed before the new pod needs them
+	if err := mc.planSharedVolumes(job); err != nil {
+		mc.failMigration(job, fmt.Sprintf("Volume check failed: %v", err))
+		return
+	}
+
+	// Steps 2 and 3 run again on another node when a target node picked by
+	// target_node_selector is lost while the new pod is being created
 	var checkpointPVC string
-	if job.Request.PreservePV {
-		var err error
-		checkpointPVC, err = mc.createCheckpoint(job)
+	for {
+		// Step 2: Create checkpoint in Persistent Volume (if enabled and not forcing a cold restart)
+		checkpointPVC = ""
+		if job.Request.PreservePV && job.Request.ForceRestart {
+			job.Details.CheckpointSkippedReason = "force_restart requested: containers start without restoring a checkpoint"
+			mc.logf(job, "Skipping checkpoint: force restart requested")
+		} else if job.Request.PreservePV {
+			mc.beginStep(job, stepCheckpoint)
+			var err error
+			checkpointPVC, err = mc.createCheckpoint(job)
+			if err != nil {
+				mc.failMigration(job, fmt.Sprintf("Failed to create checkpoint: %v", err))
+				return
+			}
+			job.Details.CheckpointPath = checkpointPVC
+			job.Details.PVClaimName = checkpointPVC
+
+			if err := mc.injectedFault(job, stepCheckpoint); err != nil {
+				mc.rollbackMigration(job)
+				mc.failMigration(job, fmt.Sprintf("Failed to create checkpoint: %v", err))
+				return
+			}
+		}
+
+		// Step 2a: Pull the new pod's images onto the target node (if requested)
+		if job.Request.PrePullImages {
+			mc.beginStep(job, stepPrePull)
+			mc.prePullImages(job)
+		}
+
+		// Step 3: Create optimized pod (only with running containers)
+		mc.beginStep(job, stepCreatePod)
+		// The checkpoint and image pulls, or a lost target node, took time in which
+		// the original pod may have been edited
+		if !job.originalDeleted {
+			replanned, err := mc.checkSpecDrift(job, stepCreatePod, true)
+			if err != nil {
+				mc.rollbackMigration(job)
+				mc.failMigration(job, fmt.Sprintf("Not creating the new pod: %v", err))
+				return
+			}
+			// The checkpoint was sized and placed for the old plan
+			if replanned && checkpointPVC != "" {
+				mc.discardCheckpoint(job)
+				continue
+			}
+		}
+		// The checkpoint must still be what was written before the new pod restores it
+		restoreFrom := checkpointPVC
+		if checkpointPVC != "" {
+			restore, err := mc.verifyCheckpoint(job, checkpointPVC)
+			if err != nil {
+				mc.rollbackMigration(job)
+				mc.failMigration(job, fmt.Sprintf("Not creating the new pod: %v", err))
+				return
+			}
+			if !restore {
+				restoreFrom = ""
+			}
+		}
+		if job.Details.DeleteOriginalFirst && !job.originalDeleted {
+			if err := mc.deleteOriginalFirst(job); err != nil {
+				mc.rollbackMigration(job)
+				mc.failMigration(job, fmt.Sprintf("Failed to delete original pod before creating the new one: %v", err))
+				return
+			}
+		}
+		err = mc.createOptimizedPod(job, restoreFrom)
+		if err == nil {
+			err = mc.injectedFault(job, stepCreatePod)
+		}
+		if err == nil {
+			break
+		}
+		retry, err := mc.reselectTargetNode(job, err)
+		if retry {
+			continue
+		}
+		mc.rollbackMigration(job)
+		mc.failMigration(job, fmt.Sprintf("Failed to create optimized pod: %v", err))
+		return
+	}
+
+	// Step 3a: Verify the migrated workload before touching the original (if requested)
+	if len(job.Request.VerifyCommand) > 0 {
+		mc.beginStep(job, stepVerify)
+		err := mc.verifyNewPod(job)
+		if err == nil {
+			err = mc.injectedFault(job, stepVerify)
+		}
 		if err != nil {
-			mc.failMigration(job, fmt.Sprintf("Failed to create checkpoint: %v", err))
+			mc.rollbackMigration(job)
+			mc.failMigration(job, fmt.Sprintf("Post-migration verification failed: %v", err))
 			return
 		}
-		job.Details.CheckpointPath = checkpointPVC
-		job.Details.PVClaimName = checkpointPVC
 	}
 
-	// Step 3: Create optimized pod (only with running containers)
-	if err := mc.createOptimizedPod(job, checkpointPVC); err != nil {
-		mc.failMigration(job, fmt.Sprintf("Failed to create optimized pod: %v", err))
+	// Step 3b: Require the new pod to stay healthy for a while (if requested)
+	if job.Request.SuccessCriteria == types.SuccessCriteriaStabilityWindow {
+		mc.beginStep(job, stepStability)
+		if err := mc.waitStabilityWindow(job); err != nil {
+			mc.rollbackMigration(job)
+			mc.failMigration(job, fmt.Sprintf("New pod was not stable: %v", err))
+			return
+		}
+	}
+	mc.recordSuccessEvidence(job)
+
+	// Give consumers a stable address in front of the new pod (if requested)
+	if job.Request.EnsureService {
+		if err := mc.ensureService(job); err != nil {
+			mc.rollbackMigration(job)
+			mc.failMigration(job, fmt.Sprintf("Failed to ensure stable service: %v", err))
+			return
+		}
+	}
+
+	// Past this point the migration can no longer be preempted or cancelled; a
+	// migration that deleted the original pod first is already cutting over
+	if !job.originalDeleted {
+		// The new pod was built from the plan, so a changed original cannot be
+		// planned again; safe mode leaves the original alone anyway
+		if !mc.config.safeMode {
+			if _, err := mc.checkSpecDrift(job, stepCutover, false); err != nil {
+				mc.rollbackMigration(job)
+				mc.failMigration(job, fmt.Sprintf("Not cutting over: %v", err))
+				return
+			}
+		}
+		if !mc.slots.protect(job) {
+			mc.rollbackMigration(job)
+			mc.failMigration(job, "Preempted before cutover")
+			return
+		}
+		if err := mc.beginCutover(job); err != nil {
+			mc.rollbackMigration(job)
+			mc.failMigration(job, fmt.Sprintf("Not cutting over: %v", err))
+			return
+		}
+	}
+
+	// Whatever happens from here, cleanup must not delete what the workload runs on
+	if err := mc.handOverTrackedResources(job); err != nil {
+		mc.rollbackMigration(job)
+		mc.failMigration(job, fmt.Sprintf("Not cutting over: %v", err))
 		return
 	}
 
-	// Step 4: Delete original pod
-	if err := mc.deleteOriginalPod(job); err != nil {
-		log.Printf("Warning: Failed to delete original pod: %v", err)
-		// Don't fail migration for this, just log warning
+	// Step 4: Delete original pod, keeping the logs of dropped containers if requested.
+	// Safe mode never deletes: both pods keep running.
+	finalStatus := types.MigrationStatusCompleted
+	if len(job.Details.DegradedContainers) > 0 {
+		finalStatus = types.MigrationStatusPartiallyDegraded
+	}
+	if mc.config.safeMode {
+		if finalStatus == types.MigrationStatusCompleted {
+			finalStatus = types.MigrationStatusCompletedSafe
+		}
+		job.Details.OriginalPodRetained = true
+		mc.logf(job, "Safe mode: retaining original pod %s alongside %s", job.Request.PodName, job.Details.NewPodName)
+	} else if job.originalDeleted {
+		mc.logf(job, "Original pod %s was deleted before %s was created", job.Request.PodName, job.Details.NewPodName)
+	} else {
+		mc.beginStep(job, stepCutover)
+		if err := mc.injectedFault(job, stepCutover); err != nil {
+			mc.rollbackMigration(job)
+			mc.failMigration(job, fmt.Sprintf("Cutover failed: %v", err))
+			return
+		}
+		mc.captureDroppedContainerLogs(job)
+		if err := mc.deleteOriginalPod(job); err != nil {
+			mc.logf(job, "Warning: Failed to delete original pod: %v", err)
+			// Don't fail migration for this, just log warning
+		}
 	}
 
-	// Step 5: Collect post-migration metrics
-	if err := mc.collectPostMigrationMetrics(job); err != nil {
-		log.Printf("Warning: Failed to collect post-migration metrics: %v", err)
-		// Don't fail migration for this
+	// Complete migration; the execution slot is released on return
+	mc.scheduleCheckpointExpiry(job)
+	mc.releaseTrackedResources(job)
+	mc.finishSteps(job)
+	if err := mc.completeMigration(job, finalStatus); err != nil {
+		log.Printf("Migration %s: %v", job.ID, err)
+		return
 	}
 
-	// Complete migration
-	mc.completeMigration(job)
-	
-	log.Printf("Migration %s completed successfully", job.ID)
+	// Step 5: Collect post-migration metrics in the background
+	go mc.runPostMigrationMetrics(job)
+	if job.Request.SampleUsage && job.Details.NewPodName != "" {
+		go mc.sampleUsage(job)
+	}
 }
 
-// captureContainerStates analyzes current container states and collects resource metrics
+// captureContainerStates plans the migration from the current container states and
+// resource metrics; the remaining steps execute the plan
 func (mc *MigrationController) captureContainerStates(job *MigrationJob) error {
 	ctx := job.ctx
 
@@ -168,18 +692,53 @@ func (mc *MigrationController) captureContainerStates(job *MigrationJob) error {
 		return fmt.Errorf("failed to get pod: %w", err)
 	}
 
-	// Analyze container states
-	containerStates, err := mc.k8sClient.GetPodContainerStates(ctx, pod)
+	if job.Request.PreservePV && !job.Request.ForceRestart {
+		job.checkpointRetention, err = checkpointRetention(pod)
+		if err != nil {
+			return err
+		}
+	}
+
+	job.classification = mc.classificationPolicyFor(job.Request)
+	plan, err := mc.planMigration(ctx, job.Request, pod, job.classification)
 	if err != nil {
-		return fmt.Errorf("failed to analyze container states: %w", err)
+		return err
 	}
+	plan.TargetNodeSelection = job.Details.TargetNodeSelection
 
-	job.Details.ContainerStates = containerStates
+	for _, decision := range plan.RestartDecisions {
+		mc.logf(job, "Restart count policy: %s", decision)
+	}
+	for _, decision := range plan.GroupingDecisions {
+		if len(decision.Promoted) > 0 {
+			mc.logf(job, "Keeping containers %v because they are grouped with a migrated container", decision.Promoted)
+		}
+		if len(decision.Missing) > 0 {
+			mc.logf(job, "Warning: grouped containers %v not found in pod", decision.Missing)
+		}
+	}
+
+	// A migration planned again after its pod changed already counted its containers
+	replanned := job.Details.Plan != nil
+	job.Details.Plan = plan
+	job.Details.PodSpecHash = plan.PodSpecHash
+	job.Details.LifecycleHooks = plan.LifecycleHooks
+	job.Details.RestartDecisions = plan.RestartDecisions
+	job.Details.GroupingDecisions = plan.GroupingDecisions
+	job.Details.ContainerStates = plan.Containers
+	job.Details.ResourceGaps = plan.ResourceGaps
+	if !replanned {
+		mc.metricsMux.Lock()
+		for _, gap := range plan.ResourceGaps {
+			mc.waste.add(gap)
+		}
+		mc.metricsMux.Unlock()
+	}
 
 	// Collect original resource metrics
-	metrics, err := mc.k8sClient.GetPodMetrics(ctx, job.Request.PodNamespace, job.Request.PodName)
-	if err != nil {
-		log.Printf("Warning: Failed to collect original metrics: %v", err)
+	metrics := plan.CurrentUsage
+	if metrics == nil {
+		mc.logf(job, "Warning: Failed to collect original metrics")
 		// Create default metrics if collection fails
 		metrics = &types.ResourceUsage{
 			CPUUsage:    0,
@@ -190,17 +749,11 @@ func (mc *MigrationController) captureContainerStates(job *MigrationJob) error {
 	
 	job.Details.OriginalResources = metrics
 
-	// Count containers that should be migrated
-	shouldMigrate := 0
-	for _, state := range containerStates {
-		if state.ShouldMigrate {
-			shouldMigrate++
-		}
+	mc.logf(job, "%d/%d containers will be migrated", plan.ContainersKept, len(plan.Containers))
+	if plan.Readiness.PostStartAllowance > 0 {
+		mc.logf(job, "Allowing %s more for the new pod to become ready because of PostStart hooks", plan.Readiness.PostStartAllowance)
 	}
 
-	log.Printf("Migration %s: %d/%d containers will be migrated", 
-		job.ID, shouldMigrate, len(containerStates))
-
 	return nil
 }
 
@@ -209,149 +762,718 @@ func (mc *MigrationController) createCheckpoint(job *MigrationJob) (string, erro
 	ctx := job.ctx
 	
 	checkpointName := fmt.Sprintf("checkpoint-%s-%d", job.Request.PodName, time.Now().Unix())
+	// The checkpoint of an earlier attempt may still be terminating under its name
+	if attempt := replans(job.Details.SpecDrifts) + len(job.Details.NodeReselections); attempt > 0 {
+		checkpointName = fmt.Sprintf("%s-%d", checkpointName, attempt)
+	}
 	
-	err := mc.k8sClient.CreatePersistentVolumeClaim(ctx, job.Request.PodNamespace, checkpointName, mc.checkpointSize)
+	planned := job.Details.Plan.Checkpoint
+	size, err := resource.ParseQuantity(planned.Size)
+	if err != nil {
+		return "", fmt.Errorf("invalid planned checkpoint size %q: %w", planned.Size, err)
+	}
+	basis := planned.SizeBasis
+	job.Details.CheckpointSize = size.String()
+	job.Details.CheckpointSizeBasis = basis
+
+	accessMode := corev1.PersistentVolumeAccessMode(planned.AccessMode)
+	if err := mc.checkCheckpointAccessMode(ctx, job, accessMode); err != nil {
+		return "", err
+	}
+	job.Details.CheckpointAccessMode = string(accessMode)
+
+	binding, err := mc.planCheckpointBinding(ctx, job)
+	if err != nil {
+		return "", err
+	}
+
+	err = mc.k8sClient.CreatePersistentVolumeClaim(ctx, job.Request.PodNamespace, checkpointName, size, accessMode, binding.SelectedNode, mc.resourceTracking(job))
 	if err != nil {
 		return "", fmt.Errorf("failed to create checkpoint PVC: %w", err)
 	}
 
-	log.Printf("Migration %s: Created checkpoint PVC %s", job.ID, checkpointName)
+	err = mc.verifyCheckpointBinding(ctx, job, checkpointName, binding)
+	if err == nil {
+		err = mc.recordCheckpointChecksum(ctx, job, checkpointName)
+	}
+	if err != nil {
+		// The PVC is not yet recorded on the job, so rollback would not find it
+		if delErr := mc.k8sClient.DeletePersistentVolumeClaim(context.Background(), job.Request.PodNamespace, checkpointName); delErr != nil && !apierrors.IsNotFound(delErr) {
+			mc.logf(job, "Warning: Failed to delete checkpoint PVC %s: %v", checkpointName, delErr)
+		}
+		mc.releaseTracked(context.Background(), job, k8s.TrackedPVC, checkpointName)
+		return "", err
+	}
+	job.Details.CheckpointBinding = binding
+	if binding.Outcome == types.CheckpointBindingUnverified {
+		mc.logf(job, "Warning: Cannot verify that checkpoint PVC %s is reachable from %s: %s", checkpointName, job.Request.TargetNode, binding.Message)
+	}
+
+	mc.logf(job, "Created checkpoint PVC %s (%s, %s, sized from %s)", checkpointName, size.String(), accessMode, basis)
 	return checkpointName, nil
 }
 
+// Bases for the chosen checkpoint size
+const (
+	checkpointSizeFromRequest     = "request"
+	checkpointSizeFromMemoryUsage = "memory_usage"
+	checkpointSizeFromDefault     = "default"
+)
+
+// checkpointSize picks the checkpoint PVC size: an explicit checkpoint_size wins;
+// otherwise the memory usage measured in the capture step plus a safety margin,
+// clamped to the configured bounds; the configured default when usage is unknown
+func (mc *MigrationController) checkpointSize(job *MigrationJob) (resource.Quantity, string, error) {
+	if job.Request.CheckpointSize != "" {
+		requested, err := resource.ParseQuantity(job.Request.CheckpointSize)
+		if err != nil {
+			return resource.Quantity{}, "", fmt.Errorf("invalid checkpoint size %q: %w", job.Request.CheckpointSize, err)
+		}
+		return requested, checkpointSizeFromRequest, nil
+	}
+
+	original := job.Details.OriginalResources
+	if original == nil || original.MemoryUsage <= 0 {
+		return mc.config.checkpointSize, checkpointSizeFromDefault, nil
+	}
+
+	// Round up to whole MiB so the PVC size reads naturally
+	const mebibyte = 1024 * 1024
+	bytes := int64(float64(original.MemoryUsage) * (1 + mc.config.checkpointSizeMargin/100))
+	bytes = (bytes + mebibyte - 1) / mebibyte * mebibyte
+	size := *resource.NewQuantity(bytes, resource.BinarySI)
+
+	if size.Cmp(mc.config.checkpointMinSize) < 0 {
+		size = mc.config.checkpointMinSize
+	} else if size.Cmp(mc.config.checkpointMaxSize) > 0 {
+		size = mc.config.checkpointMaxSize
+	}
+	return size, checkpointSizeFromMemoryUsage, nil
+}
+
 // createOptimizedPod creates a new pod with only the containers that should be migrated
 func (mc *MigrationController) createOptimizedPod(job *MigrationJob, checkpointPVC string) error {
 	ctx := job.ctx
 
-	// Get original pod
-	originalPod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Request.PodName)
-	if err != nil {
-		return fmt.Errorf("failed to get original pod: %w", err)
+	// Get original pod, which is only captured once it was deleted first
+	originalPod := job.originalManifest.DeepCopy()
+	if !job.originalDeleted {
+		var err error
+		originalPod, err = mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Request.PodName)
+		if err != nil {
+			return fmt.Errorf("failed to get original pod: %w", err)
+		}
 	}
 
-	// Create optimized pod
-	newPod, err := mc.k8sClient.CreateOptimizedPod(ctx, originalPod, job.Request.TargetNode, job.Details.ContainerStates, checkpointPVC)
+	// Controller-owned labels are not carried over to the new pod
+	if stripped := stripLabels(originalPod, mc.config.strippedLabels); len(stripped) > 0 {
+		job.Details.StrippedLabels = stripped
+		mc.logf(job, "Not copying labels %s to the new pod", strings.Join(stripped, ", "))
+	}
+
+	if err := checkContainerOverrides(originalPod, job.Details.ContainerStates, job.Request); err != nil {
+		return err
+	}
+	if applied := applyContainerOverrides(originalPod, job.Request); len(applied) > 0 {
+		job.Details.ContainerOverrides = applied
+		mc.logf(job, "Overriding command, args or resources of %d container(s)", len(applied))
+	}
+
+	// Deployments are always placed by the scheduler
+	placement := effectivePlacement(job.Request)
+	job.Details.Placement = placement
+	if placement == types.PlacementNodeName && len(originalPod.Spec.TopologySpreadConstraints) > 0 {
+		mc.logf(job, "Warning: %d topologySpreadConstraints are bypassed because nodeName is pinned (use placement=scheduler to honor them)",
+			len(originalPod.Spec.TopologySpreadConstraints))
+	}
+
+	newPodName, err := mc.newPodName(ctx, job)
 	if err != nil {
-		return fmt.Errorf("failed to create optimized pod: %w", err)
+		return err
 	}
 
-	log.Printf("Migration %s: Created optimized pod %s on node %s", 
-		job.ID, newPod.Name, job.Request.TargetNode)
+	if job.Request.WrapInDeployment {
+		if err := mc.createOptimizedDeployment(job, originalPod, newPodName, checkpointPVC); err != nil {
+			return err
+		}
+	} else {
+		// Create optimized pod
+		newPod, err := mc.k8sClient.CreateOptimizedPod(ctx, originalPod, newPodName, job.Request.TargetNode,
+			placement == types.PlacementScheduler, job.Details.ContainerStates, checkpointPVC, mc.resourceTracking(job))
+		if err != nil {
+			return fmt.Errorf("failed to create optimized pod: %w", err)
+		}
+
+		mc.logf(job, "Created optimized pod %s on node %s", newPod.Name, job.Request.TargetNode)
 
-	// Wait for new pod to be ready
-	err = mc.k8sClient.WaitForPodReady(ctx, newPod.Namespace, newPod.Name, 5*time.Minute)
+		// Store new pod name for rollback and later metric collection
+		job.Details.NewPodName = newPod.Name
+	}
+
+	if successCriteria(job.Request) == types.SuccessCriteriaPodCreated {
+		mc.logf(job, "Not waiting for %s to become ready (success criteria %s)", job.Details.NewPodName, types.SuccessCriteriaPodCreated)
+		return nil
+	}
+
+	// Wait for new pod to be ready, or for the requested custom conditions, giving up
+	// early if the target node is lost meanwhile or the pod settles partly ready
+	waitStart := time.Now()
+	nodeCtx, stopWatch := mc.watchTargetNode(job)
+	waitCtx, stopCrashWatch := mc.watchCrashLoops(nodeCtx, job)
+	err = mc.k8sClient.WaitForPodReady(waitCtx, job.Request.PodNamespace, job.Details.NewPodName,
+		job.Details.Plan.Readiness.Timeout, mc.config.podReadyPollInterval, job.Request.ReadinessConditions, job.Request.TolerateUnreadyContainers)
+	stopCrashWatch()
+	stopWatch()
+	if lost := context.Cause(waitCtx); errors.Is(lost, ErrTargetNodeNotReady) {
+		return lost
+	}
+	if crashing := context.Cause(waitCtx); err != nil && errors.Is(crashing, ErrContainersCrashLooping) {
+		err = crashing
+	}
+	if errors.Is(err, k8s.ErrPodDeleted) || errors.Is(err, k8s.ErrPodTerminated) {
+		return fmt.Errorf("new pod was lost before becoming ready: %w", err)
+	}
+	stuck := mc.recordStartupLatency(ctx, job)
+	if err != nil && mc.acceptPartialReadiness(ctx, job) {
+		return nil
+	}
+	if err != nil && stuck != "" {
+		return fmt.Errorf("new pod failed to become ready (%s): %w", stuck, err)
+	}
 	if err != nil {
 		return fmt.Errorf("new pod failed to become ready: %w", err)
 	}
 
-	log.Printf("Migration %s: New pod %s is ready", job.ID, newPod.Name)
-	
-	// Store new pod name for later metric collection
-	job.Details.NewPodName = newPod.Name
+	timeToReady := time.Since(waitStart)
+	mc.migrationsMux.Lock()
+	job.Details.TimeToReady = &timeToReady
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "New pod %s is ready after %s", job.Details.NewPodName, timeToReady.Round(time.Millisecond))
+	mc.recordToleratedContainers(ctx, job)
 	
 	return nil
 }
 
-// deleteOriginalPod removes the original pod
+// createOptimizedDeployment creates the single-replica Deployment wrapping the
+// optimized pod and waits for its pod to appear
+func (mc *MigrationController) createOptimizedDeployment(job *MigrationJob, originalPod *corev1.Pod, name, checkpointPVC string) error {
+	deployment, err := mc.k8sClient.CreateOptimizedDeployment(job.ctx, originalPod, name, job.Request.TargetNode, job.Details.ContainerStates, checkpointPVC, mc.resourceTracking(job))
+	if err != nil {
+		return fmt.Errorf("failed to create optimized deployment: %w", err)
+	}
+
+	// Store the deployment name for rollback before waiting on it
+	job.Details.DeploymentName = deployment.Name
+	mc.logf(job, "Created deployment %s pinned to node %s", deployment.Name, job.Request.TargetNode)
+
+	podName, err := mc.k8sClient.WaitForDeploymentPod(job.ctx, deployment.Namespace, deployment.Name, mc.config.podReadyTimeout)
+	if err != nil {
+		return err
+	}
+	job.Details.NewPodName = podName
+	mc.logf(job, "Deployment %s created pod %s", deployment.Name, podName)
+	return nil
+}
+
+// verifyNewPod runs the requested verification command in the new pod and requires it to exit 0
+func (mc *MigrationController) verifyNewPod(job *MigrationJob) error {
+	container := job.Request.VerifyContainer
+	if container == "" {
+		for _, state := range job.Details.ContainerStates {
+			if state.ShouldMigrate {
+				container = state.Name
+				break
+			}
+		}
+	}
+
+	output, exitCode, err := mc.k8sClient.ExecInPod(job.ctx, job.Request.PodNamespace, job.Details.NewPodName, container, job.Request.VerifyCommand)
+	if err != nil {
+		return fmt.Errorf("failed to run verification command: %w", err)
+	}
+
+	job.Details.Verification = &types.VerificationResult{
+		Container: container,
+		Command:   job.Request.VerifyCommand,
+		ExitCode:  exitCode,
+		Output:    output,
+		Passed:    exitCode == 0,
+	}
+
+	if exitCode != 0 {
+		return fmt.Errorf("command exited with code %d in container %s", exitCode, container)
+	}
+
+	mc.logf(job, "Verification command passed in container %s", container)
+	return nil
+}
+
+// rollbackMigration removes the resources created for a migration that did not
+// complete, leaving the original pod untouched. A migration that already deleted
+// the original pod restores the workload instead.
+func (mc *MigrationController) rollbackMigration(job *MigrationJob) {
+	if err := mc.updateJobStatus(job, types.MigrationStatusRollingBack); err != nil {
+		mc.logf(job, "Warning: %v", err)
+	}
+
+	// The job context may already be expired, so cleanup gets its own deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if job.originalDeleted {
+		mc.rollbackWithoutOriginal(ctx, job)
+		return
+	}
+
+	// Undo the Service first so it never points at a pod being deleted
+	mc.rollbackService(ctx, job)
+	mc.deleteCreatedObjects(ctx, job, "Rollback", false)
+}
+
+// deleteCreatedObjects deletes the new pod or Deployment and the checkpoint PVC of a
+// migration. With forcePod the new pod is deleted without a grace period, for pods
+// on a node that can no longer confirm their termination.
+func (mc *MigrationController) deleteCreatedObjects(ctx context.Context, job *MigrationJob, action string, forcePod bool) {
+	if job.Details.DeploymentName != "" {
+		// Deleting the deployment removes its pod; deleting the pod alone would only recreate it
+		err := mc.k8sClient.DeleteDeployment(ctx, job.Request.PodNamespace, job.Details.DeploymentName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			mc.logf(job, "Warning: %s failed to delete deployment %s: %v", action, job.Details.DeploymentName, err)
+		} else {
+			mc.logf(job, "%s: deleted deployment %s", action, job.Details.DeploymentName)
+			mc.releaseTracked(ctx, job, k8s.TrackedDeployment, job.Details.DeploymentName)
+			job.cleanedUp = append(job.cleanedUp, "deployment/"+job.Details.DeploymentName)
+		}
+	} else if job.Details.NewPodName != "" {
+		var err error
+		if forcePod {
+			err = mc.k8sClient.ForceDeletePod(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+		} else {
+			err = mc.k8sClient.DeletePod(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			mc.logf(job, "Warning: %s failed to delete new pod %s: %v", action, job.Details.NewPodName, err)
+		} else {
+			mc.logf(job, "%s: deleted new pod %s", action, job.Details.NewPodName)
+			mc.releaseTracked(ctx, job, k8s.TrackedPod, job.Details.NewPodName)
+			job.cleanedUp = append(job.cleanedUp, "pod/"+job.Details.NewPodName)
+		}
+	}
+
+	if job.Details.PVClaimName != "" {
+		err := mc.k8sClient.DeletePersistentVolumeClaim(ctx, job.Request.PodNamespace, job.Details.PVClaimName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			mc.logf(job, "Warning: %s failed to delete checkpoint PVC %s: %v", action, job.Details.PVClaimName, err)
+		} else {
+			mc.logf(job, "%s: deleted checkpoint PVC %s", action, job.Details.PVClaimName)
+			mc.releaseTracked(ctx, job, k8s.TrackedPVC, job.Details.PVClaimName)
+			job.cleanedUp = append(job.cleanedUp, "persistentvolumeclaim/"+job.Details.PVClaimName)
+		}
+	}
+}
+
+// deleteOriginalPod removes the original pod once its PodDisruptionBudgets allow it
 func (mc *MigrationController) deleteOriginalPod(job *MigrationJob) error {
 	ctx := job.ctx
 	
-	err := mc.k8sClient.DeletePod(ctx, job.Request.PodNamespace, job.Request.PodName)
+	if err := mc.waitForDisruptionBudget(job); err != nil {
+		return err
+	}
+
+	var err error
+	if job.Details.Plan.Cutover.HonorPreStopHooks {
+		// PreStop hooks get the pod's own grace period to finish
+		err = mc.k8sClient.DeletePodWithOwnGracePeriod(ctx, job.Request.PodNamespace, job.Request.PodName)
+	} else {
+		err = mc.k8sClient.DeletePod(ctx, job.Request.PodNamespace, job.Request.PodName)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete original pod: %w", err)
 	}
 
-	log.Printf("Migration %s: Deleted original pod %s", job.ID, job.Request.PodName)
+	mc.logf(job, "Deleted original pod %s", job.Request.PodName)
 	return nil
 }
 
-// collectPostMigrationMetrics collects resource usage after migration
-func (mc *MigrationController) collectPostMigrationMetrics(job *MigrationJob) error {
-	// Wait a bit for metrics to stabilize
-	time.Sleep(30 * time.Second)
+// runPostMigrationMetrics collects post-migration metrics on a bounded worker pool
+// and folds them into the completed migration record
+func (mc *MigrationController) runPostMigrationMetrics(job *MigrationJob) {
+	mc.metricsWorkers <- struct{}{}
+	defer func() { <-mc.metricsWorkers }()
 
-	// Collect actual metrics from the new pod
-	if job.Details.NewPodName != "" {
-		metrics, err := mc.k8sClient.GetPodMetrics(job.ctx, job.Request.PodNamespace, job.Details.NewPodName)
+	// The job context is released with the execution slot, so collection gets its own
+	ctx, cancel := context.WithTimeout(mc.stopped, originalDeleteTimeout+mc.config.metricsStabilizationDelay+time.Minute)
+	defer cancel()
+
+	// The stabilization delay also lets the original pod's usage leave the source
+	// node's metrics, once the pod is really gone
+	measureSource := mc.waitOriginalReleased(ctx, job)
+
+	optimized, err := mc.collectPostMigrationMetrics(ctx, job)
+	if err != nil {
+		mc.logf(job, "Warning: Failed to collect post-migration metrics: %v", err)
+		// Don't fail migration for this
+	}
+
+	targetNode := mc.collectTargetNodeUtilization(ctx, job)
+	var sourceRelief *types.SourceNodeRelief
+	if measureSource {
+		sourceRelief = mc.measureSourceNodeAfter(ctx, job)
+	}
+
+	mc.recordPostMigrationMetrics(job, optimized, targetNode, sourceRelief)
+	mc.writeResearchRecord(job)
+}
+
+// waitForDisruptionBudget blocks until deleting the original pod would not breach any
+// PodDisruptionBudget selecting it, re-checking periodically like kubectl drain does
+func (mc *MigrationController) waitForDisruptionBudget(job *MigrationJob) error {
+	ctx := job.ctx
+
+	check := &types.PDBCheck{}
+	job.Details.PDBCheck = check
+
+	if job.Request.ForceIgnorePDB {
+		check.Allowed = true
+		check.Ignored = true
+		check.Message = "PodDisruptionBudgets ignored by request"
+		mc.logf(job, "Ignoring PodDisruptionBudgets for original pod as requested")
+		return nil
+	}
+
+	for {
+		check.Attempts++
+
+		pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Request.PodName)
 		if err != nil {
-			log.Printf("Warning: Failed to collect optimized pod metrics: %v", err)
-			// Fallback to simulation if metrics collection fails
-			if job.Details.OriginalResources != nil {
-				job.Details.OptimizedResources = &types.ResourceUsage{
-					CPUUsage:    job.Details.OriginalResources.CPUUsage * 0.5,
-					MemoryUsage: int64(float64(job.Details.OriginalResources.MemoryUsage) * 0.6),
-					Timestamp:   time.Now(),
-				}
-			}
+			return fmt.Errorf("failed to get original pod: %w", err)
+		}
+
+		budgets, blocking, err := mc.evaluateDisruptionBudgets(ctx, pod)
+		if err != nil {
+			return err
+		}
+		check.Budgets = budgets
+
+		if blocking == "" {
+			check.Allowed = true
+			check.Message = fmt.Sprintf("%d PodDisruptionBudget(s) allow the deletion", len(budgets))
 			return nil
 		}
-		job.Details.OptimizedResources = metrics
-		log.Printf("Migration %s: Collected optimized metrics - CPU: %.2f cores, Memory: %d bytes", 
-			job.ID, metrics.CPUUsage, metrics.MemoryUsage)
-	} else {
-		// Fallback: if new pod name is not available, use simulation
-		log.Printf("Warning: New pod name not available, using simulated metrics")
-		if job.Details.OriginalResources != nil {
-			job.Details.OptimizedResources = &types.ResourceUsage{
-				CPUUsage:    job.Details.OriginalResources.CPUUsage * 0.5,
-				MemoryUsage: int64(float64(job.Details.OriginalResources.MemoryUsage) * 0.6),
-				Timestamp:   time.Now(),
-			}
+
+		check.Message = fmt.Sprintf("PodDisruptionBudget %s allows no disruptions", blocking)
+		mc.logf(job, "Deletion of original pod blocked: %s, retrying in %s", check.Message, mc.config.pdbRetryInterval)
+
+		if err := sleepContext(ctx, mc.config.pdbRetryInterval); err != nil {
+			return fmt.Errorf("%s: %w", check.Message, err)
 		}
 	}
+}
 
-	return nil
+// evaluateDisruptionBudgets returns the names of the PodDisruptionBudgets selecting
+// the pod and the first one that currently allows no disruptions, if any. Budgets
+// that cannot be read block the deletion rather than being assumed to allow it.
+func (mc *MigrationController) evaluateDisruptionBudgets(ctx context.Context, pod *corev1.Pod) (budgets []string, blocking string, err error) {
+	if err := mc.requireFeature(FeaturePolicyV1); err != nil {
+		return nil, "", fmt.Errorf("cannot check pod disruption budgets: %w; set force_ignore_pdb to delete the pod anyway", err)
+	}
+
+	pdbs, err := mc.k8sClient.GetPodDisruptionBudgetsForPod(ctx, pod)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to check pod disruption budgets: %w", err)
+	}
+
+	for _, pdb := range pdbs {
+		budgets = append(budgets, pdb.Name)
+		if blocking == "" && pdb.Status.DisruptionsAllowed < 1 {
+			blocking = pdb.Name
+		}
+	}
+	return budgets, blocking, nil
+}
+
+// collectPostMigrationMetrics collects resource usage after migration
+func (mc *MigrationController) collectPostMigrationMetrics(ctx context.Context, job *MigrationJob) (*types.ResourceUsage, error) {
+	// Wait a bit for metrics to stabilize
+	if err := sleepContext(ctx, mc.config.metricsStabilizationDelay); err != nil {
+		return nil, fmt.Errorf("interrupted while waiting for metrics to stabilize: %w", err)
+	}
+
+	// Fallback: if new pod name is not available, use simulation
+	if job.Details.NewPodName == "" {
+		mc.logf(job, "Warning: New pod name not available, using simulated metrics")
+		return simulateOptimizedResources(job.Details.OriginalResources), nil
+	}
+
+	// Collect actual metrics from the new pod
+	metrics, err := mc.metricsProvider.PodMetrics(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+	if err != nil {
+		mc.logf(job, "Warning: Failed to collect optimized pod metrics: %v", err)
+		// Fallback to simulation if metrics collection fails
+		return simulateOptimizedResources(job.Details.OriginalResources), nil
+	}
+
+	mc.logf(job, "Collected optimized metrics - CPU: %.2f cores, Memory: %d bytes",
+		metrics.CPUUsage, metrics.MemoryUsage)
+	return metrics, nil
+}
+
+// collectTargetNodeUtilization measures the target node after the migration so that
+// migrations which overload it can be spotted. Failures are logged and yield nil.
+func (mc *MigrationController) collectTargetNodeUtilization(ctx context.Context, job *MigrationJob) *types.NodeUtilization {
+	node := job.Request.TargetNode
+
+	utilization, err := mc.nodeUtilization(ctx, node)
+	if err != nil {
+		mc.logf(job, "Warning: Failed to measure target node: %v", err)
+		return nil
+	}
+
+	threshold := mc.config.nodePressureThreshold
+	if utilization.CPUPercent > threshold || utilization.MemoryPercent > threshold {
+		utilization.UnderPressure = true
+		mc.logf(job, "Warning: Target node %s is under pressure after migration (CPU %.1f%%, memory %.1f%%, threshold %.0f%%)",
+			node, utilization.CPUPercent, utilization.MemoryPercent, threshold)
+	}
+	return utilization
+}
+
+// nodeUtilization measures a node's usage as a share of its allocatable resources
+func (mc *MigrationController) nodeUtilization(ctx context.Context, node string) (*types.NodeUtilization, error) {
+	usage, err := mc.metricsProvider.NodeMetrics(ctx, node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect metrics of node %s: %w", node, err)
+	}
+	allocatableCPU, allocatableMemory, err := mc.k8sClient.GetNodeAllocatable(ctx, node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capacity of node %s: %w", node, err)
+	}
+
+	utilization := &types.NodeUtilization{
+		Node:        node,
+		CPUUsage:    usage.CPUUsage,
+		MemoryUsage: usage.MemoryUsage,
+		Timestamp:   usage.Timestamp,
+	}
+	if allocatableCPU > 0 {
+		utilization.CPUPercent = usage.CPUUsage / allocatableCPU * 100
+	}
+	if allocatableMemory > 0 {
+		utilization.MemoryPercent = float64(usage.MemoryUsage) / float64(allocatableMemory) * 100
+	}
+	return utilization, nil
+}
+
+// simulateOptimizedResources estimates post-migration usage from the paper's targets
+// (50% CPU, 60% memory) when real metrics are unavailable
+func simulateOptimizedResources(original *types.ResourceUsage) *types.ResourceUsage {
+	if original == nil {
+		return nil
+	}
+	return &types.ResourceUsage{
+		CPUUsage:    original.CPUUsage * 0.5,
+		MemoryUsage: int64(float64(original.MemoryUsage) * 0.6),
+		GPUUsage:    original.GPUUsage, // the paper sets no GPU target, so assume no change
+		Timestamp:   time.Now(),
+	}
 }
 
 // Helper methods
 
-func (mc *MigrationController) updateJobStatus(job *MigrationJob, status types.MigrationStatus) {
+// sleepContext waits for d, returning early with the context's error if ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// updateJobStatus moves a job to a non-terminal status; terminal statuses are set by
+// failMigration and completeMigration
+func (mc *MigrationController) updateJobStatus(job *MigrationJob, status types.MigrationStatus) error {
 	mc.migrationsMux.Lock()
-	job.Status = status
-	mc.migrationsMux.Unlock()
+	defer mc.migrationsMux.Unlock()
+	return mc.transitionLocked(job, status)
 }
 
+// failMigration ends a migration as failed, or as cancelled when it was cancelled
 func (mc *MigrationController) failMigration(job *MigrationJob, message string) {
-	log.Printf("Migration %s failed: %s", job.ID, message)
-	
+	status := types.MigrationStatusFailed
+	if reason := mc.cancellationReason(job); reason != "" {
+		status = types.MigrationStatusCancelled
+		mc.logf(job, "Migration cancelled (%s): %s", reason, message)
+	} else {
+		mc.logf(job, "Migration failed: %s", message)
+	}
+
+	// The original pod is left in place on failure, so its logs are still available
+	capturedLogs := mc.captureFailureLogs(job)
+
 	mc.migrationsMux.Lock()
-	job.Status = types.MigrationStatusFailed
+	if err := mc.transitionLocked(job, status); err != nil {
+		mc.migrationsMux.Unlock()
+		log.Printf("Migration %s: %v", job.ID, err)
+		return
+	}
+	job.failure = message
+	job.Details.Failure = classifyFailure(job.Details.CurrentStep, message)
+	job.Details.CapturedLogs = append(job.Details.CapturedLogs, capturedLogs...)
+	job.Details.EstimatedTimeRemaining = nil // CurrentStep is kept to show where it failed
 	endTime := time.Now()
 	job.Details.EndTime = &endTime
 	duration := endTime.Sub(job.StartTime)
 	job.Details.Duration = &duration
-	mc.metrics.FailedMigrations++
+	if status == types.MigrationStatusFailed {
+		mc.metricsMux.Lock()
+		mc.metrics.TotalMigrations++
+		mc.metrics.FailedMigrations++
+		mc.recordFinishLocked(endTime, false)
+		mc.metricsMux.Unlock()
+	}
+	job.summary = buildSummaryLocked(job)
+	close(job.done)
+	event := mc.buildWebhookEventLocked(job)
 	mc.migrationsMux.Unlock()
+
+	mc.exportRecord(buildRecord(job.summary))
+	mc.webhooks.Dispatch(event)
+	mc.writeResearchRecord(job)
 }
 
-func (mc *MigrationController) completeMigration(job *MigrationJob) {
+// completeMigration ends a successful migration as completed, or completed_safe when
+// the original pod was retained by safe mode
+func (mc *MigrationController) completeMigration(job *MigrationJob, status types.MigrationStatus) error {
+	if status == types.MigrationStatusPartiallyDegraded {
+		mc.logf(job, "Migration completed with degraded containers %s", strings.Join(job.Details.DegradedContainers, ", "))
+	} else {
+		mc.logf(job, "Migration completed successfully")
+	}
+
 	mc.migrationsMux.Lock()
-	job.Status = types.MigrationStatusCompleted
+	if err := mc.transitionLocked(job, status); err != nil {
+		mc.migrationsMux.Unlock()
+		return err
+	}
 	endTime := time.Now()
 	job.Details.EndTime = &endTime
 	duration := endTime.Sub(job.StartTime)
 	job.Details.Duration = &duration
 	
 	// Update metrics
+	mc.metricsMux.Lock()
 	mc.metrics.TotalMigrations++
 	mc.metrics.SuccessfulMigrations++
+	mc.recordFinishLocked(endTime, true)
 	
-	// Calculate average duration
-	if mc.metrics.TotalMigrations > 0 {
-		// Simplified average calculation
-		mc.metrics.AverageDuration = (mc.metrics.AverageDuration*time.Duration(mc.metrics.TotalMigrations-1) + duration) / time.Duration(mc.metrics.TotalMigrations)
+	// Running average over the successful migrations
+	successful := time.Duration(mc.metrics.SuccessfulMigrations)
+	mc.metrics.AverageDuration = (mc.metrics.AverageDuration*(successful-1) + duration) / successful
+	if job.Details.SchedulingLatency != nil {
+		mc.schedulingStats.count++
+		mc.schedulingStats.total += *job.Details.SchedulingLatency
 	}
-	
-	// Calculate resource savings if we have both metrics
-	if job.Details.OriginalResources != nil && job.Details.OptimizedResources != nil {
-		cpuSavings := ((job.Details.OriginalResources.CPUUsage - job.Details.OptimizedResources.CPUUsage) / job.Details.OriginalResources.CPUUsage) * 100
-		memorySavings := ((float64(job.Details.OriginalResources.MemoryUsage - job.Details.OptimizedResources.MemoryUsage)) / float64(job.Details.OriginalResources.MemoryUsage)) * 100
-		
-		mc.metrics.CPUSavings = cpuSavings
-		mc.metrics.MemorySavings = memorySavings
+	if job.Details.StartupLatency != nil {
+		mc.startupStats.count++
+		mc.startupStats.total += *job.Details.StartupLatency
 	}
+	mc.metricsMux.Unlock()
 	
+	// Optimized resources are collected asynchronously after completion
+	job.Details.MetricsPending = true
+	job.summary = buildSummaryLocked(job)
+	
+	close(job.done)
 	mc.migrationsMux.Unlock()
+	return nil
+}
+
+// recordPostMigrationMetrics stores the optimized resource usage of a completed
+// migration and updates the savings metrics
+func (mc *MigrationController) recordPostMigrationMetrics(job *MigrationJob, optimized *types.ResourceUsage, targetNode *types.NodeUtilization, sourceRelief *types.SourceNodeRelief) {
+	mc.migrationsMux.Lock()
+	defer mc.migrationsMux.Unlock()
+
+	job.Details.OptimizedResources = optimized
+	job.Details.TargetNodeUtilization = targetNode
+	if sourceRelief != nil {
+		job.Details.SourceNodeRelief = sourceRelief
+		mc.metricsMux.Lock()
+		mc.sourceRelief.count++
+		mc.sourceRelief.cpu += sourceRelief.CPUPercentRelief
+		mc.sourceRelief.memory += sourceRelief.MemoryPercentRelief
+		mc.metricsMux.Unlock()
+	}
+
+	// Completed migrations are exported and announced once their metrics are final
+	job.Details.MetricsPending = false
+	defer func() {
+		job.summary = buildSummaryLocked(job)
+		event := mc.buildWebhookEventLocked(job)
+		mc.exportRecord(buildRecord(job.summary))
+		mc.webhooks.Dispatch(event)
+	}()
+
+	// Calculate resource savings if we have both metrics
+	original := job.Details.OriginalResources
+	if original == nil || optimized == nil {
+		return
+	}
+	mc.metricsMux.Lock()
+	defer mc.metricsMux.Unlock()
+	if original.CPUUsage > 0 {
+		mc.metrics.CPUSavings = ((original.CPUUsage - optimized.CPUUsage) / original.CPUUsage) * 100
+	}
+	if original.MemoryUsage > 0 {
+		mc.metrics.MemorySavings = (float64(original.MemoryUsage-optimized.MemoryUsage) / float64(original.MemoryUsage)) * 100
+	}
+	mc.metrics.CPUCoresSaved += original.CPUUsage - optimized.CPUUsage
+	mc.metrics.MemoryBytesSaved += original.MemoryUsage - optimized.MemoryUsage
+
+	// Pods without GPUs leave the GPU metrics untouched
+	if original.GPUUsage > 0 {
+		mc.metrics.GPUSavings = ((original.GPUUsage - optimized.GPUUsage) / original.GPUUsage) * 100
+		mc.metrics.GPUsSaved += original.GPUUsage - optimized.GPUUsage
+	}
+}
+
+// logf writes a progress line to the process log and appends it to the job's
+// bounded log buffer so API clients can read it
+func (mc *MigrationController) logf(job *MigrationJob, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	// Only the process log is sampled; the migration's own log keeps every entry
+	if write, held := mc.logSampler.admit(format, time.Now()); write {
+		printed := message
+		if held > 0 {
+			printed = fmt.Sprintf("%s (%d similar messages suppressed)", message, held)
+		}
+		if job.Request.RequestID != "" {
+			log.Printf("Migration %s [request_id=%s]: %s", job.ID, job.Request.RequestID, printed)
+		} else {
+			log.Printf("Migration %s: %s", job.ID, printed)
+		}
+	}
+
+	mc.migrationsMux.Lock()
+	defer mc.migrationsMux.Unlock()
+
+	job.logSeq++
+	job.logs = append(job.logs, types.MigrationLogEntry{
+		Sequence:  job.logSeq,
+		Timestamp: time.Now(),
+		Message:   message,
+	})
+	if len(job.logs) > maxLogEntriesPerJob {
+		job.logs = job.logs[len(job.logs)-maxLogEntriesPerJob:]
+	}
+
+	// Wake up followers and arm a fresh channel for the next entry
+	close(job.logUpdated)
+	job.logUpdated = make(chan struct{})
 }
 
 func (mc *MigrationController) getStatusMessage(status types.MigrationStatus) string {
@@ -360,8 +1482,16 @@ func (mc *MigrationController) getStatusMessage(status types.MigrationStatus) st
 		return "Migration is pending"
 	case types.MigrationStatusRunning:
 		return "Migration is in progress"
+	case types.MigrationStatusAwaitingCutover:
+		return "New pod is ready, replacing the original pod"
+	case types.MigrationStatusRollingBack:
+		return "Migration failed, rolling back"
 	case types.MigrationStatusCompleted:
 		return "Migration completed successfully"
+	case types.MigrationStatusCompletedSafe:
+		return "Migration completed in safe mode; the original pod was retained and is still running"
+	case types.MigrationStatusPartiallyDegraded:
+		return "Migration completed, but some containers of the new pod are not ready"
 	case types.MigrationStatusFailed:
 		return "Migration failed"
 	case types.MigrationStatusCancelled:
@@ -371,12 +1501,141 @@ func (mc *MigrationController) getStatusMessage(status types.MigrationStatus) st
 	}
 }
 
-// GetMetrics returns current migration metrics
-func (mc *MigrationController) GetMetrics() *types.MigrationMetrics {
+// GetMigrationLogs returns the log entries of a migration with a sequence number greater
+// than since. The returned channel is closed when a newer entry is appended, and
+// finished reports whether the migration has reached a terminal status.
+func (mc *MigrationController) GetMigrationLogs(migrationID string, since int64) (entries []types.MigrationLogEntry, updated <-chan struct{}, finished bool, err error) {
 	mc.migrationsMux.RLock()
 	defer mc.migrationsMux.RUnlock()
-	
+
+	job, exists := mc.migrations[migrationID]
+	if !exists {
+		return nil, nil, false, fmt.Errorf("migration %s not found", migrationID)
+	}
+
+	for _, entry := range job.logs {
+		if entry.Sequence > since {
+			entries = append(entries, entry)
+		}
+	}
+
+	select {
+	case <-job.done:
+		finished = true
+	default:
+	}
+
+	return entries, job.logUpdated, finished, nil
+}
+
+// GetMetrics returns current migration metrics. It takes metricsMux rather than
+// migrationsMux, so scraping metrics and running migrations never wait on each other.
+func (mc *MigrationController) GetMetrics() *types.MigrationMetrics {
+	mc.metricsMux.Lock()
 	// Return a copy of metrics
 	metrics := *mc.metrics
+	metrics.AverageStepDurations = mc.averageStepDurationsLocked()
+	metrics.AverageSchedulingLatency = mc.schedulingStats.average()
+	metrics.AverageStartupLatency = mc.startupStats.average()
+	metrics.AverageSourceCPURelief, metrics.AverageSourceMemoryRelief = mc.sourceRelief.averages()
+	metrics.AverageCPUWaste, metrics.AverageMemoryWaste = mc.waste.averages()
+	metrics.Rate = mc.migrationRateLocked(time.Now())
+	mc.metricsMux.Unlock()
+
+	// The slots, client and sampler have locks of their own
+	metrics.ActiveByNamespace = mc.slots.runningByNamespace()
+	metrics.EffectiveConcurrency = mc.slots.currentLimit()
+	metrics.APIThrottledRequests = mc.k8sClient.ThrottledRequests()
+	metrics.LogMessagesSuppressed = mc.logSampler.suppressedTotal()
 	return &metrics
 }
+
+// GetNamespaceMetrics computes the metrics of the namespaces accepted by allowed from
+// the migrations kept in memory, for callers limited to those namespaces. Rate and
+// step durations are cluster-wide and left out.
+func (mc *MigrationController) GetNamespaceMetrics(allowed func(namespace string) bool) *types.MigrationMetrics {
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	metrics := &types.MigrationMetrics{}
+	var totalDuration time.Duration
+	var scheduling, startup stepStats
+	var relief reliefStats
+	var waste wasteStats
+	var latest, latestGPU *MigrationJob
+	for _, job := range mc.migrations {
+		if !allowed(job.Request.PodNamespace) || job.Details.AlreadyMigrated {
+			continue
+		}
+		for _, gap := range job.Details.ResourceGaps {
+			waste.add(gap)
+		}
+		switch {
+		case job.Status == types.MigrationStatusFailed:
+			metrics.TotalMigrations++
+			metrics.FailedMigrations++
+		case job.Status.Succeeded():
+			metrics.TotalMigrations++
+			metrics.SuccessfulMigrations++
+			if job.Details.Duration != nil {
+				totalDuration += *job.Details.Duration
+			}
+			if job.Details.SchedulingLatency != nil {
+				scheduling.count++
+				scheduling.total += *job.Details.SchedulingLatency
+			}
+			if job.Details.StartupLatency != nil {
+				startup.count++
+				startup.total += *job.Details.StartupLatency
+			}
+			if source := job.Details.SourceNodeRelief; source != nil && source.After != nil {
+				relief.count++
+				relief.cpu += source.CPUPercentRelief
+				relief.memory += source.MemoryPercentRelief
+			}
+			original, optimized := job.Details.OriginalResources, job.Details.OptimizedResources
+			if original == nil || optimized == nil {
+				continue
+			}
+			metrics.CPUCoresSaved += original.CPUUsage - optimized.CPUUsage
+			metrics.MemoryBytesSaved += original.MemoryUsage - optimized.MemoryUsage
+			if latest == nil || job.Details.EndTime.After(*latest.Details.EndTime) {
+				latest = job
+			}
+			if original.GPUUsage > 0 {
+				metrics.GPUsSaved += original.GPUUsage - optimized.GPUUsage
+				if latestGPU == nil || job.Details.EndTime.After(*latestGPU.Details.EndTime) {
+					latestGPU = job
+				}
+			}
+		}
+	}
+	if metrics.SuccessfulMigrations > 0 {
+		metrics.AverageDuration = totalDuration / time.Duration(metrics.SuccessfulMigrations)
+	}
+	metrics.AverageSchedulingLatency = scheduling.average()
+	metrics.AverageStartupLatency = startup.average()
+	metrics.AverageSourceCPURelief, metrics.AverageSourceMemoryRelief = relief.averages()
+	metrics.AverageCPUWaste, metrics.AverageMemoryWaste = waste.averages()
+	if latest != nil {
+		original, optimized := latest.Details.OriginalResources, latest.Details.OptimizedResources
+		if original.CPUUsage > 0 {
+			metrics.CPUSavings = ((original.CPUUsage - optimized.CPUUsage) / original.CPUUsage) * 100
+		}
+		if original.MemoryUsage > 0 {
+			metrics.MemorySavings = (float64(original.MemoryUsage-optimized.MemoryUsage) / float64(original.MemoryUsage)) * 100
+		}
+	}
+	if latestGPU != nil {
+		original, optimized := latestGPU.Details.OriginalResources, latestGPU.Details.OptimizedResources
+		metrics.GPUSavings = ((original.GPUUsage - optimized.GPUUsage) / original.GPUUsage) * 100
+	}
+
+	metrics.ActiveByNamespace = make(map[string]int)
+	for namespace, count := range mc.slots.runningByNamespace() {
+		if allowed(namespace) {
+			metrics.ActiveByNamespace[namespace] = count
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNamespaceMetricsCountEveryFinishedMigration(t *testing.T) {
+	mc, _ := newTestController(t, nil)
+	for id, status := range map[string]types.MigrationStatus{
+		"completed":  types.MigrationStatusCompleted,
+		"safe":       types.MigrationStatusCompletedSafe,
+		"degraded":   types.MigrationStatusPartiallyDegraded,
+		"failed":     types.MigrationStatusFailed,
+		"running":    types.MigrationStatusRunning,
+		"other-team": types.MigrationStatusCompleted,
+	} {
+		namespace := testNamespace
+		if id == "other-team" {
+			namespace = "other"
+		}
+		duration := time.Minute
+		if status == types.MigrationStatusFailed {
+			duration = time.Hour
+		}
+		mc.migrations[id] = &MigrationJob{
+			ID:      id,
+			Request: &types.MigrationRequest{PodNamespace: namespace},
+			Status:  status,
+			Details: &types.MigrationDetails{Duration: &duration},
+		}
+	}
+
+	metrics := mc.GetNamespaceMetrics(func(namespace string) bool { return namespace == testNamespace })
+	if metrics.TotalMigrations != 4 || metrics.SuccessfulMigrations != 3 || metrics.FailedMigrations != 1 {
+		t.Errorf("total/successful/failed = %d/%d/%d, want 4/3/1",
+			metrics.TotalMigrations, metrics.SuccessfulMigrations, metrics.FailedMigrations)
+	}
+	// The hour the failed migration took is not a migration's duration
+	if metrics.AverageDuration != time.Minute {
+		t.Errorf("average duration = %s, want 1m0s over the successes", metrics.AverageDuration)
+	}
+}
+
+// The cluster-wide counters agree with the namespace view on what a migration is
+func TestMetricsCountFailedMigrations(t *testing.T) {
+	mc, _ := newTestController(t, fullMode, testNode("node-a"), testNode("node-b"),
+		testPod("app", "node-a", "main"), testPod("db", "node-a", "main"))
+	ok := testRequest("app", "node-a", "node-b")
+	ok.SuccessCriteria = types.SuccessCriteriaPodCreated
+	ok.ForceIgnorePDB = true
+	if response := runMigration(t, mc, ok); response.Status != types.MigrationStatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+	}
+	failing := testRequest("db", "node-a", "node-b")
+	failing.ExpectedPodSpecHash = "0123456789abcdef"
+	if response := runMigration(t, mc, failing); response.Status != types.MigrationStatusFailed {
+		t.Fatalf("status = %s (%s), want failed", response.Status, response.Message)
+	}
+
+	metrics := mc.GetMetrics()
+	if metrics.TotalMigrations != 2 || metrics.SuccessfulMigrations != 1 || metrics.FailedMigrations != 1 {
+		t.Errorf("total/successful/failed = %d/%d/%d, want 2/1/1",
+			metrics.TotalMigrations, metrics.SuccessfulMigrations, metrics.FailedMigrations)
+	}
+	namespace := mc.GetNamespaceMetrics(func(string) bool { return true })
+	if namespace.TotalMigrations != metrics.TotalMigrations {
+		t.Errorf("namespace total = %d, cluster total = %d", namespace.TotalMigrations, metrics.TotalMigrations)
+	}
+}
+
+// The scheduler places the new pod by its topology spread constraints; a pinned
+// nodeName bypasses them, which the migration warns about
+func TestTopologySpreadConstraintsByPlacement(t *testing.T) {
+	for _, placement := range []string{types.PlacementScheduler, types.PlacementNodeName} {
+		t.Run(placement, func(t *testing.T) {
+			pod := testPod("app", "node-a", "main")
+			pod.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: pod.Labels},
+			}}
+			mc, clientset := newTestController(t, fullMode, testNode("node-a"), testNode("node-b"), pod)
+			req := testRequest("app", "node-a", "node-b")
+			req.SuccessCriteria = types.SuccessCriteriaPodCreated
+			req.ForceIgnorePDB = true
+			req.Placement = placement
+
+			response := runMigration(t, mc, req)
+			if response.Status != types.MigrationStatusCompleted {
+				t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+			}
+			if response.Details.Placement != placement {
+				t.Errorf("placement = %q, want %q", response.Details.Placement, placement)
+			}
+			created, err := clientset.CoreV1().Pods(testNamespace).Get(context.Background(), response.Details.NewPodName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries, _, _, err := mc.GetMigrationLogs(response.MigrationID, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			warned := false
+			for _, entry := range entries {
+				warned = warned || strings.Contains(entry.Message, "topologySpreadConstraints are bypassed")
+			}
+
+			if !reflect.DeepEqual(created.Spec.TopologySpreadConstraints, pod.Spec.TopologySpreadConstraints) {
+				t.Errorf("topology spread constraints = %+v, want the original's", created.Spec.TopologySpreadConstraints)
+			}
+			if placement == types.PlacementScheduler {
+				if created.Spec.NodeName != "" {
+					t.Errorf("nodeName = %q, want the pod left to the scheduler", created.Spec.NodeName)
+				}
+				affinity := created.Spec.Affinity
+				if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+					t.Fatalf("affinity = %+v, want node-b required", affinity)
+				}
+				terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+				if len(terms) != 1 || len(terms[0].MatchFields) != 1 || !reflect.DeepEqual(terms[0].MatchFields[0].Values, []string{"node-b"}) {
+					t.Errorf("node selector terms = %+v, want node-b required", terms)
+				}
+				if warned {
+					t.Error("warned about bypassed constraints the scheduler honors")
+				}
+			} else {
+				if created.Spec.NodeName != "node-b" {
+					t.Errorf("nodeName = %q, want node-b", created.Spec.NodeName)
+				}
+				if !warned {
+					t.Error("no warning that the constraints are bypassed")
+				}
+			}
+		})
+	}
+}
+
+// force_restart starts the containers cold even when preserve_pv asks for a checkpoint
+func TestForceRestartSkipsCheckpoint(t *testing.T) {
+	for _, forceRestart := range []bool{false, true} {
+		t.Run(fmt.Sprintf("force_restart=%v", forceRestart), func(t *testing.T) {
+			mc, clientset := newTestController(t, fullMode, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+			req := testRequest("app", "node-a", "node-b")
+			req.SuccessCriteria = types.SuccessCriteriaPodCreated
+			req.ForceIgnorePDB = true
+			req.PreservePV = true
+			req.ForceRestart = forceRestart
+
+			plan, err := mc.PlanMigration(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.Checkpoint.Enabled == forceRestart {
+				t.Errorf("planned checkpoint = %+v, want enabled %v", plan.Checkpoint, !forceRestart)
+			}
+
+			response := runMigration(t, mc, req)
+			if response.Status != types.MigrationStatusCompleted {
+				t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+			}
+			claims, err := clientset.CoreV1().PersistentVolumeClaims(testNamespace).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			pod, err := clientset.CoreV1().Pods(testNamespace).Get(context.Background(), response.Details.NewPodName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			mounted := false
+			for _, volume := range pod.Spec.Volumes {
+				mounted = mounted || volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == response.Details.PVClaimName
+			}
+
+			if forceRestart {
+				if len(claims.Items) != 0 || response.Details.PVClaimName != "" {
+					t.Errorf("checkpoint claims %d, pv_claim_name %q; want none", len(claims.Items), response.Details.PVClaimName)
+				}
+				if !strings.Contains(response.Details.CheckpointSkippedReason, "force_restart") {
+					t.Errorf("checkpoint_skipped_reason = %q, want force_restart named", response.Details.CheckpointSkippedReason)
+				}
+				for _, volume := range pod.Spec.Volumes {
+					if volume.Name == "checkpoint-volume" {
+						t.Error("new pod mounts a checkpoint volume")
+					}
+				}
+			} else if len(claims.Items) != 1 || !mounted {
+				t.Errorf("checkpoint claims %d, mounted by the new pod %v; want one mounted", len(claims.Items), mounted)
+			}
+		})
+	}
+}
+
+// BenchmarkGetMetrics scrapes the metrics from parallel goroutines while migrations
+// keep running, and reports how many finished meanwhile: scraping must not slow them.
+func BenchmarkGetMetrics(b *testing.B) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	const workers = 8
+	objects := []runtime.Object{testNode("node-a"), testNode("node-b")}
+	for i := 0; i < workers; i++ {
+		objects = append(objects, testPod(fmt.Sprintf("app-%d", i), "node-a", "main", "worker"))
+	}
+	mc, _ := newTestController(b, func(config *MigrationConfig) {
+		config.MaxConcurrentMigrations = workers
+	}, objects...)
+
+	stop := make(chan struct{})
+	var finished atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(pod string) {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				req := testRequest(pod, "node-a", "node-b")
+				req.SuccessCriteria = types.SuccessCriteriaPodCreated
+				started, err := mc.StartMigration(req)
+				if err != nil {
+					b.Errorf("StartMigration: %v", err)
+					return
+				}
+				if _, _, err := mc.WaitForMigration(context.Background(), started.MigrationID); err != nil {
+					b.Errorf("WaitForMigration: %v", err)
+					return
+				}
+				finished.Add(1)
+			}
+		}(fmt.Sprintf("app-%d", i))
+	}
+
+	b.ResetTimer()
+	start := time.Now()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mc.GetMetrics()
+		}
+	})
+	elapsed := time.Since(start)
+	b.StopTimer()
+	close(stop)
+	wg.Wait()
+	b.ReportMetric(float64(finished.Load())/elapsed.Seconds(), "migrations/s")
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ErrNoMatchingNode is returned when no schedulable node matches a target node selector
+var ErrNoMatchingNode = errors.New("no schedulable node matches target_node_selector")
+
+// ValidateTargetNode checks that a request names exactly one of a target node or a
+// target node selector
+func ValidateTargetNode(req *types.MigrationRequest) error {
+	if req.TargetNode == "" && len(req.TargetNodeSelector) == 0 {
+		return fmt.Errorf("target_node or target_node_selector is required")
+	}
+	if req.TargetNode != "" && len(req.TargetNodeSelector) > 0 {
+		return fmt.Errorf("target_node and target_node_selector are mutually exclusive")
+	}
+	if len(req.TargetNodeSelector) > 0 {
+		if _, err := labels.ValidatedSelectorFromSet(req.TargetNodeSelector); err != nil {
+			return fmt.Errorf("invalid target_node_selector: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolveTargetNode picks the target node of a request given by target_node_selector:
+// the schedulable matching node, other than the source node, with the most free CPU
+// and memory. It returns a copy of the request naming that node and how the node was
+// picked; the caller's request is not modified. Requests naming a node are returned
+// as they are, with a nil selection.
+func (mc *MigrationController) resolveTargetNode(req *types.MigrationRequest) (*types.MigrationRequest, *types.NodeSelection, error) {
+	if req.TargetNode != "" || len(req.TargetNodeSelector) == 0 {
+		return req, nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	candidates, err := mc.pickTargetNode(ctx, req, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved := *req
+	resolved.TargetNode = candidates[0].Node
+	return &resolved, &types.NodeSelection{Selector: req.TargetNodeSelector, Node: resolved.TargetNode, Candidates: candidates}, nil
+}
+
+// pickTargetNode ranks the schedulable nodes matching the target node selector of a
+// request, other than the source node and the excluded nodes, by free CPU and
+// memory. The first is the one to use; an error is returned when there is none.
+func (mc *MigrationController) pickTargetNode(ctx context.Context, req *types.MigrationRequest, excluded map[string]bool) ([]types.NodeScore, error) {
+	selector := labels.SelectorFromSet(req.TargetNodeSelector)
+	nodes, err := mc.k8sClient.ListNodes(ctx, selector.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes for target_node_selector: %w", err)
+	}
+
+	var candidates []types.NodeScore
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Name == req.SourceNode || excluded[node.Name] || nodeSchedulable(node) != nil {
+			continue
+		}
+		candidates = append(candidates, types.NodeScore{Node: node.Name, Score: mc.nodeFreeCapacity(ctx, node)})
+	}
+	if len(candidates) == 0 && len(excluded) > 0 {
+		return nil, fmt.Errorf("%w %s (%d matching nodes, none schedulable besides the source node and the %d already tried)", ErrNoMatchingNode, selector, len(nodes), len(excluded))
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w %s (%d matching nodes, none schedulable besides the source node)", ErrNoMatchingNode, selector, len(nodes))
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Node < candidates[j].Node
+	})
+	return candidates, nil
+}
+
+// nodeFreeCapacity scores a node by the average fraction of its allocatable CPU and
+// memory not in use, or -1 when its usage cannot be measured
+func (mc *MigrationController) nodeFreeCapacity(ctx context.Context, node *corev1.Node) float64 {
+	usage, err := mc.metricsProvider.NodeMetrics(ctx, node.Name)
+	if err != nil {
+		return -1
+	}
+	cpu := node.Status.Allocatable[corev1.ResourceCPU]
+	memory := node.Status.Allocatable[corev1.ResourceMemory]
+	if cpu.MilliValue() == 0 || memory.Value() == 0 {
+		return -1
+	}
+
+	freeCPU := 1 - usage.CPUUsage/(float64(cpu.MilliValue())/1000.0)
+	freeMemory := 1 - float64(usage.MemoryUsage)/float64(memory.Value())
+	return (freeCPU + freeMemory) / 2
+}
@@ -0,0 +1,299 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRecommendationNotFound is returned for an unknown recommendation ID
+	ErrRecommendationNotFound = errors.New("recommendation not found")
+	// ErrRecommendationPromoted is returned when a recommendation already started a migration
+	ErrRecommendationPromoted = errors.New("recommendation already promoted")
+)
+
+// maxRecommendations bounds the recommendations kept in memory; the oldest go first
+const maxRecommendations = 200
+
+// runPressureObserver periodically scans the nodes for pressure and records the
+// migrations that would relieve it, without starting any
+func (mc *MigrationController) runPressureObserver() {
+	ticker := time.NewTicker(mc.config.observeInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if mc.acceptingWork() != nil {
+			return
+		}
+		mc.observeNodePressure()
+	}
+}
+
+// observeTarget is a node that may receive recommended pods, with the projected usage
+// of the pods recommended to it during the scan
+type observeTarget struct {
+	utilization       *types.NodeUtilization
+	allocatableCPU    float64
+	allocatableMemory int64
+	assignedCPU       float64
+	assignedMemory    int64
+}
+
+// projectedPercents returns the node's utilization with the assigned pods and usage
+// added, in percent of allocatable
+func (t *observeTarget) projectedPercents(usage *types.ResourceUsage) (cpu, memory float64) {
+	cpu, memory = t.utilization.CPUPercent, t.utilization.MemoryPercent
+	if t.allocatableCPU > 0 {
+		cpu += (t.assignedCPU + usage.CPUUsage) / t.allocatableCPU * 100
+	}
+	if t.allocatableMemory > 0 {
+		memory += float64(t.assignedMemory+usage.MemoryUsage) / float64(t.allocatableMemory) * 100
+	}
+	return cpu, memory
+}
+
+// observeNodePressure runs one scan. A node is under pressure when its CPU or memory
+// utilization reaches the observe thresholds; for each such node the pod using the
+// most of its resources is recommended to move to the schedulable node, not under
+// pressure itself, with the most headroom once the pods recommended to it before
+// are counted. A pod that would put every such node under pressure is not
+// recommended.
+func (mc *MigrationController) observeNodePressure() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	nodes, err := mc.k8sClient.ListNodes(ctx, "")
+	if err != nil {
+		log.Printf("Pressure observer: failed to list nodes: %v", err)
+		return
+	}
+
+	var pressured []*types.NodeUtilization
+	var targets []*observeTarget
+	for i := range nodes {
+		utilization, err := mc.nodeUtilization(ctx, nodes[i].Name)
+		if err != nil {
+			log.Printf("Pressure observer: %v", err)
+			continue
+		}
+		if utilization.CPUPercent >= mc.config.observeCPUThreshold || utilization.MemoryPercent >= mc.config.observeMemoryThreshold {
+			utilization.UnderPressure = true
+			pressured = append(pressured, utilization)
+		} else if nodeSchedulable(&nodes[i]) == nil {
+			allocatableCPU, allocatableMemory, err := mc.k8sClient.GetNodeAllocatable(ctx, nodes[i].Name)
+			if err != nil {
+				log.Printf("Pressure observer: failed to get capacity of node %s: %v", nodes[i].Name, err)
+				continue
+			}
+			targets = append(targets, &observeTarget{utilization: utilization, allocatableCPU: allocatableCPU, allocatableMemory: allocatableMemory})
+		}
+	}
+
+	now := time.Now()
+	var found []*types.Recommendation
+	for _, source := range pressured {
+		if len(targets) == 0 {
+			log.Printf("Pressure observer: node %s is under pressure but no node has headroom", source.Node)
+			break
+		}
+		recommendation, err := mc.recommendForNode(ctx, source)
+		if err != nil {
+			log.Printf("Pressure observer: %v", err)
+			continue
+		}
+		if recommendation == nil {
+			continue
+		}
+		target := mc.pickObserveTarget(targets, recommendation.ProjectedUsage)
+		if target == nil {
+			log.Printf("Pressure observer: node %s is under pressure but no node has headroom for %s/%s",
+				source.Node, recommendation.PodNamespace, recommendation.PodName)
+			continue
+		}
+		target.assignedCPU += recommendation.ProjectedUsage.CPUUsage
+		target.assignedMemory += recommendation.ProjectedUsage.MemoryUsage
+		recommendation.TargetNode = target.utilization.Node
+		recommendation.TargetUtilization = target.utilization
+		found = append(found, recommendation)
+	}
+
+	mc.recommendationsMux.Lock()
+	defer mc.recommendationsMux.Unlock()
+	mc.lastObserveScan = now
+	for _, recommendation := range found {
+		mc.recordRecommendationLocked(recommendation, now)
+	}
+}
+
+// pickObserveTarget returns the target with the most headroom left after taking
+// usage, nil when it would put every target at or above the observe thresholds
+func (mc *MigrationController) pickObserveTarget(targets []*observeTarget, usage *types.ResourceUsage) *observeTarget {
+	var best *observeTarget
+	bestLoad := 0.0
+	for _, target := range targets {
+		cpu, memory := target.projectedPercents(usage)
+		if cpu >= mc.config.observeCPUThreshold || memory >= mc.config.observeMemoryThreshold {
+			continue
+		}
+		if best == nil || cpu+memory < bestLoad {
+			best, bestLoad = target, cpu+memory
+		}
+	}
+	return best
+}
+
+// recommendForNode picks the pod on a pressured node that uses the largest share of
+// the node's CPU and memory; nil when no pod there can be migrated. The caller
+// picks its target.
+func (mc *MigrationController) recommendForNode(ctx context.Context, source *types.NodeUtilization) (*types.Recommendation, error) {
+	pods, err := mc.k8sClient.ListPodsOnNode(ctx, source.Node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", source.Node, err)
+	}
+
+	var best *types.Recommendation
+	bestShare := -1.0
+	for i := range pods {
+		pod := &pods[i]
+		if !drainable(pod) {
+			continue
+		}
+		usage, err := mc.metricsProvider.PodMetrics(ctx, pod.Namespace, pod.Name)
+		if err != nil {
+			continue
+		}
+		var share float64
+		if source.CPUUsage > 0 {
+			share += usage.CPUUsage / source.CPUUsage
+		}
+		if source.MemoryUsage > 0 {
+			share += float64(usage.MemoryUsage) / float64(source.MemoryUsage)
+		}
+		if share <= bestShare {
+			continue
+		}
+		bestShare = share
+		best = &types.Recommendation{
+			PodName:           pod.Name,
+			PodNamespace:      pod.Namespace,
+			SourceNode:        source.Node,
+			SourceUtilization: source,
+			PodUsage:          usage,
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	best.Reason = fmt.Sprintf("node %s at %.1f%% CPU and %.1f%% memory (thresholds %.0f%% and %.0f%%)",
+		source.Node, source.CPUPercent, source.MemoryPercent, mc.config.observeCPUThreshold, mc.config.observeMemoryThreshold)
+	best.ProjectedUsage = simulateOptimizedResources(best.PodUsage)
+	if best.PodUsage.CPUUsage > 0 {
+		best.ProjectedCPUSavings = (best.PodUsage.CPUUsage - best.ProjectedUsage.CPUUsage) / best.PodUsage.CPUUsage * 100
+	}
+	if best.PodUsage.MemoryUsage > 0 {
+		best.ProjectedMemorySavings = float64(best.PodUsage.MemoryUsage-best.ProjectedUsage.MemoryUsage) / float64(best.PodUsage.MemoryUsage) * 100
+	}
+	return best, nil
+}
+
+// recordRecommendationLocked refreshes the open recommendation for the same pod and
+// source node, or adds a new one; recommendationsMux must be held
+func (mc *MigrationController) recordRecommendationLocked(found *types.Recommendation, now time.Time) {
+	for _, existing := range mc.recommendations {
+		if existing.Status == types.RecommendationOpen && existing.PodNamespace == found.PodNamespace &&
+			existing.PodName == found.PodName && existing.SourceNode == found.SourceNode {
+			found.ID = existing.ID
+			found.Status = existing.Status
+			found.CreatedAt = existing.CreatedAt
+			found.LastSeenAt = now
+			*existing = *found
+			return
+		}
+	}
+
+	found.ID = fmt.Sprintf("rec-%s", uuid.New().String()[:8])
+	found.Status = types.RecommendationOpen
+	found.CreatedAt = now
+	found.LastSeenAt = now
+	mc.recommendations = append(mc.recommendations, found)
+	if len(mc.recommendations) > maxRecommendations {
+		mc.recommendations = mc.recommendations[len(mc.recommendations)-maxRecommendations:]
+	}
+	log.Printf("Pressure observer: would migrate %s/%s from %s to %s (%s)",
+		found.PodNamespace, found.PodName, found.SourceNode, found.TargetNode, found.Reason)
+}
+
+// ListRecommendations returns the recorded recommendations, oldest first
+func (mc *MigrationController) ListRecommendations() *types.RecommendationList {
+	mc.recommendationsMux.Lock()
+	defer mc.recommendationsMux.Unlock()
+
+	list := &types.RecommendationList{
+		Recommendations: make([]types.Recommendation, 0, len(mc.recommendations)),
+		ScanInterval:    mc.config.observeInterval,
+	}
+	for _, recommendation := range mc.recommendations {
+		list.Recommendations = append(list.Recommendations, *recommendation)
+	}
+	if !mc.lastObserveScan.IsZero() {
+		lastScan := mc.lastObserveScan
+		list.LastScan = &lastScan
+	}
+	return list
+}
+
+// PromoteRecommendation starts the migration a recommendation describes, once
+// validate accepts the request built from it and the options. A recommendation can
+// be promoted once; it stays promoted only if the migration starts.
+func (mc *MigrationController) PromoteRecommendation(id string, req *types.PromoteRecommendationRequest, requestID string, validate func(*types.MigrationRequest) error) (*types.MigrationResponse, error) {
+	mc.recommendationsMux.Lock()
+	var recommendation *types.Recommendation
+	for _, candidate := range mc.recommendations {
+		if candidate.ID == id {
+			recommendation = candidate
+			break
+		}
+	}
+	if recommendation == nil {
+		mc.recommendationsMux.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrRecommendationNotFound, id)
+	}
+	if recommendation.Status == types.RecommendationPromoted {
+		mc.recommendationsMux.Unlock()
+		return nil, fmt.Errorf("%w: %s started migration %s", ErrRecommendationPromoted, id, recommendation.MigrationID)
+	}
+	// Claimed before the lock is released so concurrent promotions cannot both start
+	recommendation.Status = types.RecommendationPromoted
+	migration := &types.MigrationRequest{
+		PodName:          recommendation.PodName,
+		PodNamespace:     recommendation.PodNamespace,
+		SourceNode:       recommendation.SourceNode,
+		TargetNode:       recommendation.TargetNode,
+		RequestID:        requestID,
+		MigrationOptions: req.MigrationOptions,
+	}
+	mc.recommendationsMux.Unlock()
+
+	err := validate(migration)
+	var response *types.MigrationResponse
+	if err == nil {
+		response, err = mc.StartMigration(migration)
+	}
+
+	mc.recommendationsMux.Lock()
+	defer mc.recommendationsMux.Unlock()
+	if err != nil {
+		recommendation.Status = types.RecommendationOpen
+		return nil, err
+	}
+	recommendation.MigrationID = response.MigrationID
+	return response, nil
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// stubMetrics reports fixed usage of nodes and pods
+type stubMetrics struct {
+	nodes map[string]types.ResourceUsage
+	pods  map[string]types.ResourceUsage // by namespace/name
+}
+
+func (s *stubMetrics) PodMetrics(_ context.Context, namespace, name string) (*types.ResourceUsage, error) {
+	usage, ok := s.pods[namespace+"/"+name]
+	if !ok {
+		return nil, fmt.Errorf("no metrics of pod %s/%s", namespace, name)
+	}
+	return &usage, nil
+}
+
+func (s *stubMetrics) ContainerMetrics(context.Context, string, string) (map[string]types.ResourceUsage, error) {
+	return nil, errors.New("no container metrics")
+}
+
+func (s *stubMetrics) NodeMetrics(_ context.Context, name string) (*types.ResourceUsage, error) {
+	usage, ok := s.nodes[name]
+	if !ok {
+		return nil, fmt.Errorf("no metrics of node %s", name)
+	}
+	return &usage, nil
+}
+
+// listPodsByNode makes the fake cluster honour the spec.nodeName field selector of
+// pod listings, as the API server does
+func listPodsByNode(clientset *fake.Clientset) {
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		list := action.(k8stesting.ListAction)
+		node, ok := list.GetListRestrictions().Fields.RequiresExactMatch("spec.nodeName")
+		if !ok {
+			return false, nil, nil
+		}
+		obj, err := clientset.Tracker().List(corev1.SchemeGroupVersion.WithResource("pods"), corev1.SchemeGroupVersion.WithKind("Pod"), list.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		pods := obj.(*corev1.PodList)
+		filtered := &corev1.PodList{}
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName == node {
+				filtered.Items = append(filtered.Items, pod)
+			}
+		}
+		return true, filtered, nil
+	})
+}
+
+// pressureCluster has two nodes under CPU pressure, each with one pod using half of
+// its CPU, and two nodes with room for one of those pods each
+func pressureCluster(t *testing.T) *MigrationController {
+	mc, clientset := newTestController(t, fullMode, testNode("hot-1"), testNode("hot-2"), testNode("cool-1"), testNode("cool-2"),
+		testPod("big-1", "hot-1", "main"), testPod("big-2", "hot-2", "main"))
+	listPodsByNode(clientset)
+	mc.metricsProvider = &stubMetrics{
+		// 8 cores and 32Gi allocatable each
+		nodes: map[string]types.ResourceUsage{
+			"hot-1":  {CPUUsage: 7.5, MemoryUsage: 1 << 30},
+			"hot-2":  {CPUUsage: 7.5, MemoryUsage: 1 << 30},
+			"cool-1": {CPUUsage: 4, MemoryUsage: 1 << 30},
+			"cool-2": {CPUUsage: 4.5, MemoryUsage: 1 << 30},
+		},
+		// Projected at 2 cores each: 25 points of a target's CPU
+		pods: map[string]types.ResourceUsage{
+			testNamespace + "/big-1": {CPUUsage: 4, MemoryUsage: 1 << 28},
+			testNamespace + "/big-2": {CPUUsage: 4, MemoryUsage: 1 << 28},
+		},
+	}
+	return mc
+}
+
+// The first pod fills the node with the most headroom up to 75% CPU; the second
+// would put it over the threshold and goes to the other node
+func TestObserverSpreadsRecommendationsOverTargets(t *testing.T) {
+	mc := pressureCluster(t)
+	mc.observeNodePressure()
+
+	targets := map[string]string{}
+	for _, recommendation := range mc.ListRecommendations().Recommendations {
+		targets[recommendation.PodName] = recommendation.TargetNode
+		if recommendation.TargetUtilization == nil || recommendation.TargetUtilization.Node != recommendation.TargetNode {
+			t.Errorf("%s: target utilization %+v, want that of %s", recommendation.PodName, recommendation.TargetUtilization, recommendation.TargetNode)
+		}
+	}
+	if len(targets) != 2 || targets["big-1"] == targets["big-2"] {
+		t.Fatalf("recommended targets = %v, want big-1 and big-2 on different nodes", targets)
+	}
+	if targets["big-1"] != "cool-1" || targets["big-2"] != "cool-2" {
+		t.Errorf("recommended targets = %v, want big-1 on cool-1 and big-2 on cool-2", targets)
+	}
+}
+
+// A recommendation is validated like any migration request before it starts, and
+// stays open when it is rejected
+func TestPromoteRecommendationValidates(t *testing.T) {
+	mc := pressureCluster(t)
+	mc.observeNodePressure()
+	recommendation := mc.ListRecommendations().Recommendations[0]
+
+	invalid := errors.New("new_pod_name must differ from pod_name")
+	var validated *types.MigrationRequest
+	_, err := mc.PromoteRecommendation(recommendation.ID, &types.PromoteRecommendationRequest{}, "", func(req *types.MigrationRequest) error {
+		validated = req
+		return invalid
+	})
+	if !errors.Is(err, invalid) {
+		t.Fatalf("PromoteRecommendation: %v, want the validation error", err)
+	}
+	if validated == nil || validated.PodName != recommendation.PodName || validated.TargetNode != recommendation.TargetNode {
+		t.Errorf("validated %+v, want the recommended migration", validated)
+	}
+	if status := mc.ListRecommendations().Recommendations[0].Status; status != types.RecommendationOpen {
+		t.Errorf("status = %s after a rejected promotion, want open", status)
+	}
+
+	options := &types.PromoteRecommendationRequest{MigrationOptions: types.MigrationOptions{Timeout: 60, SuccessCriteria: types.SuccessCriteriaPodCreated}}
+	response, err := mc.PromoteRecommendation(recommendation.ID, options, "", func(*types.MigrationRequest) error { return nil })
+	if err != nil {
+		t.Fatalf("PromoteRecommendation: %v", err)
+	}
+	mc.migrationsMux.RLock()
+	target := mc.migrations[response.MigrationID].Request.TargetNode
+	mc.migrationsMux.RUnlock()
+	if target != recommendation.TargetNode {
+		t.Errorf("migration %s targets %s, want the recommended %s", response.MigrationID, target, recommendation.TargetNode)
+	}
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateCommandOverrides statically checks the command, args and resource
+// overrides of a request; whether the containers exist is checked against the pod
+func ValidateCommandOverrides(req *types.MigrationRequest) error {
+	for field, overrides := range map[string]map[string][]string{
+		"command_overrides": req.CommandOverrides,
+		"args_overrides":    req.ArgsOverrides,
+	} {
+		for container := range overrides {
+			if errs := validation.IsDNS1123Label(container); len(errs) > 0 {
+				return fmt.Errorf("%s: invalid container name %q: %s", field, container, strings.Join(errs, "; "))
+			}
+		}
+	}
+	for container := range req.ResourceOverrides {
+		if errs := validation.IsDNS1123Label(container); len(errs) > 0 {
+			return fmt.Errorf("resource_overrides: invalid container name %q: %s", container, strings.Join(errs, "; "))
+		}
+	}
+	if err := ValidateResourceOverrides("resource_overrides", req.ResourceOverrides); err != nil {
+		return err
+	}
+	for container, command := range req.CommandOverrides {
+		if len(command) == 0 {
+			return fmt.Errorf("command_overrides: command of container %s must not be empty", container)
+		}
+	}
+	return nil
+}
+
+// checkContainerOverrides verifies that every overridden container is a container of
+// the pod that will be migrated
+func checkContainerOverrides(pod *corev1.Pod, states []types.ContainerState, req *types.MigrationRequest) error {
+	migrated := make(map[string]bool, len(states))
+	for _, state := range states {
+		migrated[state.Name] = state.ShouldMigrate
+	}
+	for _, container := range overriddenContainers(req) {
+		if !hasContainer(pod, container) {
+			return fmt.Errorf("container override: pod %s/%s has no container %q", pod.Namespace, pod.Name, container)
+		}
+		if !migrated[container] {
+			return fmt.Errorf("container override: container %s is not migrated", container)
+		}
+	}
+	return nil
+}
+
+// applyContainerOverrides replaces the command, args and resources of the overridden
+// containers of a pod and records what was replaced; other containers keep theirs
+func applyContainerOverrides(pod *corev1.Pod, req *types.MigrationRequest) []types.ContainerOverride {
+	var applied []types.ContainerOverride
+	for _, name := range overriddenContainers(req) {
+		for i := range pod.Spec.Containers {
+			container := &pod.Spec.Containers[i]
+			if container.Name != name {
+				continue
+			}
+			record := types.ContainerOverride{
+				Container:       name,
+				OriginalCommand: container.Command,
+				OriginalArgs:    container.Args,
+			}
+			if command, ok := req.CommandOverrides[name]; ok {
+				container.Command = append([]string{}, command...)
+				record.Command = container.Command
+			}
+			if args, ok := req.ArgsOverrides[name]; ok {
+				container.Args = append([]string{}, args...)
+				record.Args = container.Args
+			}
+			if override, ok := req.ResourceOverrides[name]; ok {
+				record.OriginalResources = overriddenResources(container, override)
+				applyResourceOverride(container, override)
+				record.Resources = &override
+			}
+			applied = append(applied, record)
+		}
+	}
+	return applied
+}
+
+// overriddenContainers returns the names of the containers with an override, sorted
+func overriddenContainers(req *types.MigrationRequest) []string {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, overrides := range []map[string][]string{req.CommandOverrides, req.ArgsOverrides} {
+		for name := range overrides {
+			add(name)
+		}
+	}
+	for name := range req.ResourceOverrides {
+		add(name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyResourceOverride sets the requests and limits of an override on a container;
+// the quantities have been validated by ValidateResourceOverrides
+func applyResourceOverride(container *corev1.Container, override types.ResourceOverride) {
+	set := func(list *corev1.ResourceList, values map[string]string) {
+		if len(values) == 0 {
+			return
+		}
+		updated := corev1.ResourceList{}
+		for name, quantity := range *list {
+			updated[name] = quantity
+		}
+		for name, value := range values {
+			updated[corev1.ResourceName(name)] = resource.MustParse(value)
+		}
+		*list = updated
+	}
+	set(&container.Resources.Requests, override.Requests)
+	set(&container.Resources.Limits, override.Limits)
+}
+
+// applyResourceOverrides applies the overrides to the named containers of a pod
+func applyResourceOverrides(pod *corev1.Pod, overrides map[string]types.ResourceOverride) {
+	for i := range pod.Spec.Containers {
+		if override, ok := overrides[pod.Spec.Containers[i].Name]; ok {
+			applyResourceOverride(&pod.Spec.Containers[i], override)
+		}
+	}
+}
+
+// overriddenResources returns the amounts a container had of what an override sets
+func overriddenResources(container *corev1.Container, override types.ResourceOverride) *types.ResourceOverride {
+	original := &types.ResourceOverride{}
+	read := func(list corev1.ResourceList, values map[string]string) map[string]string {
+		var amounts map[string]string
+		for name := range values {
+			if quantity, ok := list[corev1.ResourceName(name)]; ok {
+				if amounts == nil {
+					amounts = make(map[string]string)
+				}
+				amounts[name] = quantity.String()
+			}
+		}
+		return amounts
+	}
+	original.Requests = read(container.Resources.Requests, override.Requests)
+	original.Limits = read(container.Resources.Limits, override.Limits)
+	return original
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PlanMigration plans a migration request without starting it: the containers it
+// keeps and drops, its target node, checkpoint, cutover and readiness criteria, and
+// the projected savings. Nothing in the cluster is changed.
+func (mc *MigrationController) PlanMigration(ctx context.Context, req *types.MigrationRequest) (*types.MigrationPlan, error) {
+	req, selection, err := mc.resolveTargetNode(req)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := mc.k8sClient.GetPod(ctx, req.PodNamespace, req.PodName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	plan, err := mc.planMigration(ctx, req, pod, mc.classificationPolicyFor(req))
+	if err != nil {
+		return nil, err
+	}
+	plan.TargetNodeSelection = selection
+	return plan, nil
+}
+
+// planMigration decides what migrating a pod will do. It is the capture step of
+// every migration, whose later steps follow the plan, and the whole of a dry run.
+func (mc *MigrationController) planMigration(ctx context.Context, req *types.MigrationRequest, pod *corev1.Pod, policy types.ClassificationPolicy) (*types.MigrationPlan, error) {
+	states, err := mc.k8sClient.GetPodContainerStates(ctx, pod, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze container states: %w", err)
+	}
+
+	plan := &types.MigrationPlan{
+		PodName:      req.PodName,
+		PodNamespace: req.PodNamespace,
+		SourceNode:   req.SourceNode,
+		TargetNode:   req.TargetNode,
+		PlannedAt:    time.Now(),
+	}
+	plan.PodSpecHash, err = podSpecHash(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop crash-looping containers if configured, before grouping can bring them back
+	plan.RestartDecisions = mc.applyRestartSkipPolicy(states)
+	// Keep tightly coupled containers together
+	if len(req.ContainerGrouping) > 0 {
+		plan.GroupingDecisions = applyContainerGrouping(states, req.ContainerGrouping)
+	}
+	plan.Containers = states
+	for _, state := range states {
+		if state.ShouldMigrate {
+			plan.ContainersKept++
+		}
+	}
+	plan.LifecycleHooks = lifecycleHooks(pod, states)
+
+	if usage, err := mc.metricsProvider.PodMetrics(ctx, req.PodNamespace, req.PodName); err == nil {
+		plan.CurrentUsage = usage
+		plan.ProjectedUsage = projectUsage(usage, pod, states, req.ResourceOverrides)
+		if usage.CPUUsage > 0 {
+			plan.ProjectedCPUSavings = (usage.CPUUsage - plan.ProjectedUsage.CPUUsage) / usage.CPUUsage * 100
+		}
+		if usage.MemoryUsage > 0 {
+			plan.ProjectedMemorySavings = float64(usage.MemoryUsage-plan.ProjectedUsage.MemoryUsage) / float64(usage.MemoryUsage) * 100
+		}
+	}
+	// Without per-container usage the gaps still carry the requests
+	containerUsage, _ := mc.metricsProvider.ContainerMetrics(ctx, req.PodNamespace, req.PodName)
+	plan.ResourceGaps = resourceGaps(pod, states, containerUsage)
+
+	// The checkpoint helpers work on a job; this one only carries the request and usage
+	job := &MigrationJob{Request: req, Details: &types.MigrationDetails{OriginalResources: plan.CurrentUsage}}
+	switch {
+	case !req.PreservePV:
+		plan.Checkpoint.SkippedReason = "preserve_pv not requested"
+	case req.ForceRestart:
+		plan.Checkpoint.SkippedReason = "force_restart requested: containers start without restoring a checkpoint"
+	default:
+		size, basis, err := mc.checkpointSize(job)
+		if err != nil {
+			return nil, err
+		}
+		plan.Checkpoint = types.PlannedCheckpoint{
+			Enabled:    true,
+			Size:       size.String(),
+			SizeBasis:  basis,
+			AccessMode: string(mc.checkpointAccessMode(job)),
+		}
+	}
+
+	claims, err := mc.k8sClient.SingleNodeClaims(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	plan.Cutover = types.PlannedCutover{
+		RetainOriginal:           mc.config.safeMode,
+		DeleteOriginalFirst:      len(claims) > 0 && !mc.config.safeMode,
+		SingleNodeClaims:         claims,
+		RespectDisruptionBudgets: !req.ForceIgnorePDB && !mc.config.safeMode,
+		Placement:                effectivePlacement(req),
+		WrapInDeployment:         req.WrapInDeployment,
+		HonorPreStopHooks:        hasPreStopHook(plan.LifecycleHooks) && !mc.config.safeMode,
+	}
+
+	plan.Readiness = types.PlannedReadiness{
+		SuccessCriteria:           successCriteria(req),
+		Conditions:                req.ReadinessConditions,
+		TolerateUnreadyContainers: req.TolerateUnreadyContainers,
+		Timeout:                   mc.config.podReadyTimeout,
+		VerifyCommand:             req.VerifyCommand,
+	}
+	if hasPostStartHook(plan.LifecycleHooks) {
+		plan.Readiness.PostStartAllowance = mc.config.postStartHookAllowance
+		plan.Readiness.Timeout += mc.config.postStartHookAllowance
+	}
+	if len(plan.Readiness.Conditions) == 0 {
+		plan.Readiness.Conditions = []string{string(corev1.PodReady)}
+	}
+	if plan.Readiness.SuccessCriteria == types.SuccessCriteriaStabilityWindow {
+		plan.Readiness.StabilityWindow = stabilityWindow(req)
+	}
+	return plan, nil
+}
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// withSidecar adds an istio proxy to a pod
+func withSidecar(pod *corev1.Pod) *corev1.Pod {
+	extra := testPod(pod.Name, pod.Spec.NodeName, "istio-proxy")
+	extra.Spec.Containers[0].Image = "docker.io/istio/proxyv2:1.20.0"
+	pod.Spec.Containers = append(pod.Spec.Containers, extra.Spec.Containers...)
+	pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, extra.Status.ContainerStatuses...)
+	return pod
+}
+
+// withCompleted marks a container of a pod that never restarts as exited with 0
+func withCompleted(pod *corev1.Pod, name string) *corev1.Pod {
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == name {
+			pod.Status.ContainerStatuses[i].Ready = false
+			pod.Status.ContainerStatuses[i].State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}
+		}
+	}
+	return pod
+}
+
+// keptContainers lists the containers a plan keeps, in pod order
+func keptContainers(plan *types.MigrationPlan) []string {
+	var kept []string
+	for _, state := range plan.Containers {
+		if state.ShouldMigrate {
+			kept = append(kept, state.Name)
+		}
+	}
+	return kept
+}
+
+func TestPlanMigrationPodShapes(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		pod       *corev1.Pod
+		objects   []runtime.Object
+		configure func(*MigrationConfig)
+		request   func(*types.MigrationRequest)
+		kept      []string
+		check     func(*testing.T, *types.MigrationPlan)
+	}{
+		{
+			name: "single container",
+			pod:  testPod("app", "node-a", "main"),
+			kept: []string{"main"},
+			check: func(t *testing.T, plan *types.MigrationPlan) {
+				if plan.Checkpoint.Enabled || plan.Checkpoint.SkippedReason == "" {
+					t.Errorf("checkpoint = %+v, want skipped without preserve_pv", plan.Checkpoint)
+				}
+				if !plan.Cutover.RetainOriginal || plan.Cutover.DeleteOriginalFirst {
+					t.Errorf("cutover = %+v, want the original kept in safe mode", plan.Cutover)
+				}
+				if !reflect.DeepEqual(plan.Readiness.Conditions, []string{"Ready"}) {
+					t.Errorf("readiness conditions = %v, want [Ready]", plan.Readiness.Conditions)
+				}
+				if plan.PodSpecHash == "" {
+					t.Error("no pod spec hash")
+				}
+			},
+		},
+		{
+			name: "sidecar",
+			pod:  withSidecar(testPod("app", "node-a", "main")),
+			kept: []string{"main"},
+		},
+		{
+			name:    "sidecar included",
+			pod:     withSidecar(testPod("app", "node-a", "main")),
+			request: func(req *types.MigrationRequest) { req.IncludeSidecars = true },
+			kept:    []string{"main", "istio-proxy"},
+		},
+		{
+			name: "completed container",
+			pod:  withCompleted(testPod("app", "node-a", "main", "migrate"), "migrate"),
+			kept: []string{"main"},
+		},
+		{
+			name:    "completed container grouped with a running one",
+			pod:     withCompleted(testPod("app", "node-a", "main", "migrate"), "migrate"),
+			request: func(req *types.MigrationRequest) { req.ContainerGrouping = [][]string{{"main", "migrate"}} },
+			kept:    []string{"main", "migrate"},
+			check: func(t *testing.T, plan *types.MigrationPlan) {
+				if len(plan.GroupingDecisions) != 1 {
+					t.Errorf("grouping decisions = %+v, want one", plan.GroupingDecisions)
+				}
+			},
+		},
+		{
+			name:      "ReadWriteOnce volume",
+			pod:       rwoPod(),
+			objects:   []runtime.Object{testClaim("db-data", corev1.ReadWriteOnce)},
+			configure: fullMode,
+			kept:      []string{"postgres"},
+			check: func(t *testing.T, plan *types.MigrationPlan) {
+				cutover := plan.Cutover
+				if !cutover.DeleteOriginalFirst || !reflect.DeepEqual(cutover.SingleNodeClaims, []string{"db-data"}) {
+					t.Errorf("cutover = %+v, want the original deleted first to free db-data", cutover)
+				}
+				if !cutover.RespectDisruptionBudgets {
+					t.Error("disruption budgets not respected outside safe mode")
+				}
+			},
+		},
+		{
+			name:    "checkpoint",
+			pod:     testPod("app", "node-a", "main"),
+			request: func(req *types.MigrationRequest) { req.PreservePV = true },
+			kept:    []string{"main"},
+			check: func(t *testing.T, plan *types.MigrationPlan) {
+				if !plan.Checkpoint.Enabled || plan.Checkpoint.Size == "" || plan.Checkpoint.AccessMode == "" {
+					t.Errorf("checkpoint = %+v, want one sized with an access mode", plan.Checkpoint)
+				}
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			objects := append([]runtime.Object{testNode("node-a"), testNode("node-b"), test.pod}, test.objects...)
+			mc, _ := newTestController(t, test.configure, objects...)
+			req := testRequest(test.pod.Name, "node-a", "node-b")
+			if test.request != nil {
+				test.request(req)
+			}
+
+			plan, err := mc.PlanMigration(context.Background(), req)
+			if err != nil {
+				t.Fatalf("PlanMigration: %v", err)
+			}
+			if kept := keptContainers(plan); !reflect.DeepEqual(kept, test.kept) || plan.ContainersKept != len(test.kept) {
+				t.Errorf("kept %v (%d), want %v", kept, plan.ContainersKept, test.kept)
+			}
+			if plan.TargetNode != "node-b" {
+				t.Errorf("target node = %q, want node-b", plan.TargetNode)
+			}
+			if test.check != nil {
+				test.check(t, plan)
+			}
+		})
+	}
+}
+
+// Preflight and the resource preview keep the containers the plan keeps
+func TestPlanSharedByPreflightAndPreview(t *testing.T) {
+	pod := withCompleted(testPod("app", "node-a", "main", "migrate"), "migrate")
+	mc, _ := newTestController(t, nil, testNode("node-a"), testNode("node-b"), withSidecar(pod))
+	req := testRequest("app", "node-a", "node-b")
+	req.ContainerGrouping = [][]string{{"main", "migrate"}}
+
+	plan, err := mc.PlanMigration(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := mc.ValidateMigration(context.Background(), req)
+	for _, check := range report.Checks {
+		if check.Name == preflightContainers && (!check.Passed || check.Message != "2/3 containers would be migrated") {
+			t.Errorf("preflight containers check = %+v, want the plan's 2/3", check)
+		}
+	}
+	if kept := keptContainers(plan); !reflect.DeepEqual(kept, []string{"main", "migrate"}) {
+		t.Errorf("plan kept %v, want [main migrate]", kept)
+	}
+
+	// The preview has no grouping to apply: the completed container is dropped
+	preview, err := mc.PreviewResources(context.Background(), testNamespace, "app", &types.ResourcePreviewRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrated := map[string]bool{}
+	for _, container := range preview.Containers {
+		migrated[container.Name] = container.Migrated
+	}
+	if want := map[string]bool{"main": true, "migrate": false, "istio-proxy": false}; !reflect.DeepEqual(migrated, want) {
+		t.Errorf("preview migrated = %v, want %v", migrated, want)
+	}
+}
+
+// A request giving target_node_selector is planned and started against the picked
+// node without the caller's request being changed
+func TestTargetNodeSelectorLeavesRequestUnchanged(t *testing.T) {
+	gpu := func(node *corev1.Node) *corev1.Node {
+		node.Labels["pool"] = "gpu"
+		return node
+	}
+	mc, _ := newTestController(t, fullMode, gpu(testNode("node-a")), gpu(testNode("node-b")), testNode("node-c"),
+		testPod("app", "node-a", "main"))
+	req := testRequest("app", "node-a", "")
+	req.TargetNodeSelector = map[string]string{"pool": "gpu"}
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ForceIgnorePDB = true
+
+	plan, err := mc.PlanMigration(context.Background(), req)
+	if err != nil {
+		t.Fatalf("PlanMigration: %v", err)
+	}
+	if plan.TargetNode != "node-b" || plan.TargetNodeSelection == nil || plan.TargetNodeSelection.Node != "node-b" {
+		t.Errorf("planned target %q (selection %+v), want node-b", plan.TargetNode, plan.TargetNodeSelection)
+	}
+	if report := mc.ValidateMigration(context.Background(), req); len(report.Checks) == 0 {
+		t.Error("ValidateMigration ran no checks")
+	}
+	if req.TargetNode != "" {
+		t.Errorf("target_node of the request set to %q by planning", req.TargetNode)
+	}
+
+	response, err := mc.StartMigration(req)
+	if err != nil {
+		t.Fatalf("StartMigration: %v", err)
+	}
+	if req.TargetNode != "" {
+		t.Errorf("target_node of the request set to %q by starting it", req.TargetNode)
+	}
+	mc.migrationsMux.RLock()
+	target := mc.migrations[response.MigrationID].Request.TargetNode
+	mc.migrationsMux.RUnlock()
+	if target != "node-b" {
+		t.Errorf("migration targets %q, want node-b", target)
+	}
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ErrPodNameInUse is returned when an explicitly requested new pod name is taken
+var ErrPodNameInUse = errors.New("pod name already in use")
+
+// DefaultPodNameTemplate names new pods when neither a name nor a template is given
+const DefaultPodNameTemplate = "{original}-migrated-{timestamp}"
+
+// podNamePlaceholder matches the {placeholders} of a pod name template
+var podNamePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// podNamePlaceholders are the placeholders a template may use, with the values used
+// to check that a template renders to a valid name
+var podNamePlaceholders = map[string]string{
+	"original":   "pod",
+	"namespace":  "default",
+	"sourcenode": "node-a",
+	"targetnode": "node-b",
+	"shortid":    "0a1b2c3d",
+	"timestamp":  "1700000000",
+}
+
+// ValidatePodNameTemplate checks that a pod name template only uses known
+// placeholders and renders to a DNS-1123 name
+func ValidatePodNameTemplate(template string) error {
+	name, err := renderPodName(template, podNamePlaceholders)
+	if err != nil {
+		return err
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("template %q renders invalid pod names such as %q: %s", template, name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// renderPodName replaces the placeholders of a template with their values
+func renderPodName(template string, values map[string]string) (string, error) {
+	var unknown []string
+	name := podNamePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := values[strings.Trim(placeholder, "{}")]
+		if !ok {
+			unknown = append(unknown, placeholder)
+		}
+		return value
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("template %q has unknown placeholders %s: use {original}, {namespace}, {sourcenode}, {targetnode}, {shortid} or {timestamp}",
+			template, strings.Join(unknown, ", "))
+	}
+	return name, nil
+}
+
+// newPodName names the new pod, or the Deployment wrapping it: the request's
+// new_pod_name as is, otherwise its new_pod_name_template or the configured one
+// rendered for this migration, which must be a free DNS-1123 name
+func (mc *MigrationController) newPodName(ctx context.Context, job *MigrationJob) (string, error) {
+	if job.Request.NewPodName != "" {
+		return job.Request.NewPodName, nil
+	}
+
+	template := job.Request.NewPodNameTemplate
+	if template == "" {
+		template = mc.config.podNameTemplate
+	}
+	name, err := renderPodName(template, map[string]string{
+		"original":   job.Request.PodName,
+		"namespace":  job.Request.PodNamespace,
+		"sourcenode": job.Request.SourceNode,
+		"targetnode": job.Request.TargetNode,
+		"shortid":    strings.TrimPrefix(job.ID, "migration-"),
+		"timestamp":  strconv.FormatInt(time.Now().Unix(), 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("pod name template %q rendered invalid name %q: %s", template, name, strings.Join(errs, "; "))
+	}
+	if name == job.Request.PodName {
+		return "", fmt.Errorf("pod name template %q rendered the original pod's name", template)
+	}
+	if err := mc.checkPodNameFree(ctx, job.Request.PodNamespace, name, job); err != nil {
+		return "", err
+	}
+
+	// Another migration may have rendered the same name since it was checked
+	mc.migrationsMux.Lock()
+	defer mc.migrationsMux.Unlock()
+	if err := mc.podNameReservedLocked(job.Request.PodNamespace, name, job); err != nil {
+		return "", err
+	}
+	job.reservedPodName = name
+	job.Details.NewPodNameTemplate = template
+	return name, nil
+}
+
+// checkNewPodName verifies that an explicit new pod name is neither an existing pod
+// nor claimed by another unfinished migration in the same namespace. The name is
+// only reserved once launchMigration registers the migration.
+func (mc *MigrationController) checkNewPodName(req *types.MigrationRequest) error {
+	if req.NewPodName == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return mc.checkPodNameFree(ctx, req.PodNamespace, req.NewPodName, nil)
+}
+
+// checkPodNameFree verifies that name is neither an existing pod nor claimed by an
+// unfinished migration other than self in namespace
+func (mc *MigrationController) checkPodNameFree(ctx context.Context, namespace, name string, self *MigrationJob) error {
+	mc.migrationsMux.RLock()
+	err := mc.podNameReservedLocked(namespace, name, self)
+	mc.migrationsMux.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	_, err = mc.k8sClient.GetPod(ctx, namespace, name)
+	if err == nil {
+		return fmt.Errorf("%w: pod %s/%s exists", ErrPodNameInUse, namespace, name)
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to check new pod name: %w", err)
+	}
+	return nil
+}
+
+// podNameReservedLocked fails if an unfinished migration other than self in namespace
+// was asked for the pod name, rendered it or created a pod by it. migrationsMux must
+// be held.
+func (mc *MigrationController) podNameReservedLocked(namespace, name string, self *MigrationJob) error {
+	for _, job := range mc.migrations {
+		if job == self || job.Request.PodNamespace != namespace {
+			continue
+		}
+		if job.Request.NewPodName != name && job.reservedPodName != name && job.Details.NewPodName != name {
+			continue
+		}
+		select {
+		case <-job.done:
+		default:
+			return fmt.Errorf("%w: %s/%s is reserved by migration %s", ErrPodNameInUse, namespace, name, job.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Requests checked at the same time cannot both take a new pod name: it is reserved
+// as the first migration is registered
+func TestNewPodNameReservedAtLaunch(t *testing.T) {
+	objects := []runtime.Object{testNode("node-a"), testNode("node-b")}
+	for i := 0; i < 8; i++ {
+		objects = append(objects, testPod(fmt.Sprintf("app-%d", i), "node-a", "main"))
+	}
+	mc, _ := newTestController(t, fullMode, objects...)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := testRequest(fmt.Sprintf("app-%d", i), "node-a", "node-b")
+			req.SuccessCriteria = types.SuccessCriteriaPodCreated
+			req.ForceIgnorePDB = true
+			req.NewPodName = "app-fixed"
+			_, errs[i] = mc.StartMigration(req)
+		}(i)
+	}
+	wg.Wait()
+
+	started := 0
+	for i, err := range errs {
+		if err == nil {
+			started++
+		} else if !errors.Is(err, ErrPodNameInUse) {
+			t.Errorf("app-%d: %v, want %v", i, err, ErrPodNameInUse)
+		}
+	}
+	if started != 1 {
+		t.Errorf("%d migrations started with new_pod_name app-fixed, want 1", started)
+	}
+}
+
+func TestLaunchMigrationRejectsReservedName(t *testing.T) {
+	mc, _ := newTestController(t, nil)
+	held := testJob()
+	held.Request.NewPodName = "app-fixed"
+	other := testJob()
+	other.ID = "migration-other"
+	other.reservedPodName = "db-migrated-1700000000"
+	mc.migrationsMux.Lock()
+	mc.trackMigrationLocked(held)
+	mc.trackMigrationLocked(other)
+	mc.migrationsMux.Unlock()
+
+	for _, name := range []string{"app-fixed", "db-migrated-1700000000"} {
+		req := testRequest("db", "node-a", "node-b")
+		req.NewPodName = name
+		if _, err := mc.launchMigration(req, nil, nil); !errors.Is(err, ErrPodNameInUse) {
+			t.Errorf("launch with new_pod_name %s: %v, want %v", name, err, ErrPodNameInUse)
+		}
+	}
+	mc.migrationsMux.RLock()
+	count := len(mc.migrations)
+	mc.migrationsMux.RUnlock()
+	if count != 2 {
+		t.Errorf("%d migrations registered, want only the 2 holding the names", count)
+	}
+
+	// A finished migration no longer holds its name
+	close(held.done)
+	mc.migrationsMux.RLock()
+	err := mc.podNameReservedLocked(testNamespace, "app-fixed", nil)
+	mc.migrationsMux.RUnlock()
+	if err != nil {
+		t.Errorf("name of a finished migration: %v, want it free", err)
+	}
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// What happens when the policy service gives no decision
+const (
+	// PolicyFailClosed refuses the migration
+	PolicyFailClosed = "closed"
+	// PolicyFailOpen lets the migration run
+	PolicyFailOpen = "open"
+)
+
+var (
+	// ErrPolicyDenied is returned when the policy service denies a migration
+	ErrPolicyDenied = errors.New("migration denied by policy")
+	// ErrPolicyUnavailable is returned when failing closed without a policy decision
+	ErrPolicyUnavailable = errors.New("policy check failed")
+)
+
+// validatePolicyFailureMode checks a policy failure mode name
+func validatePolicyFailureMode(mode string) error {
+	switch mode {
+	case PolicyFailClosed, PolicyFailOpen:
+		return nil
+	}
+	return fmt.Errorf("policy failure mode must be %s or %s, got %q", PolicyFailClosed, PolicyFailOpen, mode)
+}
+
+// checkPolicy plans a migration and asks the policy service whether it may run,
+// before it is started. It returns nil without a policy service, and an error
+// wrapping ErrPolicyDenied or ErrPolicyUnavailable when the migration must not run.
+func (mc *MigrationController) checkPolicy(req *types.MigrationRequest, selection *types.NodeSelection) (*types.PolicyDecision, error) {
+	if mc.policy == nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pod, err := mc.k8sClient.GetPod(ctx, req.PodNamespace, req.PodName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+	plan, err := mc.planMigration(ctx, req, pod, mc.classificationPolicyFor(req))
+	if err != nil {
+		return nil, err
+	}
+	plan.TargetNodeSelection = selection
+	return mc.decidePolicy(ctx, req, plan)
+}
+
+// decidePolicy asks the policy service whether a migration may run as planned,
+// returning errors as checkPolicy does
+func (mc *MigrationController) decidePolicy(ctx context.Context, req *types.MigrationRequest, plan *types.MigrationPlan) (*types.PolicyDecision, error) {
+	allowed, reason, err := mc.policy.Decide(ctx, &types.PolicyInput{Request: req, Plan: plan})
+	decision := &types.PolicyDecision{
+		Allowed:     allowed,
+		Reason:      reason,
+		Endpoint:    mc.policy.Endpoint(),
+		DecidedAt:   time.Now(),
+		PodSpecHash: plan.PodSpecHash,
+	}
+	if err != nil {
+		decision.Error = err.Error()
+		if mc.config.policyFailureMode != PolicyFailOpen {
+			return decision, fmt.Errorf("%w: %v", ErrPolicyUnavailable, err)
+		}
+		decision.Allowed = true
+		decision.FailedOpen = true
+		log.Printf("Policy check of %s/%s failed, allowing it (failing open): %v", req.PodNamespace, req.PodName, err)
+		return decision, nil
+	}
+	if !allowed {
+		if reason == "" {
+			reason = "no reason given"
+		}
+		return decision, fmt.Errorf("%w: %s", ErrPolicyDenied, reason)
+	}
+	return decision, nil
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// policyServer answers every policy request with body
+func policyServer(t *testing.T, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStartMigrationPolicyGate(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	for _, test := range []struct {
+		name        string
+		endpoint    string
+		failureMode string
+		err         error  // nil when the migration starts
+		reason      string // in the error or the recorded decision
+		failedOpen  bool
+	}{
+		{name: "allow", endpoint: policyServer(t, `{"result": {"allow": true, "reason": "ok"}}`).URL, reason: "ok"},
+		{name: "deny", endpoint: policyServer(t, `{"result": {"allow": false, "reason": "frozen namespace"}}`).URL, err: ErrPolicyDenied, reason: "frozen namespace"},
+		{name: "deny without reason", endpoint: policyServer(t, `{"result": {"allow": false}}`).URL, err: ErrPolicyDenied, reason: "no reason given"},
+		{name: "no result fails closed", endpoint: policyServer(t, `{}`).URL, err: ErrPolicyUnavailable},
+		{name: "server down fails closed", endpoint: down.URL, err: ErrPolicyUnavailable},
+		{name: "server down fails open", endpoint: down.URL, failureMode: PolicyFailOpen, failedOpen: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			mc, _ := newTestController(t, func(config *MigrationConfig) {
+				fullMode(config)
+				config.PolicyEndpoint = test.endpoint
+				if test.failureMode != "" {
+					config.PolicyFailureMode = test.failureMode
+				}
+			}, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+			defer mc.MarkStopped()
+			req := testRequest("app", "node-a", "node-b")
+			req.SuccessCriteria = types.SuccessCriteriaPodCreated
+
+			response, err := mc.StartMigration(req)
+			mc.migrationsMux.RLock()
+			registered := len(mc.migrations)
+			mc.migrationsMux.RUnlock()
+
+			if test.err != nil {
+				if !errors.Is(err, test.err) || !strings.Contains(err.Error(), test.reason) {
+					t.Fatalf("StartMigration: %v, want %v with %q", err, test.err, test.reason)
+				}
+				if registered != 0 {
+					t.Errorf("%d migrations launched, want none", registered)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("StartMigration: %v", err)
+			}
+			if registered != 1 {
+				t.Errorf("%d migrations launched, want 1", registered)
+			}
+			decision := response.Details.PolicyDecision
+			if decision == nil || !decision.Allowed || decision.Reason != test.reason || decision.FailedOpen != test.failedOpen {
+				t.Errorf("policy decision = %+v, want allowed with reason %q, failed open %v", decision, test.reason, test.failedOpen)
+			}
+			if test.failedOpen && decision.Error == "" {
+				t.Error("decision of a failed-open check records no error")
+			}
+		})
+	}
+}
+
+// Children are judged by the policy as they start: db may only migrate once app
+// did, which a check at submission would have denied. A denied child is skipped
+// without stopping the others.
+func TestBatchChildPolicyCheckedAtStart(t *testing.T) {
+	var mc *MigrationController
+	appMigrated := func() bool {
+		mc.migrationsMux.RLock()
+		defer mc.migrationsMux.RUnlock()
+		for _, job := range mc.migrations {
+			if job.Request.PodName == "app" && job.Status.Succeeded() {
+				return true
+			}
+		}
+		return false
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input types.PolicyInput `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode policy input: %v", err)
+		}
+		switch {
+		case body.Input.Request.PodName == "cache":
+			w.Write([]byte(`{"result": {"allow": false, "reason": "caches are rebuilt, not migrated"}}`))
+		case body.Input.Request.PodName == "db" && !appMigrated():
+			w.Write([]byte(`{"result": {"allow": false, "reason": "app first"}}`))
+		default:
+			w.Write([]byte(`{"result": {"allow": true}}`))
+		}
+	}))
+	defer server.Close()
+
+	mc, _ = newTestController(t, func(config *MigrationConfig) {
+		fullMode(config)
+		config.PolicyEndpoint = server.URL
+	}, testNode("node-a"), testNode("node-b"),
+		testPod("app", "node-a", "main"), testPod("db", "node-a", "main"), testPod("cache", "node-a", "main"))
+	req := &types.BatchMigrationRequest{Dependencies: []types.BatchDependency{{Pod: "db", DependsOn: []string{"app"}}}}
+	for _, pod := range []string{"app", "db", "cache"} {
+		child := testRequest(pod, "node-a", "node-b")
+		child.SuccessCriteria = types.SuccessCriteriaPodCreated
+		child.ForceIgnorePDB = true
+		req.Migrations = append(req.Migrations, *child)
+	}
+
+	started, err := mc.StartBatch(req)
+	if err != nil {
+		t.Fatalf("StartBatch: %v", err)
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	var batch *types.BatchMigrationResponse
+	for {
+		if batch, err = mc.GetBatch(started.BatchID); err != nil {
+			t.Fatal(err)
+		}
+		if batch.EndTime != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("batch still running: %+v", batch)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, child := range batch.Children {
+		switch child.PodName {
+		case "app", "db":
+			if !child.Status.Succeeded() {
+				t.Errorf("%s: status %q (skipped: %q), want it migrated", child.PodName, child.Status, child.SkippedReason)
+			}
+		case "cache":
+			if child.MigrationID != "" || !strings.Contains(child.SkippedReason, ErrPolicyDenied.Error()+": caches are rebuilt, not migrated") {
+				t.Errorf("cache: migration %q, skipped %q; want it skipped with the policy's reason", child.MigrationID, child.SkippedReason)
+			}
+		}
+	}
+}
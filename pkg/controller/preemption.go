@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// handlePreemption records that job took victim's execution slot and cancels the
+// victim. The victim's next step fails on its cancelled context, rolls back the
+// resources it created and ends as cancelled.
+func (mc *MigrationController) handlePreemption(victim, job *MigrationJob) {
+	now := time.Now()
+
+	mc.migrationsMux.Lock()
+	victim.cancelReason = fmt.Sprintf("preempted by migration %s", job.ID)
+	victim.Details.PreemptedBy = &types.PreemptionRecord{
+		MigrationID: job.ID,
+		Priority:    job.Request.Priority,
+		Time:        now,
+	}
+	job.Details.Preempted = &types.PreemptionRecord{
+		MigrationID: victim.ID,
+		Priority:    victim.Request.Priority,
+		Time:        now,
+	}
+	mc.migrationsMux.Unlock()
+
+	mc.logf(victim, "Preempted by higher-priority migration %s (priority %d > %d)",
+		job.ID, job.Request.Priority, victim.Request.Priority)
+	mc.logf(job, "Preempted migration %s (priority %d) to take its execution slot",
+		victim.ID, victim.Request.Priority)
+
+	if victim.cancel != nil {
+		victim.cancel()
+	}
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Names of the preflight checks, in the order they are reported
+const (
+	preflightPodExists         = "pod_exists"
+	preflightSourceNode        = "source_node"
+	preflightTargetNode        = "target_node"
+	preflightArchitecture      = "node_architecture"
+	preflightNoConcurrent      = "no_concurrent_migration"
+	preflightNewPodName        = "new_pod_name"
+	preflightContainers        = "containers_to_migrate"
+	preflightCheckpointStorage = "checkpoint_storage"
+	preflightSharedVolumes     = "single_node_volumes"
+	preflightResourceQuota     = "resource_quota"
+	preflightDisruptionBudget  = "disruption_budget"
+)
+
+// ValidateMigration runs every precondition of a migration synchronously and reports
+// each result without creating anything. It reuses the checks of the migration
+// itself; checks that depend on the pod are skipped when it cannot be read.
+func (mc *MigrationController) ValidateMigration(ctx context.Context, req *types.MigrationRequest) *types.PreflightReport {
+	report := &types.PreflightReport{Passed: true}
+	add := func(name string, err error, okMessage string) {
+		check := types.PreflightCheck{Name: name, Passed: err == nil, Message: okMessage}
+		if err != nil {
+			check.Message = err.Error()
+			report.Passed = false
+		}
+		report.Checks = append(report.Checks, check)
+	}
+	skip := func(name, reason string) {
+		report.Checks = append(report.Checks, types.PreflightCheck{Name: name, Passed: true, Skipped: true, Message: reason})
+	}
+
+	pod, podErr := mc.k8sClient.GetPod(ctx, req.PodNamespace, req.PodName)
+	add(preflightPodExists, podErr, fmt.Sprintf("pod %s/%s exists", req.PodNamespace, req.PodName))
+
+	if pod != nil {
+		var err error
+		if pod.Spec.NodeName != req.SourceNode {
+			err = fmt.Errorf("pod runs on node %q, not source node %s", pod.Spec.NodeName, req.SourceNode)
+		}
+		add(preflightSourceNode, err, fmt.Sprintf("pod runs on %s", req.SourceNode))
+	} else {
+		skip(preflightSourceNode, "pod not found")
+	}
+
+	if resolved, _, err := mc.resolveTargetNode(req); err != nil {
+		add(preflightTargetNode, err, "")
+	} else {
+		req = resolved
+		add(preflightTargetNode, mc.checkTargetNode(ctx, req.TargetNode), fmt.Sprintf("node %s is ready and schedulable", req.TargetNode))
+	}
+
+	if req.AllowArchMismatch {
+		skip(preflightArchitecture, "architecture mismatch allowed by request")
+	} else {
+		add(preflightArchitecture, mc.checkNodeArchitecture(ctx, req.SourceNode, req.TargetNode), "source and target nodes share architecture and OS")
+	}
+
+	if active := mc.activeMigrationForPod(req.PodNamespace, req.PodName); active != "" {
+		add(preflightNoConcurrent, fmt.Errorf("migration %s of this pod has not finished", active), "")
+	} else {
+		add(preflightNoConcurrent, nil, "no unfinished migration of this pod")
+	}
+
+	if req.NewPodName != "" {
+		add(preflightNewPodName, mc.checkNewPodName(req), fmt.Sprintf("%s is available", req.NewPodName))
+	} else {
+		skip(preflightNewPodName, "name will be generated")
+	}
+
+	if pod == nil {
+		skip(preflightContainers, "pod not found")
+		skip(preflightCheckpointStorage, "pod not found")
+		skip(preflightSharedVolumes, "pod not found")
+		skip(preflightResourceQuota, "pod not found")
+		skip(preflightDisruptionBudget, "pod not found")
+		return report
+	}
+
+	// A scratch job lets the quota and checkpoint checks run exactly as in a migration
+	job := &MigrationJob{
+		ID:         "preflight",
+		Request:    req,
+		Details:    &types.MigrationDetails{},
+		ctx:        ctx,
+		logUpdated: make(chan struct{}),
+	}
+	// The containers are those the migration itself would plan to keep
+	plan, err := mc.planMigration(ctx, req, pod, mc.classificationPolicyFor(req))
+	if err == nil {
+		job.Details.ContainerStates = plan.Containers
+		job.Details.OriginalResources = plan.CurrentUsage
+		if plan.ContainersKept == 0 {
+			err = fmt.Errorf("none of the %d containers would be migrated", len(plan.Containers))
+		} else {
+			err = checkContainerOverrides(pod, plan.Containers, req)
+		}
+		add(preflightContainers, err, fmt.Sprintf("%d/%d containers would be migrated", plan.ContainersKept, len(plan.Containers)))
+	} else {
+		add(preflightContainers, err, "")
+		if usage, err := mc.metricsProvider.PodMetrics(ctx, req.PodNamespace, req.PodName); err == nil {
+			job.Details.OriginalResources = usage
+		}
+	}
+
+	withCheckpoint := req.PreservePV && !req.ForceRestart
+	if withCheckpoint {
+		accessMode := mc.checkpointAccessMode(job)
+		add(preflightCheckpointStorage, mc.checkCheckpointAccessMode(ctx, job, accessMode),
+			fmt.Sprintf("default storage class accepts %s checkpoints", accessMode))
+	} else {
+		skip(preflightCheckpointStorage, "no checkpoint will be created")
+	}
+
+	if claims, err := mc.checkSharedVolumes(ctx, pod); err != nil || len(claims) == 0 {
+		add(preflightSharedVolumes, err, "no ReadWriteOnce PVCs to share with the new pod")
+	} else {
+		add(preflightSharedVolumes, nil, fmt.Sprintf("original pod is deleted before the new one is created to free %s", strings.Join(claims, ", ")))
+	}
+
+	add(preflightResourceQuota, mc.checkResourceQuota(job, withCheckpoint), "optimized pod fits in the namespace quota")
+
+	if req.ForceIgnorePDB {
+		skip(preflightDisruptionBudget, "PodDisruptionBudgets ignored by request")
+	} else if mc.config.safeMode {
+		skip(preflightDisruptionBudget, "safe mode keeps the original pod")
+	} else {
+		budgets, blocking, err := mc.evaluateDisruptionBudgets(ctx, pod)
+		if err == nil && blocking != "" {
+			err = fmt.Errorf("PodDisruptionBudget %s allows no disruptions", blocking)
+		}
+		add(preflightDisruptionBudget, err, fmt.Sprintf("%d PodDisruptionBudget(s) allow the deletion", len(budgets)))
+	}
+
+	return report
+}
+
+// checkTargetNode verifies that the node exists, is Ready and is not cordoned
+func (mc *MigrationController) checkTargetNode(ctx context.Context, name string) error {
+	node, err := mc.k8sClient.GetNode(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get target node: %w", err)
+	}
+	return nodeSchedulable(node)
+}
+
+// nodeSchedulable checks that a node is neither cordoned nor NotReady
+func nodeSchedulable(node *corev1.Node) error {
+	if node.Spec.Unschedulable {
+		return fmt.Errorf("target node %s is cordoned", node.Name)
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status != corev1.ConditionTrue {
+				return fmt.Errorf("target node %s is not ready: %s", node.Name, condition.Message)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("target node %s reports no Ready condition", node.Name)
+}
+
+// activeMigrationForPod returns the ID of an unfinished migration of the pod, or ""
+func (mc *MigrationController) activeMigrationForPod(namespace, name string) string {
+	mc.migrationsMux.RLock()
+	defer mc.migrationsMux.RUnlock()
+
+	for _, job := range mc.migrations {
+		if job.Request.PodNamespace != namespace || job.Request.PodName != name {
+			continue
+		}
+		select {
+		case <-job.done:
+		default:
+			return job.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// prePullImages pulls the images of the optimized pod onto the target node before it
+// is created, so the pod does not stall on image pulls. Images the node already has,
+// and images with pullPolicy Never, are skipped. This is only an optimization:
+// failures are recorded and logged but never fail the migration.
+func (mc *MigrationController) prePullImages(job *MigrationJob) {
+	ctx := job.ctx
+	start := time.Now()
+	report := &types.ImagePrePull{}
+	defer func() {
+		report.Duration = time.Since(start)
+		mc.migrationsMux.Lock()
+		job.Details.ImagePrePull = report
+		mc.migrationsMux.Unlock()
+	}()
+
+	pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Request.PodName)
+	if err != nil {
+		mc.logf(job, "Warning: Skipping image pre-pull, failed to get pod: %v", err)
+		return
+	}
+	node, err := mc.k8sClient.GetNode(ctx, job.Request.TargetNode)
+	if err != nil {
+		mc.logf(job, "Warning: Skipping image pre-pull, failed to get target node: %v", err)
+		return
+	}
+
+	var missing []string
+	for _, image := range optimizedPodImages(pod, job.Details.ContainerStates) {
+		if k8s.NodeHasImage(node, image) {
+			report.AlreadyPresent = append(report.AlreadyPresent, image)
+		} else {
+			missing = append(missing, image)
+		}
+	}
+	if len(missing) == 0 {
+		mc.logf(job, "All images already present on node %s", job.Request.TargetNode)
+		return
+	}
+
+	helperName := fmt.Sprintf("%s-prepull-%s", job.Request.PodName, strings.TrimPrefix(job.ID, "migration-"))
+	if len(helperName) > validation.DNS1123SubdomainMaxLength {
+		helperName = strings.TrimLeft(helperName[len(helperName)-validation.DNS1123SubdomainMaxLength:], "-.")
+	}
+	if _, err := mc.k8sClient.CreateImagePullPod(ctx, pod, helperName, job.Request.TargetNode, missing); err != nil {
+		mc.logf(job, "Warning: Failed to create image pre-pull pod: %v", err)
+		return
+	}
+	report.HelperPod = helperName
+	defer func() {
+		// The job context may already be done; clean up regardless
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := mc.k8sClient.DeletePod(cleanupCtx, job.Request.PodNamespace, helperName); err != nil {
+			mc.logf(job, "Warning: Failed to delete image pre-pull pod %s: %v", helperName, err)
+		}
+	}()
+
+	mc.logf(job, "Pre-pulling %d images on node %s", len(missing), job.Request.TargetNode)
+	failed, err := mc.k8sClient.WaitForImagePulls(ctx, job.Request.PodNamespace, helperName, mc.config.podReadyTimeout)
+	if err != nil {
+		mc.logf(job, "Warning: Image pre-pull did not finish: %v", err)
+		report.Failed = missing
+		return
+	}
+
+	failedSet := make(map[string]bool, len(failed))
+	for _, image := range failed {
+		failedSet[image] = true
+	}
+	for _, image := range missing {
+		if failedSet[image] {
+			report.Failed = append(report.Failed, image)
+		} else {
+			report.Pulled = append(report.Pulled, image)
+		}
+	}
+	if len(report.Failed) > 0 {
+		mc.logf(job, "Warning: Failed to pre-pull images: %v", report.Failed)
+	}
+	mc.logf(job, "Pre-pulled %d images in %s", len(report.Pulled), time.Since(start).Round(time.Millisecond))
+}
+
+// optimizedPodImages returns the distinct images the optimized pod will need: its init
+// containers and migrated containers, except those never pulled
+func optimizedPodImages(pod *corev1.Pod, states []types.ContainerState) []string {
+	migrated := make(map[string]bool, len(states))
+	for _, state := range states {
+		migrated[state.Name] = state.ShouldMigrate
+	}
+
+	seen := make(map[string]bool)
+	var images []string
+	add := func(container corev1.Container) {
+		if container.ImagePullPolicy == corev1.PullNever || seen[container.Image] {
+			return
+		}
+		seen[container.Image] = true
+		images = append(images, container.Image)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		add(container)
+	}
+	for _, container := range pod.Spec.Containers {
+		if migrated[container.Name] {
+			add(container)
+		}
+	}
+	return images
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// SavePreset validates and stores a preset, replacing any preset with the same name
+func (mc *MigrationController) SavePreset(preset *types.MigrationPreset) error {
+	if errs := validation.IsDNS1123Label(preset.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid preset name %q: %v", preset.Name, errs)
+	}
+	if preset.Options.Timeout < 0 {
+		return fmt.Errorf("preset %s: timeout must be non-negative", preset.Name)
+	}
+	if preset.Options.CheckpointSize != "" {
+		if _, err := resource.ParseQuantity(preset.Options.CheckpointSize); err != nil {
+			return fmt.Errorf("preset %s: invalid checkpoint_size %q: %w", preset.Name, preset.Options.CheckpointSize, err)
+		}
+	}
+	if err := ValidateContainerGrouping(preset.Options.ContainerGrouping); err != nil {
+		return fmt.Errorf("preset %s: %w", preset.Name, err)
+	}
+
+	mc.presetsMux.Lock()
+	mc.presets[preset.Name] = preset
+	mc.presetsMux.Unlock()
+
+	return nil
+}
+
+// GetPreset returns a stored preset by name
+func (mc *MigrationController) GetPreset(name string) (*types.MigrationPreset, error) {
+	mc.presetsMux.RLock()
+	defer mc.presetsMux.RUnlock()
+
+	preset, exists := mc.presets[name]
+	if !exists {
+		return nil, fmt.Errorf("preset %s not found", name)
+	}
+	return preset, nil
+}
+
+// ListPresets returns all stored presets sorted by name
+func (mc *MigrationController) ListPresets() []*types.MigrationPreset {
+	mc.presetsMux.RLock()
+	defer mc.presetsMux.RUnlock()
+
+	result := make([]*types.MigrationPreset, 0, len(mc.presets))
+	for _, preset := range mc.presets {
+		result = append(result, preset)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// DeletePreset removes a stored preset
+func (mc *MigrationController) DeletePreset(name string) error {
+	mc.presetsMux.Lock()
+	defer mc.presetsMux.Unlock()
+
+	if _, exists := mc.presets[name]; !exists {
+		return fmt.Errorf("preset %s not found", name)
+	}
+	delete(mc.presets, name)
+	return nil
+}
+
+// ApplyPreset merges the preset named in req with the raw request body it was decoded
+// from. The body is decoded over the preset's options, so any option present in the
+// request wins, including explicit false or zero values. The body is decoded over a
+// copy, as decoding reuses the slices it decodes into.
+func (mc *MigrationController) ApplyPreset(req *types.MigrationRequest, body []byte) error {
+	preset, err := mc.GetPreset(req.Preset)
+	if err != nil {
+		return err
+	}
+
+	merged := types.MigrationRequest{MigrationOptions: preset.Options.DeepCopy()}
+	if err := json.Unmarshal(body, &merged); err != nil {
+		return fmt.Errorf("failed to merge request with preset %s: %w", preset.Name, err)
+	}
+
+	*req = merged
+	return nil
+}
+
+// LoadPresetsFile loads presets from a JSON file containing an array of presets
+func (mc *MigrationController) LoadPresetsFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read presets file: %w", err)
+	}
+
+	var presets []*types.MigrationPreset
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return fmt.Errorf("failed to parse presets file: %w", err)
+	}
+
+	for _, preset := range presets {
+		if err := mc.SavePreset(preset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
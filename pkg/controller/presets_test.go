@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+func gpuPreset() *types.MigrationPreset {
+	return &types.MigrationPreset{
+		Name: "gpu",
+		Options: types.MigrationOptions{
+			PreservePV:          true,
+			Timeout:             900,
+			VerifyCommand:       []string{"nvidia-smi", "-L"},
+			ReadinessConditions: []string{"ModelLoaded", "Ready"},
+			ContainerGrouping:   [][]string{{"trainer", "loader"}},
+		},
+	}
+}
+
+func TestApplyPresetRequestWins(t *testing.T) {
+	mc, _ := newTestController(t, nil)
+	if err := mc.SavePreset(gpuPreset()); err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{"pod_name": "app", "pod_namespace": "default", "source_node": "node-a", "preset": "gpu",
+		"preserve_pv": false, "verify_command": ["true"]}`)
+	req := types.MigrationRequest{Preset: "gpu"}
+	if err := mc.ApplyPreset(&req, body); err != nil {
+		t.Fatal(err)
+	}
+	if req.PreservePV {
+		t.Error("explicit preserve_pv false did not override the preset")
+	}
+	if req.Timeout != 900 {
+		t.Errorf("timeout = %d, want the preset's 900", req.Timeout)
+	}
+	if !reflect.DeepEqual(req.VerifyCommand, []string{"true"}) {
+		t.Errorf("verify_command = %v, want the request's", req.VerifyCommand)
+	}
+}
+
+// Decoding a request over the preset's options must not write into the preset's
+// slices, which every later request starts from
+func TestApplyPresetLeavesPresetIntact(t *testing.T) {
+	mc, _ := newTestController(t, nil)
+	if err := mc.SavePreset(gpuPreset()); err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{"pod_name": "app", "pod_namespace": "default", "source_node": "node-a", "preset": "gpu",
+		"verify_command": ["sh", "-c"], "readiness_conditions": ["Ready"], "container_grouping": [["web"]]}`)
+	req := types.MigrationRequest{Preset: "gpu"}
+	if err := mc.ApplyPreset(&req, body); err != nil {
+		t.Fatal(err)
+	}
+	// A request that takes the preset's slices must not share them either
+	plain := types.MigrationRequest{Preset: "gpu"}
+	if err := mc.ApplyPreset(&plain, []byte(`{"pod_name": "db", "preset": "gpu"}`)); err != nil {
+		t.Fatal(err)
+	}
+	plain.VerifyCommand[0] = "edited"
+	plain.ContainerGrouping[0][0] = "edited"
+
+	preset, err := mc.GetPreset("gpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := gpuPreset().Options; !reflect.DeepEqual(preset.Options, want) {
+		t.Errorf("preset options = %+v, want unchanged %+v", preset.Options, want)
+	}
+}
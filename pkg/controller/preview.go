@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ValidateResourceOverrides checks that the overrides in field only set CPU and
+// memory to valid quantities
+func ValidateResourceOverrides(field string, overrides map[string]types.ResourceOverride) error {
+	for container, override := range overrides {
+		for kind, values := range map[string]map[string]string{"requests": override.Requests, "limits": override.Limits} {
+			for name, value := range values {
+				if name != string(corev1.ResourceCPU) && name != string(corev1.ResourceMemory) {
+					return fmt.Errorf("%s[%s].%s: only cpu and memory can be overridden, got %q", field, container, kind, name)
+				}
+				if _, err := resource.ParseQuantity(value); err != nil {
+					return fmt.Errorf("%s[%s].%s.%s: invalid quantity %q: %w", field, container, kind, name, value, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// PreviewResources compares the requests and limits of a pod with those its optimized
+// pod would have: the containers a migration would drop are dropped and overrides
+// applied to the rest. Nothing in the cluster is changed.
+func (mc *MigrationController) PreviewResources(ctx context.Context, namespace, name string, req *types.ResourcePreviewRequest) (*types.ResourcePreview, error) {
+	pod, err := mc.k8sClient.GetPod(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	for container := range req.Overrides {
+		if !hasContainer(pod, container) {
+			return nil, fmt.Errorf("overrides: pod %s/%s has no container %q", namespace, name, container)
+		}
+	}
+
+	// The containers kept are those a migration of the pod with defaults would keep
+	migration := &types.MigrationRequest{PodName: name, PodNamespace: namespace, SourceNode: pod.Spec.NodeName}
+	plan, err := mc.planMigration(ctx, migration, pod, mc.classificationPolicyFor(migration))
+	if err != nil {
+		return nil, err
+	}
+	migrated := make(map[string]types.ContainerState, len(plan.Containers))
+	for _, state := range plan.Containers {
+		migrated[state.Name] = state
+	}
+
+	preview := &types.ResourcePreview{PodName: name, PodNamespace: namespace}
+	for _, container := range pod.Spec.Containers {
+		state := migrated[container.Name]
+		override, overridden := req.Overrides[container.Name]
+		entry := types.ContainerResourcePreview{
+			Name:       container.Name,
+			Migrated:   state.ShouldMigrate,
+			Reason:     state.Reason,
+			Overridden: overridden && state.ShouldMigrate,
+		}
+
+		entry.Requests.Current = resourceAmounts(container.Resources.Requests)
+		entry.Limits.Current = resourceAmounts(container.Resources.Limits)
+		if state.ShouldMigrate {
+			entry.Requests.Proposed = overrideAmounts(entry.Requests.Current, override.Requests)
+			entry.Limits.Proposed = overrideAmounts(entry.Limits.Current, override.Limits)
+		}
+		entry.Requests.Delta = subtractAmounts(entry.Requests.Proposed, entry.Requests.Current)
+		entry.Limits.Delta = subtractAmounts(entry.Limits.Proposed, entry.Limits.Current)
+
+		addToDiff(&preview.TotalRequests, entry.Requests)
+		addToDiff(&preview.TotalLimits, entry.Limits)
+		preview.Containers = append(preview.Containers, entry)
+	}
+
+	current, proposed := preview.TotalRequests.Current, preview.TotalRequests.Proposed
+	if current.CPU > 0 {
+		preview.RequestCPUSavings = (current.CPU - proposed.CPU) / current.CPU * 100
+	}
+	if current.Memory > 0 {
+		preview.RequestMemorySavings = float64(current.Memory-proposed.Memory) / float64(current.Memory) * 100
+	}
+
+	preview.CurrentUsage = plan.CurrentUsage
+	preview.ProjectedUsage = projectUsage(plan.CurrentUsage, pod, plan.Containers, req.Overrides)
+	return preview, nil
+}
+
+// projectUsage projects the usage of the optimized pod from the original's, with
+// the resource overrides applied: no container uses more than its limit, so the
+// projection is capped at the sum of the migrated containers' limits when all of
+// them have one. Nil without a measured usage.
+func projectUsage(usage *types.ResourceUsage, pod *corev1.Pod, states []types.ContainerState, overrides map[string]types.ResourceOverride) *types.ResourceUsage {
+	if usage == nil {
+		return nil
+	}
+	projected := simulateOptimizedResources(usage)
+
+	migrated := make(map[string]bool, len(states))
+	for _, state := range states {
+		migrated[state.Name] = state.ShouldMigrate
+	}
+	var limits types.ResourceAmounts
+	cpuBound, memoryBound := true, true
+	for _, container := range pod.Spec.Containers {
+		if !migrated[container.Name] {
+			continue
+		}
+		amounts := overrideAmounts(resourceAmounts(container.Resources.Limits), overrides[container.Name].Limits)
+		limits.CPU += amounts.CPU
+		limits.Memory += amounts.Memory
+		cpuBound = cpuBound && amounts.CPU > 0
+		memoryBound = memoryBound && amounts.Memory > 0
+	}
+	if cpuBound && projected.CPUUsage > limits.CPU {
+		projected.CPUUsage = limits.CPU
+	}
+	if memoryBound && projected.MemoryUsage > limits.Memory {
+		projected.MemoryUsage = limits.Memory
+	}
+	return projected
+}
+
+// hasContainer reports whether a pod has an app container with the given name
+func hasContainer(pod *corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceAmounts reads CPU and memory from a resource list
+func resourceAmounts(list corev1.ResourceList) types.ResourceAmounts {
+	cpu := list[corev1.ResourceCPU]
+	memory := list[corev1.ResourceMemory]
+	return types.ResourceAmounts{CPU: float64(cpu.MilliValue()) / 1000.0, Memory: memory.Value()}
+}
+
+// overrideAmounts replaces the amounts set in an override; the quantities have been
+// validated by ValidateResourceOverrides
+func overrideAmounts(current types.ResourceAmounts, override map[string]string) types.ResourceAmounts {
+	amounts := current
+	if value, ok := override[string(corev1.ResourceCPU)]; ok {
+		quantity := resource.MustParse(value)
+		amounts.CPU = float64(quantity.MilliValue()) / 1000.0
+	}
+	if value, ok := override[string(corev1.ResourceMemory)]; ok {
+		quantity := resource.MustParse(value)
+		amounts.Memory = quantity.Value()
+	}
+	return amounts
+}
+
+func subtractAmounts(a, b types.ResourceAmounts) types.ResourceAmounts {
+	return types.ResourceAmounts{CPU: a.CPU - b.CPU, Memory: a.Memory - b.Memory}
+}
+
+// addToDiff adds a container's diff to the pod total
+func addToDiff(total *types.ResourceDiff, diff types.ResourceDiff) {
+	total.Current.CPU += diff.Current.CPU
+	total.Current.Memory += diff.Current.Memory
+	total.Proposed.CPU += diff.Proposed.CPU
+	total.Proposed.Memory += diff.Proposed.Memory
+	total.Delta.CPU += diff.Delta.CPU
+	total.Delta.Memory += diff.Delta.Memory
+}
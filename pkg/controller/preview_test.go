@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// withLimits gives every container of a pod the same requests and limits
+func withLimits(pod *corev1.Pod, cpu, memory string) *corev1.Pod {
+	for i := range pod.Spec.Containers {
+		list := corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}
+		pod.Spec.Containers[i].Resources = corev1.ResourceRequirements{Requests: list, Limits: list.DeepCopy()}
+	}
+	return pod
+}
+
+func TestProjectUsageHonorsOverriddenLimits(t *testing.T) {
+	pod := withLimits(testPod("app", "node-a", "main", "worker"), "2", "4Gi")
+	states := []types.ContainerState{{Name: "main", ShouldMigrate: true}, {Name: "worker", ShouldMigrate: true}}
+	usage := &types.ResourceUsage{CPUUsage: 3, MemoryUsage: 6 << 30}
+
+	// Without overrides the 4 cores and 8Gi of limits leave the projection alone
+	projected := projectUsage(usage, pod, states, nil)
+	if want := simulateOptimizedResources(usage); projected.CPUUsage != want.CPUUsage || projected.MemoryUsage != want.MemoryUsage {
+		t.Errorf("projected = %+v, want the simulated usage", projected)
+	}
+
+	overrides := map[string]types.ResourceOverride{
+		"main":   {Limits: map[string]string{"cpu": "500m", "memory": "1Gi"}},
+		"worker": {Limits: map[string]string{"cpu": "500m"}},
+	}
+	projected = projectUsage(usage, pod, states, overrides)
+	if projected.CPUUsage != 1 {
+		t.Errorf("projected cpu = %v, want the 1 core of overridden limits", projected.CPUUsage)
+	}
+	if want := int64(5 << 30); projected.MemoryUsage > want {
+		t.Errorf("projected memory = %d, want at most the %d of limits", projected.MemoryUsage, want)
+	}
+
+	// A container without a limit leaves the pod unbounded
+	pod.Spec.Containers[1].Resources.Limits = nil
+	projected = projectUsage(usage, pod, states, map[string]types.ResourceOverride{"main": overrides["main"]})
+	if projected.CPUUsage != 1.5 {
+		t.Errorf("projected cpu = %v, want it unbounded by a container without a limit", projected.CPUUsage)
+	}
+	if projectUsage(nil, pod, states, overrides) != nil {
+		t.Error("projected usage without a measurement")
+	}
+}
+
+func TestValidateResourceOverridesOfRequest(t *testing.T) {
+	for name, overrides := range map[string]map[string]types.ResourceOverride{
+		"only cpu and memory": {"main": {Limits: map[string]string{"nvidia.com/gpu": "1"}}},
+		"invalid quantity":    {"main": {Requests: map[string]string{"cpu": "lots"}}},
+		"invalid container":   {"Main_1": {Requests: map[string]string{"cpu": "1"}}},
+	} {
+		req := testRequest("app", "node-a", "node-b")
+		req.ResourceOverrides = overrides
+		err := ValidateCommandOverrides(req)
+		if err == nil || !strings.HasPrefix(err.Error(), "resource_overrides") {
+			t.Errorf("%s: error = %v, want one about resource_overrides", name, err)
+		}
+	}
+}
+
+// The new pod gets the overridden resources and the migration records the ones replaced
+func TestMigrationAppliesResourceOverrides(t *testing.T) {
+	mc, clientset := newTestController(t, nil, testNode("node-a"), testNode("node-b"),
+		withLimits(testPod("app", "node-a", "main", "worker"), "2", "4Gi"))
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ResourceOverrides = map[string]types.ResourceOverride{
+		"main": {Requests: map[string]string{"cpu": "500m"}, Limits: map[string]string{"memory": "1Gi"}},
+	}
+
+	response := runMigration(t, mc, req)
+	if !response.Status.Succeeded() {
+		t.Fatalf("status = %s (%s), want success", response.Status, response.Message)
+	}
+	pod, err := clientset.CoreV1().Pods(testNamespace).Get(context.Background(), response.Details.NewPodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, container := range pod.Spec.Containers {
+		cpu, memory := container.Resources.Requests[corev1.ResourceCPU], container.Resources.Limits[corev1.ResourceMemory]
+		want := map[string][2]string{"main": {"500m", "1Gi"}, "worker": {"2", "4Gi"}}[container.Name]
+		if cpu.String() != want[0] || memory.String() != want[1] {
+			t.Errorf("%s: cpu request %s, memory limit %s; want %s, %s", container.Name, cpu.String(), memory.String(), want[0], want[1])
+		}
+	}
+
+	overrides := response.Details.ContainerOverrides
+	want := []types.ContainerOverride{{
+		Container:         "main",
+		Resources:         &types.ResourceOverride{Requests: map[string]string{"cpu": "500m"}, Limits: map[string]string{"memory": "1Gi"}},
+		OriginalResources: &types.ResourceOverride{Requests: map[string]string{"cpu": "2"}, Limits: map[string]string{"memory": "4Gi"}},
+	}}
+	if !reflect.DeepEqual(overrides, want) {
+		t.Errorf("container overrides = %+v, want %+v", overrides, want)
+	}
+}
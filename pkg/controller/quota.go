@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// checkResourceQuota verifies that the optimized pod, and its checkpoint PVC if one
+// will be created, fit in the namespace's remaining quota. The original pod keeps
+// running until cutover and is already counted as used, so the new objects must fit
+// on top of it. The headroom of every constrained resource is recorded in the details.
+func (mc *MigrationController) checkResourceQuota(job *MigrationJob, withCheckpoint bool) error {
+	ctx := job.ctx
+
+	quotas, err := mc.k8sClient.GetResourceQuotas(ctx, job.Request.PodNamespace)
+	if err != nil {
+		return err
+	}
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Request.PodName)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	// The new pod is counted with the resources it will be created with
+	applyResourceOverrides(pod, job.Request.ResourceOverrides)
+	required := optimizedPodUsage(pod, job.Details.ContainerStates)
+	if withCheckpoint {
+		size, _, err := mc.checkpointSize(job)
+		if err != nil {
+			return err
+		}
+		required[corev1.ResourcePersistentVolumeClaims] = *resource.NewQuantity(1, resource.DecimalSI)
+		required[corev1.ResourceRequestsStorage] = size
+	}
+
+	var headroom []types.QuotaHeadroom
+	var exceeded []string
+	for _, quota := range quotas {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			mc.logf(job, "Skipping scoped ResourceQuota %s in quota check", quota.Name)
+			continue
+		}
+		for name, hard := range quota.Status.Hard {
+			need, constrained := required[name]
+			if !constrained {
+				continue
+			}
+			used := quota.Status.Used[name]
+			remaining := hard.DeepCopy()
+			remaining.Sub(used)
+			fits := need.Cmp(remaining) <= 0
+
+			headroom = append(headroom, types.QuotaHeadroom{
+				Quota:     quota.Name,
+				Resource:  string(name),
+				Hard:      hard.String(),
+				Used:      used.String(),
+				Required:  need.String(),
+				Remaining: remaining.String(),
+				Fits:      fits,
+			})
+			if !fits {
+				exceeded = append(exceeded, fmt.Sprintf("%s in quota %s (needs %s, %s of %s remaining)",
+					name, quota.Name, need.String(), remaining.String(), hard.String()))
+			}
+		}
+	}
+
+	job.Details.QuotaHeadroom = headroom
+	if len(exceeded) > 0 {
+		return fmt.Errorf("optimized pod would exceed namespace quota while the original pod is still running: %s",
+			strings.Join(exceeded, "; "))
+	}
+	return nil
+}
+
+// optimizedPodUsage returns the quota usage of the pod that will be created: the
+// migrated containers plus pod overhead, with init containers counted at their peak
+func optimizedPodUsage(pod *corev1.Pod, states []types.ContainerState) corev1.ResourceList {
+	migrated := make(map[string]bool, len(states))
+	for _, state := range states {
+		migrated[state.Name] = state.ShouldMigrate
+	}
+
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		if !migrated[container.Name] {
+			continue
+		}
+		addResources(requests, container.Resources.Requests)
+		addResources(limits, container.Resources.Limits)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		maxResources(requests, container.Resources.Requests)
+		maxResources(limits, container.Resources.Limits)
+	}
+	addResources(requests, pod.Spec.Overhead)
+	addResources(limits, pod.Spec.Overhead)
+
+	usage := corev1.ResourceList{
+		corev1.ResourcePods: *resource.NewQuantity(1, resource.DecimalSI),
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+		if quantity, ok := requests[name]; ok {
+			usage[name] = quantity
+			usage[corev1.ResourceName("requests."+string(name))] = quantity
+		}
+		if quantity, ok := limits[name]; ok {
+			usage[corev1.ResourceName("limits."+string(name))] = quantity
+		}
+	}
+	return usage
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		current := total[name]
+		current.Add(quantity)
+		total[name] = current
+	}
+}
+
+func maxResources(total, candidate corev1.ResourceList) {
+	for name, quantity := range candidate {
+		if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
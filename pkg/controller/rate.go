@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+const (
+	// rateInstantWindow is the window of the instantaneous migrations-per-minute rate
+	rateInstantWindow = time.Minute
+	// rateAverageWindow is the window of the moving average and the success rate
+	rateAverageWindow = 5 * time.Minute
+)
+
+// finishEvent is one migration that ended as completed or failed
+type finishEvent struct {
+	at      time.Time
+	success bool
+}
+
+// recordFinishLocked adds a finished migration to the sliding window, dropping events
+// that fell out of it; metricsMux must be held. Cancelled migrations are not
+// recorded: they say nothing about throughput or health.
+func (mc *MigrationController) recordFinishLocked(now time.Time, success bool) {
+	mc.pruneFinishesLocked(now)
+	mc.finishes = append(mc.finishes, finishEvent{at: now, success: success})
+}
+
+func (mc *MigrationController) pruneFinishesLocked(now time.Time) {
+	cutoff := now.Add(-rateAverageWindow)
+	keep := 0
+	for keep < len(mc.finishes) && mc.finishes[keep].at.Before(cutoff) {
+		keep++
+	}
+	mc.finishes = mc.finishes[keep:]
+}
+
+// migrationRateLocked computes throughput over the sliding window; metricsMux must be held
+func (mc *MigrationController) migrationRateLocked(now time.Time) *types.MigrationRate {
+	rate := &types.MigrationRate{}
+
+	instantCutoff := now.Add(-rateInstantWindow)
+	averageCutoff := now.Add(-rateAverageWindow)
+	var recent, succeeded int
+	for _, event := range mc.finishes {
+		if event.at.Before(averageCutoff) {
+			continue
+		}
+		recent++
+		if event.success {
+			succeeded++
+		}
+		if !event.at.Before(instantCutoff) {
+			rate.PerMinute++
+		}
+	}
+
+	rate.AveragePerMinute = float64(recent) / rateAverageWindow.Minutes()
+	if recent > 0 {
+		successRate := float64(succeeded) / float64(recent) * 100
+		rate.SuccessRate = &successRate
+	}
+	return rate
+}
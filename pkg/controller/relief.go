@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// reliefStats accumulates how much completed migrations relieved their source nodes
+type reliefStats struct {
+	count  int64
+	cpu    float64 // percentage points
+	memory float64 // percentage points
+}
+
+func (s *reliefStats) averages() (cpu, memory float64) {
+	if s.count == 0 {
+		return 0, 0
+	}
+	return s.cpu / float64(s.count), s.memory / float64(s.count)
+}
+
+// measureSourceNodeBefore records the source node's utilization before anything is
+// changed. Failures are logged and leave the relief unmeasured.
+func (mc *MigrationController) measureSourceNodeBefore(job *MigrationJob) {
+	ctx, cancel := context.WithTimeout(job.ctx, 30*time.Second)
+	defer cancel()
+
+	before, err := mc.nodeUtilization(ctx, job.Request.SourceNode)
+	if err != nil {
+		mc.logf(job, "Warning: Failed to measure source node before migration: %v", err)
+		return
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.SourceNodeRelief = &types.SourceNodeRelief{Node: job.Request.SourceNode, Before: before}
+	mc.migrationsMux.Unlock()
+}
+
+// waitOriginalReleased waits until the deleted original pod is gone, so that its
+// resources are actually released before the stabilization delay starts. It
+// reports whether the source node should be measured again.
+func (mc *MigrationController) waitOriginalReleased(ctx context.Context, job *MigrationJob) bool {
+	if job.Details.SourceNodeRelief == nil || job.Details.OriginalPodRetained {
+		return false
+	}
+	if err := mc.k8sClient.WaitForPodDeleted(ctx, job.Request.PodNamespace, job.Request.PodName, originalDeleteTimeout); err != nil {
+		mc.logf(job, "Warning: Not measuring source node relief: %v", err)
+		return false
+	}
+	return true
+}
+
+// measureSourceNodeAfter measures the source node once the original pod's usage has
+// dropped out of the metrics window and compares it with the earlier measurement
+func (mc *MigrationController) measureSourceNodeAfter(ctx context.Context, job *MigrationJob) *types.SourceNodeRelief {
+	previous := job.Details.SourceNodeRelief
+	after, err := mc.nodeUtilization(ctx, previous.Node)
+	if err != nil {
+		mc.logf(job, "Warning: Failed to measure source node after migration: %v", err)
+		return nil
+	}
+
+	relief := &types.SourceNodeRelief{
+		Node:                previous.Node,
+		Before:              previous.Before,
+		After:               after,
+		CPUFreed:            previous.Before.CPUUsage - after.CPUUsage,
+		MemoryFreed:         previous.Before.MemoryUsage - after.MemoryUsage,
+		CPUPercentRelief:    previous.Before.CPUPercent - after.CPUPercent,
+		MemoryPercentRelief: previous.Before.MemoryPercent - after.MemoryPercent,
+	}
+	mc.logf(job, "Source node %s relieved by %.1f CPU and %.1f memory percentage points",
+		relief.Node, relief.CPUPercentRelief, relief.MemoryPercentRelief)
+	return relief
+}
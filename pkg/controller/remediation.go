@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"strings"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// failureHints maps classes of failure messages to what an operator can do about
+// them. Patterns are matched case-insensitively against the failure message and the
+// first matching class wins, so more specific patterns come first.
+var failureHints = []struct {
+	class    string
+	patterns []string
+	hint     string
+}{
+	{"injected_fault", []string{"injected failure"},
+		"The failure was injected with fail-at for testing; retry without it"},
+	{"cancelled", []string{"context canceled", "cancelled"},
+		"The migration was cancelled or preempted; start it again when appropriate"},
+	{"slot_wait", []string{"waiting for a migration slot"},
+		"No execution slot freed up in time; raise --max-concurrent-migrations or the namespace limit, or retry later"},
+	{"target_node_cordoned", []string{"is cordoned"},
+		"The target node is cordoned; uncordon it or pick another target node"},
+	{"target_node_not_ready", []string{"is not ready", "reports no ready condition"},
+		"The target node is not Ready; check the kubelet on it or pick another target node"},
+	{"no_matching_node", []string{"matches target_node_selector"},
+		"No schedulable node matches target_node_selector; check the node labels or widen the selector"},
+	{"architecture_mismatch", []string{"allow_arch_mismatch"},
+		"Source and target node differ in architecture; pick a matching node or set allow_arch_mismatch for multi-arch images"},
+	{"checkpoint_not_bound", []string{"not bound after"},
+		"The checkpoint PVC stayed Pending; check that the default storage class's provisioner is running and has capacity"},
+	{"checkpoint_unreachable", []string{"not reachable from target node"},
+		"The checkpoint volume cannot be attached on the target node; use a WaitForFirstConsumer storage class for local or zonal storage"},
+	{"checkpoint_access_mode", []string{"checkpoint access mode"},
+		"The storage class does not support the checkpoint access mode; use ReadWriteOnce or a storage class that supports it"},
+	{"single_node_volume", []string{"readwriteonce volumes cannot be attached"},
+		"The pod mounts ReadWriteOnce PVCs that the new pod can only attach once the original is gone; run with --safe-mode=false, or move the data to ReadWriteMany storage"},
+	{"quota_exceeded", []string{"exceeded quota", "exceed namespace quota"},
+		"The namespace ResourceQuota has no room for the new pod; raise the quota or free resources in the namespace"},
+	{"insufficient_resources", []string{"insufficient cpu", "insufficient memory", "didn't have free ports"},
+		"The target node lacks free resources for the new pod; free capacity there or pick another target node"},
+	{"image_pull", []string{"errimagepull", "imagepullbackoff", "invalidimagename"},
+		"An image of the new pod could not be pulled on the target node; check the image name, registry access and pull secrets"},
+	{"new_pod_deleted", []string{"pod was deleted while waiting"},
+		"The new pod was deleted while starting; check for controllers or policies removing it"},
+	{"new_pod_terminated", []string{"pod terminated while waiting"},
+		"The new pod exited while starting; check its logs and events"},
+	{"readiness_timeout", []string{"timeout waiting for pod conditions", "timed out waiting"},
+		"The new pod did not become ready in time; check its events and logs, or raise --pod-ready-timeout"},
+	{"verification_failed", []string{"verification", "exited with code"},
+		"The verify_command failed in the new pod; check the command and the state restored in the pod"},
+	{"disruption_budget", []string{"disruption budget", "poddisruptionbudget"},
+		"A PodDisruptionBudget does not allow deleting the original pod; wait for replicas to become healthy or set force_ignore_pdb"},
+	{"checkpoint_corrupt", []string{"checkpoint integrity check failed"},
+		"The checkpoint PVC was deleted, replaced or rebound while the migration ran; start the migration again, or run with --checkpoint-integrity-policy=cold_restart"},
+	{"spec_drift", []string{"pod spec changed"},
+		"The original pod was changed while the migration ran; start the migration again, or run with --spec-drift-policy=replan"},
+	{"pod_not_found", []string{"failed to get original pod", "failed to get pod"},
+		"The original pod could not be read; check that it still exists and has not been renamed"},
+	{"forbidden", []string{"forbidden"},
+		"The orchestrator's service account lacks permission; check its ClusterRole"},
+}
+
+// classifyFailure returns the failure details of a message, with a remediation hint
+// when the message belongs to a known class
+func classifyFailure(step, message string) *types.FailureDetail {
+	failure := &types.FailureDetail{Step: step, Error: message}
+	lower := strings.ToLower(message)
+	for _, entry := range failureHints {
+		for _, pattern := range entry.patterns {
+			if strings.Contains(lower, pattern) {
+				failure.Class = entry.class
+				failure.Hint = entry.hint
+				return failure
+			}
+		}
+	}
+	return failure
+}
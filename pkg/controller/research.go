@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ai-storage-orchestrator/pkg/metrics"
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// writeResearchRecord writes the rationale of a finished migration to the results
+// directory, replacing an earlier version of it: completed migrations are written
+// once their post-migration metrics are final and again when usage sampling ends.
+// Failures are logged and never affect the migration.
+func (mc *MigrationController) writeResearchRecord(job *MigrationJob) {
+	if mc.config.resultsDir == "" {
+		return
+	}
+
+	// Held across building and writing so a newer record is never overwritten by an
+	// older one
+	mc.researchMux.Lock()
+	defer mc.researchMux.Unlock()
+
+	mc.migrationsMux.RLock()
+	record := mc.buildResearchRecordLocked(job)
+	mc.migrationsMux.RUnlock()
+
+	if err := writeJSONFile(filepath.Join(mc.config.resultsDir, job.ID+".json"), record); err != nil {
+		log.Printf("Migration %s: failed to write research record: %v", job.ID, err)
+	}
+}
+
+// buildResearchRecordLocked assembles the research record of a finished migration
+func (mc *MigrationController) buildResearchRecordLocked(job *MigrationJob) *types.ResearchRecord {
+	summary := job.summary
+	details := job.Details
+
+	source := mc.config.metricsProvider
+	if source == "" {
+		source = metrics.ProviderMetricsServer
+	}
+	methodology := types.SamplingMethodology{
+		Source:               source,
+		OriginalReading:      "single reading of the original pod before the checkpoint",
+		OptimizedReading:     "single reading of the new pod after the stabilization delay",
+		StabilizationDelayMs: mc.config.metricsStabilizationDelay.Milliseconds(),
+	}
+	if job.Request.SampleUsage {
+		interval, duration := usageSamplingWindow(&job.Request.MigrationOptions)
+		methodology.SampleIntervalMs = (time.Duration(interval) * time.Second).Milliseconds()
+		methodology.SampleDurationMs = (time.Duration(duration) * time.Second).Milliseconds()
+	}
+
+	steps := make([]types.ResearchStepTime, 0, len(summary.Steps))
+	for _, step := range summary.Steps {
+		steps = append(steps, types.ResearchStepTime{Step: step.Step, StartTime: step.StartTime, DurationMs: step.Duration.Milliseconds()})
+	}
+	endTime := summary.EndTime
+
+	return &types.ResearchRecord{
+		Schema:       types.ResearchRecordSchema,
+		MigrationID:  job.ID,
+		RequestID:    summary.RequestID,
+		PodName:      summary.PodName,
+		PodNamespace: summary.PodNamespace,
+		NewPodName:   summary.NewPodName,
+		Status:       summary.Status,
+		ExportedAt:   time.Now(),
+		Classification: types.ResearchClassification{
+			Policy:             job.classification,
+			Containers:         details.ContainerStates,
+			ContainersTotal:    summary.ContainersTotal,
+			ContainersMigrated: summary.ContainersMigrated,
+			RestartDecisions:   details.RestartDecisions,
+			GroupingDecisions:  details.GroupingDecisions,
+		},
+		Resources: types.ResearchResources{
+			Original:      summary.OriginalResources,
+			Optimized:     summary.OptimizedResources,
+			CPUSavings:    summary.CPUSavings,
+			MemorySavings: summary.MemorySavings,
+			GPUSavings:    summary.GPUSavings,
+			Samples:       details.UsageSamples,
+			Methodology:   methodology,
+		},
+		NodeSelection: types.ResearchNodeSelection{
+			SourceNode:            summary.SourceNode,
+			TargetNode:            summary.TargetNode,
+			Selection:             details.TargetNodeSelection,
+			Reselections:          details.NodeReselections,
+			TargetNodeUtilization: details.TargetNodeUtilization,
+			SourceNodeRelief:      details.SourceNodeRelief,
+		},
+		Timing: types.ResearchTiming{
+			StartTime:           summary.StartTime,
+			EndTime:             &endTime,
+			DurationMs:          summary.Duration.Milliseconds(),
+			Steps:               steps,
+			TimeToReadyMs:       durationMs(summary.TimeToReady),
+			SchedulingLatencyMs: durationMs(summary.SchedulingLatency),
+			StartupLatencyMs:    durationMs(summary.StartupLatency),
+			FailedStep:          summary.FailedStep,
+		},
+		Failure: summary.Failure,
+	}
+}
+
+// durationMs converts an optional duration to milliseconds
+func durationMs(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	ms := d.Milliseconds()
+	return &ms
+}
+
+// writeJSONFile writes a value as indented JSON, replacing the file atomically so
+// readers never see a partial document
+func writeJSONFile(path string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode: %w", err)
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+// writeFileAtomic replaces a file with data through a temporary file and a rename
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrTargetNodeNotReady is returned when the target node stops being Ready, or is
+// deleted, while the new pod is being created
+var ErrTargetNodeNotReady = errors.New("target node is not ready")
+
+// nodeWatchInterval is how often the target node is checked while the new pod starts
+const nodeWatchInterval = 10 * time.Second
+
+// targetNodeLost returns an ErrTargetNodeNotReady error when the node was deleted or
+// is not Ready, and nil otherwise. A cordoned node still runs the pods bound to it,
+// and a node that cannot be read is given the benefit of the doubt.
+func (mc *MigrationController) targetNodeLost(ctx context.Context, name string) error {
+	node, err := mc.k8sClient.GetNode(ctx, name)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: node %s was deleted", ErrTargetNodeNotReady, name)
+	}
+	if err != nil {
+		return nil
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
+			return fmt.Errorf("%w: node %s has Ready=%s: %s", ErrTargetNodeNotReady, name, condition.Status, condition.Message)
+		}
+	}
+	return nil
+}
+
+// watchTargetNode returns a context derived from the job's that is cancelled, with
+// the ErrTargetNodeNotReady error as its cause, once the target node is lost. The
+// returned function stops the watch.
+func (mc *MigrationController) watchTargetNode(job *MigrationJob) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(job.ctx)
+	node := job.Request.TargetNode
+
+	go func() {
+		ticker := time.NewTicker(nodeWatchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if lost := mc.targetNodeLost(ctx, node); lost != nil {
+					cancel(lost)
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() { cancel(nil) }
+}
+
+// reselectTargetNode handles a failed attempt to create the new pod. When the target
+// node was lost and was picked by target_node_selector, the objects created for it
+// are deleted and another matching node becomes the target; retry reports whether
+// the checkpoint and creation steps should run again. Otherwise the error to fail
+// the migration with is returned, explaining why no other node was tried.
+func (mc *MigrationController) reselectTargetNode(job *MigrationJob, cause error) (retry bool, err error) {
+	if job.ctx.Err() != nil {
+		return false, cause
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	from := job.Request.TargetNode
+	lost := cause
+	if !errors.Is(cause, ErrTargetNodeNotReady) {
+		if lost = mc.targetNodeLost(ctx, from); lost == nil {
+			return false, cause
+		}
+	}
+
+	if job.Details.TargetNodeSelection == nil {
+		return false, fmt.Errorf("%w; target_node was given explicitly, so no other node is tried", lost)
+	}
+	if len(job.Details.NodeReselections) >= mc.config.maxNodeReselections {
+		return false, fmt.Errorf("%w; gave up after %d node reselections", lost, len(job.Details.NodeReselections))
+	}
+
+	excluded := map[string]bool{from: true}
+	for _, reselection := range job.Details.NodeReselections {
+		excluded[reselection.FromNode] = true
+	}
+	candidates, err := mc.pickTargetNode(ctx, job.Request, excluded)
+	if err != nil {
+		return false, fmt.Errorf("%w; no other node to move to: %v", lost, err)
+	}
+	next := candidates[0].Node
+	if !job.Request.AllowArchMismatch {
+		if err := mc.checkNodeArchitecture(ctx, job.Request.SourceNode, next); err != nil {
+			return false, fmt.Errorf("%w; cannot move to node %s: %v", lost, next, err)
+		}
+	}
+
+	// Pods on a lost node never confirm their termination, so they are force deleted
+	mc.deleteCreatedObjects(ctx, job, "Reselection", true)
+
+	mc.migrationsMux.Lock()
+	job.Details.NewPodName = ""
+	job.Details.DeploymentName = ""
+	job.Details.PVClaimName = ""
+	job.Details.CheckpointPath = ""
+	job.Details.CheckpointBinding = nil
+	job.Request.TargetNode = next
+	job.Details.Plan.TargetNode = next
+	job.Details.TargetNodeSelection.Node = next
+	job.Details.TargetNodeSelection.Candidates = candidates
+	job.Details.NodeReselections = append(job.Details.NodeReselections, types.NodeReselection{
+		FromNode: from,
+		ToNode:   next,
+		Reason:   lost.Error(),
+		Time:     time.Now(),
+	})
+	attempt := len(job.Details.NodeReselections)
+	mc.migrationsMux.Unlock()
+
+	mc.logf(job, "Warning: %v; moving to node %s (reselection %d of %d)", lost, next, attempt, mc.config.maxNodeReselections)
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// Policies for containers restarted more than the restart threshold
+const (
+	// RestartPolicyIgnore makes no decision based on restart counts
+	RestartPolicyIgnore = "ignore"
+	// RestartPolicySkip does not migrate crash-looping containers
+	RestartPolicySkip = "skip"
+	// RestartPolicyPrioritize schedules pods with crash-looping containers first, to
+	// move them to a healthier node sooner
+	RestartPolicyPrioritize = "prioritize"
+)
+
+// restartPriorityBoost is added to the priority of a migration under RestartPolicyPrioritize
+const restartPriorityBoost = 10
+
+// validateRestartPolicy checks a restart count policy name
+func validateRestartPolicy(policy string) error {
+	switch policy {
+	case RestartPolicyIgnore, RestartPolicySkip, RestartPolicyPrioritize:
+		return nil
+	}
+	return fmt.Errorf("restart count policy must be %s, %s or %s, got %q",
+		RestartPolicyIgnore, RestartPolicySkip, RestartPolicyPrioritize, policy)
+}
+
+// applyRestartSkipPolicy stops migrating containers restarted more than the threshold
+// and returns a decision per affected container
+func (mc *MigrationController) applyRestartSkipPolicy(states []types.ContainerState) []string {
+	if mc.config.restartCountPolicy != RestartPolicySkip {
+		return nil
+	}
+
+	var decisions []string
+	for i := range states {
+		if !states[i].ShouldMigrate || states[i].RestartCount <= mc.config.restartCountThreshold {
+			continue
+		}
+		states[i].ShouldMigrate = false
+		states[i].Reason = fmt.Sprintf("restarted %d times (threshold %d), skipped", states[i].RestartCount, mc.config.restartCountThreshold)
+		decisions = append(decisions, fmt.Sprintf("skipped container %s: %s", states[i].Name, states[i].Reason))
+	}
+	return decisions
+}
+
+// applyRestartPriorityPolicy raises the priority of a pending migration whose pod has
+// a container restarted more than the threshold. It runs before the job asks for a
+// slot, while nothing else reads the request's priority.
+func (mc *MigrationController) applyRestartPriorityPolicy(job *MigrationJob) {
+	if mc.config.restartCountPolicy != RestartPolicyPrioritize {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(job.ctx, 10*time.Second)
+	defer cancel()
+
+	pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Request.PodName)
+	if err != nil {
+		// The capture step reports a missing pod
+		return
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.RestartCount <= mc.config.restartCountThreshold {
+			continue
+		}
+		job.Request.Priority += restartPriorityBoost
+		decision := fmt.Sprintf("priority raised by %d to %d: container %s restarted %d times (threshold %d)",
+			restartPriorityBoost, job.Request.Priority, status.Name, status.RestartCount, mc.config.restartCountThreshold)
+		mc.migrationsMux.Lock()
+		job.Details.RestartDecisions = append(job.Details.RestartDecisions, decision)
+		mc.migrationsMux.Unlock()
+		mc.logf(job, "Restart count policy: %s", decision)
+		return
+	}
+}
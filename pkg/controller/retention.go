@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// CheckpointRetentionAnnotation on a pod asks for its checkpoint PVC to be deleted
+	// this long (a Go duration such as "24h") after a successful migration
+	CheckpointRetentionAnnotation = "orchestrator/checkpoint-retention"
+	// checkpointExpiresAnnotation records on the PVC when the reconciler may delete it
+	checkpointExpiresAnnotation = "orchestrator/checkpoint-expires-at"
+)
+
+// checkpointRetention reads and validates the pod's retention annotation; zero means
+// the checkpoint is kept indefinitely
+func checkpointRetention(pod *corev1.Pod) (time.Duration, error) {
+	value, exists := pod.Annotations[CheckpointRetentionAnnotation]
+	if !exists {
+		return 0, nil
+	}
+	retention, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation %q: %w", CheckpointRetentionAnnotation, value, err)
+	}
+	if retention <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation %q: must be positive", CheckpointRetentionAnnotation, value)
+	}
+	return retention, nil
+}
+
+// scheduleCheckpointExpiry marks the checkpoint PVC of a successful migration for
+// deletion once its retention has passed. The expiry lives on the PVC so it survives
+// restarts of the orchestrator.
+func (mc *MigrationController) scheduleCheckpointExpiry(job *MigrationJob) {
+	if job.Details.PVClaimName == "" || job.checkpointRetention == 0 {
+		return
+	}
+
+	expiresAt := time.Now().Add(job.checkpointRetention).UTC()
+	err := mc.k8sClient.AnnotatePersistentVolumeClaim(job.ctx, job.Request.PodNamespace, job.Details.PVClaimName,
+		checkpointExpiresAnnotation, expiresAt.Format(time.RFC3339))
+	if err != nil {
+		mc.logf(job, "Warning: Failed to schedule deletion of checkpoint PVC %s: %v", job.Details.PVClaimName, err)
+		return
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.CheckpointExpiresAt = &expiresAt
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "Checkpoint PVC %s retained until %s", job.Details.PVClaimName, expiresAt.Format(time.RFC3339))
+}
+
+// runCheckpointReconciler periodically deletes checkpoint PVCs whose retention expired
+func (mc *MigrationController) runCheckpointReconciler() {
+	ticker := time.NewTicker(mc.config.checkpointReconcileInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		mc.reconcileCheckpoints()
+		if mc.config.cleanupFinalizers {
+			mc.reconcileTrackedResources()
+		}
+	}
+}
+
+// reconcileCheckpoints deletes every expired checkpoint PVC once. A PVC still mounted
+// by the migrated pod is only removed by Kubernetes once that pod is gone.
+func (mc *MigrationController) reconcileCheckpoints() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pvcs, err := mc.k8sClient.ListCheckpointPVCs(ctx)
+	if err != nil {
+		log.Printf("Checkpoint reconciler: failed to list checkpoint PVCs: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, pvc := range pvcs {
+		value, exists := pvc.Annotations[checkpointExpiresAnnotation]
+		if !exists || pvc.DeletionTimestamp != nil {
+			continue
+		}
+		expiresAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			log.Printf("Checkpoint reconciler: PVC %s/%s has invalid %s %q", pvc.Namespace, pvc.Name, checkpointExpiresAnnotation, value)
+			continue
+		}
+		if now.Before(expiresAt) {
+			continue
+		}
+
+		err = mc.k8sClient.DeletePersistentVolumeClaim(ctx, pvc.Namespace, pvc.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Printf("Checkpoint reconciler: failed to delete expired PVC %s/%s: %v", pvc.Namespace, pvc.Name, err)
+			continue
+		}
+		log.Printf("Checkpoint reconciler: deleted PVC %s/%s, retention expired at %s", pvc.Namespace, pvc.Name, value)
+	}
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// Usage sampling bounds and defaults, in seconds
+const (
+	maxUsageSamples            = 120
+	minUsageSampleInterval     = 5
+	defaultUsageSampleInterval = 30
+	defaultUsageSampleDuration = 600
+)
+
+// ValidateUsageSampling checks the sampling options of a request
+func ValidateUsageSampling(options *types.MigrationOptions) error {
+	if !options.SampleUsage {
+		if options.SampleInterval != 0 || options.SampleDuration != 0 {
+			return fmt.Errorf("sample_interval and sample_duration require sample_usage")
+		}
+		return nil
+	}
+
+	interval, duration := usageSamplingWindow(options)
+	if interval < minUsageSampleInterval {
+		return fmt.Errorf("sample_interval must be at least %d seconds", minUsageSampleInterval)
+	}
+	if duration < interval {
+		return fmt.Errorf("sample_duration must be at least sample_interval")
+	}
+	if duration/interval > maxUsageSamples {
+		return fmt.Errorf("sample_duration / sample_interval must not exceed %d samples", maxUsageSamples)
+	}
+	return nil
+}
+
+// usageSamplingWindow returns the sampling interval and duration in seconds, defaulted
+func usageSamplingWindow(options *types.MigrationOptions) (int, int) {
+	interval, duration := options.SampleInterval, options.SampleDuration
+	if interval == 0 {
+		interval = defaultUsageSampleInterval
+	}
+	if duration == 0 {
+		duration = defaultUsageSampleDuration
+	}
+	return interval, duration
+}
+
+// sampleUsage records the new pod's resource usage at the requested interval after a
+// completed migration, so the way usage settles can be charted. Failed readings are
+// skipped; sampling ends early once the pod is gone.
+func (mc *MigrationController) sampleUsage(job *MigrationJob) {
+	interval, duration := usageSamplingWindow(&job.Request.MigrationOptions)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(duration)*time.Second)
+	defer cancel()
+	// The samples complete the research record, if one is written
+	defer mc.writeResearchRecord(job)
+
+	mc.logf(job, "Sampling usage of %s every %ds for %ds", job.Details.NewPodName, interval, duration)
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for samples := 0; samples < duration/interval; samples++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		usage, err := mc.metricsProvider.PodMetrics(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+		if err != nil {
+			if _, podErr := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Details.NewPodName); podErr != nil {
+				mc.logf(job, "Stopped usage sampling: %v", podErr)
+				return
+			}
+			continue
+		}
+
+		mc.migrationsMux.Lock()
+		job.Details.UsageSamples = append(job.Details.UsageSamples, *usage)
+		mc.migrationsMux.Unlock()
+	}
+}
@@ -0,0 +1,276 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// slotScheduler bounds how many migrations execute at the same time, globally and per
+// namespace, and hands freed slots to waiting migrations by priority, in arrival order
+// within a priority. A migration that finds no free slot may preempt a lower-priority
+// preemptible one.
+type slotScheduler struct {
+	mu        sync.Mutex
+	limit     int
+	running   map[string]*MigrationJob
+	protected map[string]bool // running jobs past the point where preemption is allowed
+	waiting   []*slotWaiter
+
+	// Per-namespace caps: namespaceLimits overrides namespaceDefault, 0 means no cap
+	namespaceDefault int
+	namespaceLimits  map[string]int
+	namespaceRunning map[string]int
+
+	// onPreempt is called, without s.mu held, after victim's slot went to job
+	onPreempt func(victim, job *MigrationJob)
+}
+
+// slotWaiter is a migration queued for an execution slot
+type slotWaiter struct {
+	job   *MigrationJob
+	ready chan struct{} // closed when the slot is granted
+}
+
+func newSlotScheduler(limit, namespaceDefault int, namespaceLimits map[string]int, onPreempt func(victim, job *MigrationJob)) *slotScheduler {
+	return &slotScheduler{
+		limit:            limit,
+		running:          make(map[string]*MigrationJob),
+		protected:        make(map[string]bool),
+		namespaceDefault: namespaceDefault,
+		namespaceLimits:  namespaceLimits,
+		namespaceRunning: make(map[string]int),
+		onPreempt:        onPreempt,
+	}
+}
+
+// acquire blocks until the job holds an execution slot or ctx is done
+func (s *slotScheduler) acquire(ctx context.Context, job *MigrationJob) error {
+	s.mu.Lock()
+	// Waiters left over after a dispatch are all namespace-throttled, so a job whose
+	// namespace has room only has to find a free global slot
+	if len(s.running) < s.limit && s.namespaceHasRoomLocked(job.Request.PodNamespace) {
+		s.grantLocked(job)
+		s.mu.Unlock()
+		return nil
+	}
+	if victim := s.preemptionVictimLocked(job); victim != nil {
+		// Take the victim's slot right away; its own release becomes a no-op
+		s.revokeLocked(victim)
+		s.grantLocked(job)
+		s.mu.Unlock()
+		if s.onPreempt != nil {
+			s.onPreempt(victim, job)
+		}
+		return nil
+	}
+	waiter := &slotWaiter{job: job, ready: make(chan struct{})}
+	s.waiting = append(s.waiting, waiter)
+	s.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, granted := s.running[job.ID]; granted {
+			// The slot was handed over while we were giving up; pass it on
+			s.revokeLocked(job)
+			s.dispatchLocked()
+		} else {
+			s.removeWaiterLocked(job.ID)
+		}
+		return ctx.Err()
+	}
+}
+
+// protect marks the job's slot as no longer preemptible. It returns false if the
+// job has already lost its slot to a preemption.
+func (s *slotScheduler) protect(job *MigrationJob) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, holding := s.running[job.ID]; !holding {
+		return false
+	}
+	s.protected[job.ID] = true
+	return true
+}
+
+// release frees the job's slot and hands it to the next waiting migration
+func (s *slotScheduler) release(job *MigrationJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running[job.ID] == job {
+		s.revokeLocked(job)
+	}
+	delete(s.protected, job.ID)
+	s.dispatchLocked()
+}
+
+// setLimit changes how many migrations may execute at once. Lowering it lets running
+// migrations finish but grants no new slots until they fit; raising it hands the
+// new slots to waiting migrations right away.
+func (s *slotScheduler) setLimit(limit int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.limit = limit
+	s.dispatchLocked()
+}
+
+// currentLimit returns how many migrations may execute at once
+func (s *slotScheduler) currentLimit() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.limit
+}
+
+// queueReason explains why a job is still waiting for a slot, or returns "" if it
+// is not waiting
+func (s *slotScheduler) queueReason(jobID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, waiter := range s.waiting {
+		if waiter.job.ID != jobID {
+			continue
+		}
+		namespace := waiter.job.Request.PodNamespace
+		if !s.namespaceHasRoomLocked(namespace) {
+			return fmt.Sprintf("namespace %s is at its limit of %d concurrent migrations", namespace, s.namespaceLimitLocked(namespace))
+		}
+		return fmt.Sprintf("all %d execution slots are in use", s.limit)
+	}
+	return ""
+}
+
+// queuePosition returns the job's 1-based place in the order waiters are granted
+// slots: by priority, then arrival. It returns 0 if the job is not waiting.
+func (s *slotScheduler) queuePosition(jobID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index := -1
+	for i, waiter := range s.waiting {
+		if waiter.job.ID == jobID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return 0
+	}
+
+	priority := s.waiting[index].job.Request.Priority
+	position := 1
+	for i, waiter := range s.waiting {
+		other := waiter.job.Request.Priority
+		if other > priority || (other == priority && i < index) {
+			position++
+		}
+	}
+	return position
+}
+
+// runningByNamespace returns the number of migrations holding a slot per namespace
+func (s *slotScheduler) runningByNamespace() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int, len(s.namespaceRunning))
+	for namespace, count := range s.namespaceRunning {
+		counts[namespace] = count
+	}
+	return counts
+}
+
+// grantLocked gives job a slot; s.mu must be held
+func (s *slotScheduler) grantLocked(job *MigrationJob) {
+	s.running[job.ID] = job
+	s.namespaceRunning[job.Request.PodNamespace]++
+}
+
+// revokeLocked takes job's slot away; s.mu must be held
+func (s *slotScheduler) revokeLocked(job *MigrationJob) {
+	delete(s.running, job.ID)
+	namespace := job.Request.PodNamespace
+	if s.namespaceRunning[namespace]--; s.namespaceRunning[namespace] <= 0 {
+		delete(s.namespaceRunning, namespace)
+	}
+}
+
+// namespaceLimitLocked returns the namespace's cap, 0 for none; s.mu must be held
+func (s *slotScheduler) namespaceLimitLocked(namespace string) int {
+	if limit, exists := s.namespaceLimits[namespace]; exists {
+		return limit
+	}
+	return s.namespaceDefault
+}
+
+// namespaceHasRoomLocked reports whether the namespace is below its cap; s.mu must be held
+func (s *slotScheduler) namespaceHasRoomLocked(namespace string) bool {
+	limit := s.namespaceLimitLocked(namespace)
+	return limit <= 0 || s.namespaceRunning[namespace] < limit
+}
+
+// preemptionVictimLocked picks the running preemptible migration with the lowest
+// priority below job's, preferring the most recently started. When job's namespace
+// is at its cap only a victim from the same namespace frees a usable slot. s.mu must
+// be held.
+func (s *slotScheduler) preemptionVictimLocked(job *MigrationJob) *MigrationJob {
+	sameNamespaceOnly := !s.namespaceHasRoomLocked(job.Request.PodNamespace)
+
+	var victim *MigrationJob
+	for id, candidate := range s.running {
+		if !candidate.Request.Preemptible || s.protected[id] {
+			continue
+		}
+		if candidate.Request.Priority >= job.Request.Priority {
+			continue
+		}
+		if sameNamespaceOnly && candidate.Request.PodNamespace != job.Request.PodNamespace {
+			continue
+		}
+		if victim == nil ||
+			candidate.Request.Priority < victim.Request.Priority ||
+			(candidate.Request.Priority == victim.Request.Priority && candidate.StartTime.After(victim.StartTime)) {
+			victim = candidate
+		}
+	}
+	return victim
+}
+
+// dispatchLocked grants free slots to waiters whose namespace has room; s.mu must be held
+func (s *slotScheduler) dispatchLocked() {
+	for len(s.running) < s.limit {
+		best := -1
+		for i, waiter := range s.waiting {
+			if !s.namespaceHasRoomLocked(waiter.job.Request.PodNamespace) {
+				continue
+			}
+			if best < 0 || waiter.job.Request.Priority > s.waiting[best].job.Request.Priority {
+				best = i
+			}
+		}
+		if best < 0 {
+			return
+		}
+		next := s.waiting[best]
+		s.waiting = append(s.waiting[:best], s.waiting[best+1:]...)
+		s.grantLocked(next.job)
+		close(next.ready)
+	}
+}
+
+// removeWaiterLocked drops a job from the wait queue; s.mu must be held
+func (s *slotScheduler) removeWaiterLocked(jobID string) {
+	for i, waiter := range s.waiting {
+		if waiter.job.ID == jobID {
+			s.waiting = append(s.waiting[:i], s.waiting[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ai-storage-orchestrator/pkg/k8s"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// serviceLabel marks the pod a stable Service created for a migration selects
+const serviceLabel = "migration.ai-storage/service"
+
+// stableServiceName returns the requested Service name or one derived from the pod
+func stableServiceName(job *MigrationJob) string {
+	if job.Request.ServiceName != "" {
+		return job.Request.ServiceName
+	}
+	name := job.Request.PodName + "-stable"
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
+
+// ensureService points a ClusterIP Service at the new pod so consumers keep a stable
+// address across the migration. A missing Service is created from the migrated
+// containers' ports; an existing one has its selector switched to the new pod.
+func (mc *MigrationController) ensureService(job *MigrationJob) error {
+	ctx := job.ctx
+	name := stableServiceName(job)
+
+	selector := map[string]string{serviceLabel: name}
+	if job.Details.DeploymentName != "" {
+		// Pods recreated by the Deployment would not carry a label patched onto this one
+		selector = k8s.DeploymentPodSelector(job.Details.DeploymentName)
+	} else if err := mc.k8sClient.LabelPod(ctx, job.Request.PodNamespace, job.Details.NewPodName, serviceLabel, name); err != nil {
+		return fmt.Errorf("failed to label new pod: %w", err)
+	}
+
+	existing, err := mc.k8sClient.GetService(ctx, job.Request.PodNamespace, name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+
+	if err == nil {
+		previous := existing.Spec.Selector
+		if err := mc.k8sClient.SetServiceSelector(ctx, existing, selector); err != nil {
+			return fmt.Errorf("failed to update selector of service %s: %w", name, err)
+		}
+		job.servicePreviousSelector = previous
+		job.serviceSelectorReplaced = true
+		job.Details.ServiceName = name
+		mc.logf(job, "Service %s now selects the new pod", name)
+		return nil
+	}
+
+	newPod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+	if err != nil {
+		return fmt.Errorf("failed to get new pod: %w", err)
+	}
+	ports := servicePorts(newPod)
+	if len(ports) == 0 {
+		return fmt.Errorf("cannot create service %s: the migrated containers declare no ports", name)
+	}
+
+	if _, err := mc.k8sClient.CreateClusterIPService(ctx, job.Request.PodNamespace, name, selector, ports); err != nil {
+		return fmt.Errorf("failed to create service %s: %w", name, err)
+	}
+	job.Details.ServiceName = name
+	job.Details.ServiceCreated = true
+	mc.logf(job, "Created service %s with %d port(s) for the new pod", name, len(ports))
+	return nil
+}
+
+// rollbackService deletes a Service the migration created, or restores the selector
+// of one it repointed
+func (mc *MigrationController) rollbackService(ctx context.Context, job *MigrationJob) {
+	name := job.Details.ServiceName
+	if name == "" {
+		return
+	}
+
+	if job.Details.ServiceCreated {
+		err := mc.k8sClient.DeleteService(ctx, job.Request.PodNamespace, name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			mc.logf(job, "Warning: Rollback failed to delete service %s: %v", name, err)
+		} else {
+			mc.logf(job, "Rollback: deleted service %s", name)
+			job.cleanedUp = append(job.cleanedUp, "service/"+name)
+		}
+		return
+	}
+
+	if !job.serviceSelectorReplaced {
+		return
+	}
+	service, err := mc.k8sClient.GetService(ctx, job.Request.PodNamespace, name)
+	if err == nil {
+		err = mc.k8sClient.SetServiceSelector(ctx, service, job.servicePreviousSelector)
+	}
+	if err != nil {
+		mc.logf(job, "Warning: Rollback failed to restore selector of service %s: %v", name, err)
+	} else {
+		mc.logf(job, "Rollback: restored selector of service %s", name)
+	}
+}
+
+// servicePorts exposes every container port of the pod once per port and protocol
+func servicePorts(pod *corev1.Pod) []corev1.ServicePort {
+	seen := make(map[string]bool)
+	var ports []corev1.ServicePort
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			name := fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), port.ContainerPort)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			ports = append(ports, corev1.ServicePort{
+				Name:       name,
+				Protocol:   protocol,
+				Port:       port.ContainerPort,
+				TargetPort: intstr.FromInt(int(port.ContainerPort)),
+			})
+		}
+	}
+	return ports
+}
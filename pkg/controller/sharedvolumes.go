@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrSharedVolumeDeadlock is returned when the new pod would need a single-node
+// volume the original pod keeps mounted
+var ErrSharedVolumeDeadlock = errors.New("ReadWriteOnce volumes cannot be attached to the new pod while the original runs")
+
+// originalDeleteTimeout bounds the wait for the original pod to be gone, and its
+// volumes detached, before the new pod is created
+const originalDeleteTimeout = 2 * time.Minute
+
+// checkSharedVolumes finds the PVCs of a pod that only one node can attach. The new
+// pod mounts the same claims on another node, so while the original pod runs it
+// would stay ContainerCreating until the readiness wait timed out. Such pods are
+// migrated by deleting the original first, which safe mode never does.
+func (mc *MigrationController) checkSharedVolumes(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	claims, err := mc.k8sClient.SingleNodeClaims(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	return claims, mc.sharedVolumesAllowed(claims)
+}
+
+// sharedVolumesAllowed fails in safe mode when there are single-node claims
+func (mc *MigrationController) sharedVolumesAllowed(claims []string) error {
+	if len(claims) > 0 && mc.config.safeMode {
+		return fmt.Errorf("%w: pod mounts %s and safe mode never deletes the original pod; run with --safe-mode=false to delete it before creating the new one",
+			ErrSharedVolumeDeadlock, strings.Join(claims, ", "))
+	}
+	return nil
+}
+
+// planSharedVolumes switches a migration whose pod mounts single-node PVCs, as found
+// by its plan, to deleting the original pod before the new one is created
+func (mc *MigrationController) planSharedVolumes(job *MigrationJob) error {
+	claims := job.Details.Plan.Cutover.SingleNodeClaims
+	if err := mc.sharedVolumesAllowed(claims); err != nil {
+		return err
+	}
+	if len(claims) == 0 {
+		return nil
+	}
+
+	// Batches read it to count the pods that may go down
+	mc.migrationsMux.Lock()
+	job.Details.SingleNodeClaims = claims
+	job.Details.DeleteOriginalFirst = true
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "Warning: pod mounts ReadWriteOnce PVCs %s; the original pod is deleted before the new one is created, so the workload is down until the new pod is ready",
+		strings.Join(claims, ", "))
+	return nil
+}
+
+// deleteOriginalFirst cuts over early: it deletes the original pod and waits until
+// it is gone, releasing its single-node volumes for the new pod. From here on the
+// migration can no longer be preempted or cancelled.
+func (mc *MigrationController) deleteOriginalFirst(job *MigrationJob) error {
+	if !mc.slots.protect(job) {
+		return fmt.Errorf("preempted before deleting the original pod")
+	}
+	if err := mc.beginCutover(job); err != nil {
+		return err
+	}
+
+	// Rollback recreates the original from this if no new pod replaces it
+	original, err := mc.k8sClient.GetPod(job.ctx, job.Request.PodNamespace, job.Request.PodName)
+	if err != nil {
+		return fmt.Errorf("failed to get original pod: %w", err)
+	}
+	job.originalManifest = original
+
+	mc.captureDroppedContainerLogs(job)
+	if err := mc.deleteOriginalPod(job); err != nil {
+		return err
+	}
+	if err := mc.k8sClient.WaitForPodDeleted(job.ctx, job.Request.PodNamespace, job.Request.PodName, originalDeleteTimeout); err != nil {
+		return err
+	}
+	job.originalDeleted = true
+	mc.logf(job, "Original pod %s is gone, its volumes are free for the new pod", job.Request.PodName)
+	return nil
+}
+
+// rollbackWithoutOriginal rolls back a migration that already deleted the original
+// pod. Deleting the new pod too would leave the workload without any pod, so one
+// that was created is kept, ready or not, with its Service; otherwise the original
+// is recreated from the manifest captured before it was deleted, unless a controller
+// owning it replaces it anyway. The checkpoint PVC is only deleted with no pod
+// mounting it.
+func (mc *MigrationController) rollbackWithoutOriginal(ctx context.Context, job *MigrationJob) {
+	if job.Details.NewPodName != "" || job.Details.DeploymentName != "" {
+		kept := job.Details.NewPodName
+		if job.Details.DeploymentName != "" {
+			kept = "deployment " + job.Details.DeploymentName
+		}
+		mc.migrationsMux.Lock()
+		job.Details.NewPodKept = true
+		mc.migrationsMux.Unlock()
+		mc.logf(job, "Warning: Rollback keeps %s: the original pod %s was already deleted", kept, job.Request.PodName)
+		mc.releaseTrackedResources(job)
+		return
+	}
+
+	if owner := metav1.GetControllerOf(job.originalManifest); owner != nil {
+		mc.logf(job, "Rollback: original pod %s is recreated by its owner %s %s", job.Request.PodName, owner.Kind, owner.Name)
+	} else if err := mc.k8sClient.RecreatePod(ctx, job.originalManifest); err != nil {
+		mc.logf(job, "Warning: Rollback failed to recreate original pod %s: %v", job.Request.PodName, err)
+	} else {
+		mc.migrationsMux.Lock()
+		job.Details.OriginalRecreated = true
+		mc.migrationsMux.Unlock()
+		mc.logf(job, "Rollback: recreated original pod %s", job.Request.PodName)
+	}
+	mc.deleteCreatedObjects(ctx, job, "Rollback", false)
+}
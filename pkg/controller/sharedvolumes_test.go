@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// rwoPod returns a pod mounting a ReadWriteOnce claim, which a new pod on another
+// node cannot attach while the original runs
+func rwoPod() *corev1.Pod {
+	pod := testPod("db", "node-a", "postgres")
+	pod.Spec.Volumes = []corev1.Volume{{
+		Name: "data",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "db-data"},
+		},
+	}}
+	return pod
+}
+
+func rwoObjects() []runtime.Object {
+	return []runtime.Object{testNode("node-a"), testNode("node-b"), rwoPod(), testClaim("db-data", corev1.ReadWriteOnce)}
+}
+
+func fullMode(config *MigrationConfig) {
+	config.SafeMode = false
+}
+
+func TestSharedVolumesRefusedInSafeMode(t *testing.T) {
+	mc, _ := newTestController(t, nil, rwoObjects()...)
+
+	claims, err := mc.checkSharedVolumes(context.Background(), rwoPod())
+	if !errors.Is(err, ErrSharedVolumeDeadlock) {
+		t.Fatalf("checkSharedVolumes error = %v, want ErrSharedVolumeDeadlock", err)
+	}
+	if len(claims) != 1 || claims[0] != "db-data" {
+		t.Errorf("claims = %v, want [db-data]", claims)
+	}
+}
+
+func TestSharedVolumesIgnoreReadWriteMany(t *testing.T) {
+	mc, _ := newTestController(t, nil, testNode("node-a"), rwoPod(), testClaim("db-data", corev1.ReadWriteMany))
+
+	claims, err := mc.checkSharedVolumes(context.Background(), rwoPod())
+	if err != nil || len(claims) != 0 {
+		t.Fatalf("checkSharedVolumes = %v, %v; want no claims", claims, err)
+	}
+}
+
+// Waiting for the new pod before deleting the original would never end: the
+// migration must delete the original first
+func TestSharedVolumesDeleteOriginalFirst(t *testing.T) {
+	mc, clientset := newTestController(t, fullMode, rwoObjects()...)
+	req := testRequest("db", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ForceIgnorePDB = true
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+	}
+	if !response.Details.DeleteOriginalFirst {
+		t.Error("delete_original_first not set")
+	}
+
+	deleted, created := -1, -1
+	for i, action := range clientset.Actions() {
+		if action.GetResource().Resource != "pods" {
+			continue
+		}
+		switch a := action.(type) {
+		case k8stesting.DeleteAction:
+			if a.GetName() == "db" && deleted < 0 {
+				deleted = i
+			}
+		case k8stesting.CreateAction:
+			if created < 0 {
+				created = i
+			}
+		}
+	}
+	if deleted < 0 || created < 0 || deleted > created {
+		t.Errorf("original deleted at action %d, new pod created at action %d; want delete first", deleted, created)
+	}
+}
+
+// A failure after the original is gone keeps the new pod rather than leaving the
+// workload without any
+func TestSharedVolumesRollbackKeepsNewPod(t *testing.T) {
+	mc, clientset := newTestController(t, fullMode, rwoObjects()...)
+	req := testRequest("db", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ForceIgnorePDB = true
+	req.FailAt = stepCreatePod
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusFailed {
+		t.Fatalf("status = %s, want failed", response.Status)
+	}
+	if !response.Details.NewPodKept {
+		t.Error("new_pod_kept not set")
+	}
+	if _, err := clientset.CoreV1().Pods(testNamespace).Get(context.Background(), response.Details.NewPodName, metav1.GetOptions{}); err != nil {
+		t.Errorf("new pod %s was not kept: %v", response.Details.NewPodName, err)
+	}
+}
+
+// Without a new pod, rollback recreates the original from its captured manifest
+func TestSharedVolumesRollbackRecreatesOriginal(t *testing.T) {
+	mc, clientset := newTestController(t, fullMode, rwoObjects()...)
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		if pod.Name == "db" {
+			return false, nil, nil
+		}
+		return true, nil, errors.New("admission webhook denied the pod")
+	})
+	req := testRequest("db", "node-a", "node-b")
+	req.ForceIgnorePDB = true
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusFailed {
+		t.Fatalf("status = %s, want failed", response.Status)
+	}
+	if !response.Details.OriginalRecreated {
+		t.Error("original_recreated not set")
+	}
+	recreated, err := clientset.CoreV1().Pods(testNamespace).Get(context.Background(), "db", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("original pod was not recreated: %v", err)
+	}
+	if recreated.ResourceVersion != "" || recreated.UID != "" || recreated.Spec.NodeName != "" {
+		t.Errorf("recreated pod kept server-set fields: resourceVersion %q, uid %q, nodeName %q",
+			recreated.ResourceVersion, recreated.UID, recreated.Spec.NodeName)
+	}
+}
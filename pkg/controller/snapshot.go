@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+
+	k8sjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// ErrSnapshotsDisabled is returned for snapshot_original when no snapshot directory is configured
+var ErrSnapshotsDisabled = errors.New("snapshot_original requires --snapshot-dir")
+
+// checkSnapshotConfigured rejects a request for a snapshot nowhere to be written
+func (mc *MigrationController) checkSnapshotConfigured(req *types.MigrationRequest) error {
+	if req.SnapshotOriginal && mc.config.snapshotDir == "" {
+		return ErrSnapshotsDisabled
+	}
+	return nil
+}
+
+// snapshotOriginalPod writes the manifest of the original pod as YAML to
+// <snapshot dir>/<namespace>/<migration id>.yaml before anything is changed, so the
+// pod can be restored by hand even after the orchestrator is gone. Fields set by the
+// server, scheduler or kubelet are left out, as rollback leaves them out when it
+// recreates the pod, so the file can be applied as it is.
+func (mc *MigrationController) snapshotOriginalPod(job *MigrationJob) error {
+	ctx, cancel := context.WithTimeout(job.ctx, 30*time.Second)
+	defer cancel()
+
+	pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Request.PodName)
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	// Typed clients leave the type meta empty; the snapshot must apply on its own
+	pod = k8s.RecreatablePod(pod)
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+
+	var manifest bytes.Buffer
+	serializer := k8sjson.NewSerializerWithOptions(k8sjson.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, k8sjson.SerializerOptions{Yaml: true})
+	if err := serializer.Encode(pod, &manifest); err != nil {
+		return fmt.Errorf("failed to encode pod: %w", err)
+	}
+	dir := filepath.Join(mc.config.snapshotDir, job.Request.PodNamespace)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, job.ID+".yaml")
+	if err := writeFileAtomic(path, manifest.Bytes()); err != nil {
+		return err
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.OriginalSnapshot = path
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "Saved manifest of original pod %s/%s to %s", pod.Namespace, pod.Name, path)
+	return nil
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"os"
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// The snapshot applies as it is: nothing the server, scheduler or kubelet set is in it
+func TestSnapshotLeavesOutServerSetFields(t *testing.T) {
+	dir := t.TempDir()
+	mc, _ := newTestController(t, func(config *MigrationConfig) {
+		fullMode(config)
+		config.SnapshotDir = dir
+	}, testNode("node-a"), testNode("node-b"), testPod("app", "node-a", "main"))
+	req := testRequest("app", "node-a", "node-b")
+	req.SuccessCriteria = types.SuccessCriteriaPodCreated
+	req.ForceIgnorePDB = true
+	req.SnapshotOriginal = true
+
+	response := runMigration(t, mc, req)
+	if response.Status != types.MigrationStatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+	}
+	manifest, err := os.ReadFile(response.Details.OriginalSnapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(manifest, nil, nil)
+	if err != nil {
+		t.Fatalf("decode snapshot: %v", err)
+	}
+	pod := obj.(*corev1.Pod)
+
+	if pod.Name != "app" || pod.Namespace != testNamespace || pod.Labels["app"] != "app" || len(pod.Spec.Containers) != 1 {
+		t.Errorf("snapshot does not describe the original pod: %+v", pod)
+	}
+	if pod.UID != "" || pod.ResourceVersion != "" || !pod.CreationTimestamp.IsZero() || pod.ManagedFields != nil {
+		t.Errorf("server-set metadata kept: uid %q, resourceVersion %q, creationTimestamp %s", pod.UID, pod.ResourceVersion, pod.CreationTimestamp)
+	}
+	if pod.Spec.NodeName != "" {
+		t.Errorf("nodeName = %q, want the restored pod scheduled anew", pod.Spec.NodeName)
+	}
+	if pod.Status.Phase != "" || pod.Status.Conditions != nil || pod.Status.ContainerStatuses != nil {
+		t.Errorf("status kept: %+v", pod.Status)
+	}
+}
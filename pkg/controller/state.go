@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"fmt"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// migrationTransitions lists the statuses each status may move to. Statuses
+// without an entry are terminal.
+var migrationTransitions = map[types.MigrationStatus][]types.MigrationStatus{
+	types.MigrationStatusPending: {
+		types.MigrationStatusRunning,
+		types.MigrationStatusFailed,
+		types.MigrationStatusCancelled,
+	},
+	types.MigrationStatusRunning: {
+		types.MigrationStatusAwaitingCutover,
+		types.MigrationStatusRollingBack,
+		types.MigrationStatusCompleted,
+		types.MigrationStatusPartiallyDegraded,
+		types.MigrationStatusFailed,
+		types.MigrationStatusCancelled,
+	},
+	types.MigrationStatusAwaitingCutover: {
+		types.MigrationStatusRollingBack,
+		types.MigrationStatusCompleted,
+		types.MigrationStatusCompletedSafe,
+		types.MigrationStatusPartiallyDegraded,
+		types.MigrationStatusFailed,
+		types.MigrationStatusCancelled,
+	},
+	types.MigrationStatusRollingBack: {
+		types.MigrationStatusFailed,
+		types.MigrationStatusCancelled,
+	},
+}
+
+// canTransition reports whether a migration may move from one status to another
+func canTransition(from, to types.MigrationStatus) bool {
+	for _, allowed := range migrationTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transitionLocked moves a job to a new status, rejecting illegal transitions.
+// migrationsMux must be held for writing.
+func (mc *MigrationController) transitionLocked(job *MigrationJob, to types.MigrationStatus) error {
+	if !canTransition(job.Status, to) {
+		return fmt.Errorf("illegal status transition for migration %s: %s -> %s", job.ID, job.Status, to)
+	}
+	job.Status = to
+	return nil
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"testing"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+var allMigrationStatuses = []types.MigrationStatus{
+	types.MigrationStatusPending,
+	types.MigrationStatusRunning,
+	types.MigrationStatusAwaitingCutover,
+	types.MigrationStatusRollingBack,
+	types.MigrationStatusCompleted,
+	types.MigrationStatusCompletedSafe,
+	types.MigrationStatusPartiallyDegraded,
+	types.MigrationStatusFailed,
+	types.MigrationStatusCancelled,
+}
+
+// Every pair of statuses not listed here is an illegal transition
+func TestCanTransition(t *testing.T) {
+	legal := map[[2]types.MigrationStatus]bool{
+		{types.MigrationStatusPending, types.MigrationStatusRunning}:   true,
+		{types.MigrationStatusPending, types.MigrationStatusFailed}:    true,
+		{types.MigrationStatusPending, types.MigrationStatusCancelled}: true,
+
+		{types.MigrationStatusRunning, types.MigrationStatusAwaitingCutover}:   true,
+		{types.MigrationStatusRunning, types.MigrationStatusRollingBack}:       true,
+		{types.MigrationStatusRunning, types.MigrationStatusCompleted}:         true,
+		{types.MigrationStatusRunning, types.MigrationStatusPartiallyDegraded}: true,
+		{types.MigrationStatusRunning, types.MigrationStatusFailed}:            true,
+		{types.MigrationStatusRunning, types.MigrationStatusCancelled}:         true,
+
+		{types.MigrationStatusAwaitingCutover, types.MigrationStatusRollingBack}:       true,
+		{types.MigrationStatusAwaitingCutover, types.MigrationStatusCompleted}:         true,
+		{types.MigrationStatusAwaitingCutover, types.MigrationStatusCompletedSafe}:     true,
+		{types.MigrationStatusAwaitingCutover, types.MigrationStatusPartiallyDegraded}: true,
+		{types.MigrationStatusAwaitingCutover, types.MigrationStatusFailed}:            true,
+		{types.MigrationStatusAwaitingCutover, types.MigrationStatusCancelled}:         true,
+
+		{types.MigrationStatusRollingBack, types.MigrationStatusFailed}:    true,
+		{types.MigrationStatusRollingBack, types.MigrationStatusCancelled}: true,
+	}
+
+	for _, from := range allMigrationStatuses {
+		for _, to := range allMigrationStatuses {
+			want := legal[[2]types.MigrationStatus{from, to}]
+			if got := canTransition(from, to); got != want {
+				t.Errorf("canTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestTransitionLockedRejectsIllegalTransitions(t *testing.T) {
+	mc, _ := newTestController(t, nil)
+	job := testJob()
+	job.Status = types.MigrationStatusCompleted
+
+	if err := mc.transitionLocked(job, types.MigrationStatusRunning); err == nil {
+		t.Error("completed -> running allowed")
+	}
+	if job.Status != types.MigrationStatusCompleted {
+		t.Errorf("status = %s after a rejected transition, want completed", job.Status)
+	}
+
+	job.Status = types.MigrationStatusRunning
+	if err := mc.transitionLocked(job, types.MigrationStatusAwaitingCutover); err != nil {
+		t.Fatalf("running -> awaiting_cutover: %v", err)
+	}
+	if job.Status != types.MigrationStatusAwaitingCutover {
+		t.Errorf("status = %s, want awaiting_cutover", job.Status)
+	}
+}
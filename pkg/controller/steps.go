@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"sort"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// Migration steps, in execution order
+const (
+	stepCaptureState = "capture_state"
+	stepCheckpoint   = "checkpoint"
+	stepPrePull      = "pre_pull_images"
+	stepCreatePod    = "create_pod"
+	stepVerify       = "verify"
+	stepStability    = "stability_window"
+	stepCutover      = "cutover"
+)
+
+// minStepSamples is the history a step needs before it contributes to an estimate
+const minStepSamples = 3
+
+// stepStats accumulates the durations of successfully completed steps
+type stepStats struct {
+	count int64
+	total time.Duration
+}
+
+// stepDurationBuckets are the upper bounds of the step duration histograms
+var stepDurationBuckets = []time.Duration{
+	500 * time.Millisecond, time.Second, 2500 * time.Millisecond, 5 * time.Second,
+	10 * time.Second, 30 * time.Second, time.Minute, 2 * time.Minute,
+	5 * time.Minute, 10 * time.Minute, 30 * time.Minute,
+}
+
+// stepHistogram counts the durations of a step per bucket of stepDurationBuckets,
+// non-cumulatively; durations above the last bound only count towards the total
+type stepHistogram struct {
+	buckets []int64
+	count   int64
+	total   time.Duration
+}
+
+func (h *stepHistogram) observe(duration time.Duration) {
+	if h.buckets == nil {
+		h.buckets = make([]int64, len(stepDurationBuckets))
+	}
+	for i, bound := range stepDurationBuckets {
+		if duration <= bound {
+			h.buckets[i]++
+			break
+		}
+	}
+	h.count++
+	h.total += duration
+}
+
+func (s *stepStats) average() time.Duration {
+	if s == nil || s.count == 0 {
+		return 0
+	}
+	return s.total / time.Duration(s.count)
+}
+
+// plannedSteps returns the steps a migration will run, given its options; safe mode
+// has no cutover step
+func plannedSteps(req *types.MigrationRequest, safeMode bool) []string {
+	steps := []string{stepCaptureState}
+	if req.PreservePV && !req.ForceRestart {
+		steps = append(steps, stepCheckpoint)
+	}
+	if req.PrePullImages {
+		steps = append(steps, stepPrePull)
+	}
+	steps = append(steps, stepCreatePod)
+	if len(req.VerifyCommand) > 0 {
+		steps = append(steps, stepVerify)
+	}
+	if req.SuccessCriteria == types.SuccessCriteriaStabilityWindow {
+		steps = append(steps, stepStability)
+	}
+	if safeMode {
+		return steps
+	}
+	return append(steps, stepCutover)
+}
+
+// beginStep finishes the job's current step, if any, and starts the next one.
+// Reaching the next step means the previous one succeeded, so its duration is
+// added to the history used for estimates.
+func (mc *MigrationController) beginStep(job *MigrationJob, step string) {
+	mc.migrationsMux.Lock()
+	defer mc.migrationsMux.Unlock()
+
+	now := time.Now()
+	mc.finishStepLocked(job, now)
+	job.Details.CurrentStep = step
+	job.stepStarted = now
+	job.Details.EstimatedTimeRemaining = mc.estimateRemainingLocked(job, now)
+}
+
+// finishSteps records the final step of a completed migration
+func (mc *MigrationController) finishSteps(job *MigrationJob) {
+	mc.migrationsMux.Lock()
+	defer mc.migrationsMux.Unlock()
+
+	mc.finishStepLocked(job, time.Now())
+	job.Details.CurrentStep = ""
+	job.Details.EstimatedTimeRemaining = nil
+}
+
+func (mc *MigrationController) finishStepLocked(job *MigrationJob, now time.Time) {
+	step := job.Details.CurrentStep
+	if step == "" {
+		return
+	}
+
+	duration := now.Sub(job.stepStarted)
+	job.Details.StepTimings = append(job.Details.StepTimings, types.StepTiming{
+		Step:      step,
+		StartTime: job.stepStarted,
+		Duration:  duration,
+	})
+
+	mc.metricsMux.Lock()
+	defer mc.metricsMux.Unlock()
+	stats, exists := mc.stepStats[step]
+	if !exists {
+		stats = &stepStats{}
+		mc.stepStats[step] = stats
+	}
+	stats.count++
+	stats.total += duration
+
+	histogram, exists := mc.stepHistograms[step]
+	if !exists {
+		histogram = &stepHistogram{}
+		mc.stepHistograms[step] = histogram
+	}
+	histogram.observe(duration)
+}
+
+// estimateRemainingLocked sums the historical average of the current and remaining
+// steps, less the time already spent in the current step. It returns nil when any
+// of those steps lacks enough history.
+func (mc *MigrationController) estimateRemainingLocked(job *MigrationJob, now time.Time) *time.Duration {
+	steps := plannedSteps(job.Request, mc.config.safeMode)
+
+	current := -1
+	for i, step := range steps {
+		if step == job.Details.CurrentStep {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		return nil
+	}
+
+	mc.metricsMux.Lock()
+	defer mc.metricsMux.Unlock()
+	var remaining time.Duration
+	for i, step := range steps[current:] {
+		stats := mc.stepStats[step]
+		if stats == nil || stats.count < minStepSamples {
+			return nil
+		}
+		average := stats.average()
+		if i == 0 {
+			average -= now.Sub(job.stepStarted)
+			if average < 0 {
+				average = 0
+			}
+		}
+		remaining += average
+	}
+	return &remaining
+}
+
+// averageStepDurationsLocked returns the historical average duration of each step;
+// metricsMux must be held
+func (mc *MigrationController) averageStepDurationsLocked() map[string]time.Duration {
+	if len(mc.stepStats) == 0 {
+		return nil
+	}
+	averages := make(map[string]time.Duration, len(mc.stepStats))
+	for step, stats := range mc.stepStats {
+		averages[step] = stats.average()
+	}
+	return averages
+}
+
+// GetStepDurationHistograms returns the cumulative duration histogram of each
+// successfully completed step, sorted by step
+func (mc *MigrationController) GetStepDurationHistograms() []types.StepDurationHistogram {
+	mc.metricsMux.Lock()
+	defer mc.metricsMux.Unlock()
+
+	histograms := make([]types.StepDurationHistogram, 0, len(mc.stepHistograms))
+	for step, h := range mc.stepHistograms {
+		histogram := types.StepDurationHistogram{
+			Step:    step,
+			Buckets: make([]types.HistogramBucket, len(stepDurationBuckets)),
+			Count:   h.count,
+			Sum:     h.total,
+		}
+		var cumulative int64
+		for i, bound := range stepDurationBuckets {
+			cumulative += h.buckets[i]
+			histogram.Buckets[i] = types.HistogramBucket{UpperBound: bound, Count: cumulative}
+		}
+		histograms = append(histograms, histogram)
+	}
+	sort.Slice(histograms, func(i, j int) bool { return histograms[i].Step < histograms[j].Step })
+	return histograms
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+)
+
+const (
+	// defaultStabilityWindow applies to the stability_window criteria without stability_window_seconds
+	defaultStabilityWindow = 60 * time.Second
+	// maxStabilityWindow caps stability_window_seconds
+	maxStabilityWindow = 30 * time.Minute
+	// stabilityCheckInterval is how often the new pod is checked during the window
+	stabilityCheckInterval = 5 * time.Second
+)
+
+// ValidateSuccessCriteria checks the success criteria options of a request
+func ValidateSuccessCriteria(options *types.MigrationOptions) error {
+	switch options.SuccessCriteria {
+	case "", types.SuccessCriteriaPodCreated, types.SuccessCriteriaPodReady, types.SuccessCriteriaStabilityWindow:
+	case types.SuccessCriteriaProbePassed:
+		if len(options.VerifyCommand) == 0 {
+			return fmt.Errorf("success_criteria %s requires verify_command", types.SuccessCriteriaProbePassed)
+		}
+	default:
+		return fmt.Errorf("success_criteria must be %s, %s, %s or %s", types.SuccessCriteriaPodCreated,
+			types.SuccessCriteriaPodReady, types.SuccessCriteriaProbePassed, types.SuccessCriteriaStabilityWindow)
+	}
+
+	if options.StabilityWindowSeconds != 0 && options.SuccessCriteria != types.SuccessCriteriaStabilityWindow {
+		return fmt.Errorf("stability_window_seconds requires success_criteria %s", types.SuccessCriteriaStabilityWindow)
+	}
+	if options.StabilityWindowSeconds < 0 || time.Duration(options.StabilityWindowSeconds)*time.Second > maxStabilityWindow {
+		return fmt.Errorf("stability_window_seconds must be between 0 and %d", int(maxStabilityWindow/time.Second))
+	}
+	if options.SuccessCriteria == types.SuccessCriteriaPodCreated && len(options.VerifyCommand) > 0 {
+		return fmt.Errorf("verify_command needs a running pod and cannot be used with success_criteria %s", types.SuccessCriteriaPodCreated)
+	}
+	return nil
+}
+
+// successCriteria returns the request's criteria, pod_ready by default
+func successCriteria(req *types.MigrationRequest) string {
+	if req.SuccessCriteria == "" {
+		return types.SuccessCriteriaPodReady
+	}
+	return req.SuccessCriteria
+}
+
+// stabilityWindow returns how long the new pod must stay ready without restarts
+func stabilityWindow(req *types.MigrationRequest) time.Duration {
+	if req.StabilityWindowSeconds == 0 {
+		return defaultStabilityWindow
+	}
+	return time.Duration(req.StabilityWindowSeconds) * time.Second
+}
+
+// waitStabilityWindow requires the new pod to keep its readiness conditions and not
+// restart any container for the whole stability window
+func (mc *MigrationController) waitStabilityWindow(job *MigrationJob) error {
+	window := stabilityWindow(job.Request)
+	mc.logf(job, "Watching %s for a %s stability window", job.Details.NewPodName, window)
+
+	degraded := make(map[string]bool, len(job.Details.DegradedContainers))
+	for _, name := range job.Details.DegradedContainers {
+		degraded[name] = true
+	}
+
+	var baseline map[string]int32
+	deadline := time.Now().Add(window)
+	ticker := time.NewTicker(stabilityCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		pod, err := mc.k8sClient.GetPod(job.ctx, job.Request.PodNamespace, job.Details.NewPodName)
+		if err != nil {
+			return fmt.Errorf("failed to get new pod: %w", err)
+		}
+		// Containers of a partially ready pod kept anyway are not held to the window
+		tolerated := append(append([]string(nil), job.Request.TolerateUnreadyContainers...), job.Details.DegradedContainers...)
+		if unmet := k8s.PendingPodConditions(pod, job.Request.ReadinessConditions, tolerated); len(unmet) > 0 {
+			return fmt.Errorf("pod lost readiness conditions %v during the stability window", unmet)
+		}
+		restarts := make(map[string]int32, len(pod.Status.ContainerStatuses))
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts[status.Name] = status.RestartCount
+			if baseline != nil && status.RestartCount > baseline[status.Name] && !degraded[status.Name] {
+				return fmt.Errorf("container %s restarted during the stability window", status.Name)
+			}
+		}
+		if baseline == nil {
+			baseline = restarts
+		}
+
+		if !time.Now().Before(deadline) {
+			return nil
+		}
+		select {
+		case <-job.ctx.Done():
+			return job.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// recordToleratedContainers notes which tolerated containers were still unready when
+// the new pod counted as ready
+func (mc *MigrationController) recordToleratedContainers(ctx context.Context, job *MigrationJob) {
+	if len(job.Request.TolerateUnreadyContainers) == 0 {
+		return
+	}
+	pod, err := mc.k8sClient.GetPod(ctx, job.Request.PodNamespace, job.Details.NewPodName)
+	if err != nil {
+		return
+	}
+	unready := k8s.UnreadyContainers(pod, job.Request.TolerateUnreadyContainers)
+	if len(unready) == 0 {
+		return
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.ToleratedUnreadyContainers = unready
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "Tolerating unready containers %s as requested", strings.Join(unready, ", "))
+}
+
+// recordSuccessEvidence notes which success criteria the migration met and how
+func (mc *MigrationController) recordSuccessEvidence(job *MigrationJob) {
+	criteria := successCriteria(job.Request)
+	evidence := &types.SuccessEvidence{Criteria: criteria, MetAt: time.Now()}
+
+	switch criteria {
+	case types.SuccessCriteriaPodCreated:
+		evidence.Detail = fmt.Sprintf("pod %s created", job.Details.NewPodName)
+	case types.SuccessCriteriaProbePassed:
+		if verification := job.Details.Verification; verification != nil {
+			evidence.Detail = fmt.Sprintf("verify command exited %d in container %s", verification.ExitCode, verification.Container)
+			evidence.ProbeOutput = verification.Output
+		}
+	case types.SuccessCriteriaStabilityWindow:
+		window := stabilityWindow(job.Request)
+		evidence.StabilityWindow = &window
+		evidence.Detail = fmt.Sprintf("pod %s stayed ready without restarts for %s", job.Details.NewPodName, window)
+	default:
+		evidence.Detail = fmt.Sprintf("pod %s ready", job.Details.NewPodName)
+		if len(job.Details.DegradedContainers) > 0 {
+			evidence.Detail = fmt.Sprintf("pod %s partially ready, containers %s not ready", job.Details.NewPodName, strings.Join(job.Details.DegradedContainers, ", "))
+		}
+		if job.Details.TimeToReady != nil {
+			evidence.Detail += fmt.Sprintf(" after %s", job.Details.TimeToReady.Round(time.Millisecond))
+		}
+	}
+
+	mc.migrationsMux.Lock()
+	job.Details.SuccessEvidence = evidence
+	mc.migrationsMux.Unlock()
+	mc.logf(job, "Success criteria %s met: %s", criteria, evidence.Detail)
+}
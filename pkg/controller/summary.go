@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// buildSummaryLocked assembles the final summary of a job that has reached a
+// terminal status. The summary shares nothing with Details, so it can be handed
+// out without the lock; migrationsMux must be held.
+func buildSummaryLocked(job *MigrationJob) *types.MigrationSummary {
+	details := job.Details
+	summary := &types.MigrationSummary{
+		MigrationID:       job.ID,
+		RequestID:         details.RequestID,
+		PodName:           job.Request.PodName,
+		PodNamespace:      job.Request.PodNamespace,
+		NewPodName:        details.NewPodName,
+		SourceNode:        job.Request.SourceNode,
+		TargetNode:        job.Request.TargetNode,
+		Status:            job.Status,
+		StartTime:         job.StartTime,
+		Steps:             append([]types.StepTiming(nil), details.StepTimings...),
+		TimeToReady:       copyDuration(details.TimeToReady),
+		SchedulingLatency: copyDuration(details.SchedulingLatency),
+		StartupLatency:    copyDuration(details.StartupLatency),
+		CheckpointPVC:     details.PVClaimName,
+		MetricsFinal:      !details.MetricsPending,
+	}
+	if details.EndTime != nil {
+		summary.EndTime = *details.EndTime
+	}
+	if details.Duration != nil {
+		summary.Duration = *details.Duration
+	}
+	if job.Status == types.MigrationStatusFailed || job.Status == types.MigrationStatusCancelled {
+		summary.FailedStep = details.CurrentStep
+	}
+
+	summary.ContainersTotal = len(details.ContainerStates)
+	summary.DegradedContainers = append([]string(nil), details.DegradedContainers...)
+	for _, state := range details.ContainerStates {
+		if state.ShouldMigrate {
+			summary.ContainersMigrated++
+		}
+	}
+
+	if details.OriginalResources != nil {
+		original := *details.OriginalResources
+		summary.OriginalResources = &original
+	}
+	if details.OptimizedResources != nil {
+		optimized := *details.OptimizedResources
+		summary.OptimizedResources = &optimized
+	}
+	if original, optimized := summary.OriginalResources, summary.OptimizedResources; original != nil && optimized != nil {
+		if original.CPUUsage > 0 {
+			cpuSavings := (original.CPUUsage - optimized.CPUUsage) / original.CPUUsage * 100
+			summary.CPUSavings = &cpuSavings
+		}
+		if original.MemoryUsage > 0 {
+			memorySavings := float64(original.MemoryUsage-optimized.MemoryUsage) / float64(original.MemoryUsage) * 100
+			summary.MemorySavings = &memorySavings
+		}
+		if original.GPUUsage > 0 {
+			gpuSavings := (original.GPUUsage - optimized.GPUUsage) / original.GPUUsage * 100
+			summary.GPUSavings = &gpuSavings
+		}
+	}
+
+	if details.PVClaimName != "" {
+		summary.ResourcesCreated = append(summary.ResourcesCreated, "persistentvolumeclaim/"+details.PVClaimName)
+	}
+	if details.DeploymentName != "" {
+		summary.ResourcesCreated = append(summary.ResourcesCreated, "deployment/"+details.DeploymentName)
+	}
+	if details.NewPodName != "" {
+		summary.ResourcesCreated = append(summary.ResourcesCreated, "pod/"+details.NewPodName)
+	}
+	if details.ServiceCreated && details.ServiceName != "" {
+		summary.ResourcesCreated = append(summary.ResourcesCreated, "service/"+details.ServiceName)
+	}
+	summary.ResourcesCleanedUp = append([]string(nil), job.cleanedUp...)
+
+	// Warnings logged along the way, then the reason the migration ended
+	for _, entry := range job.logs {
+		if strings.HasPrefix(entry.Message, "Warning:") {
+			summary.Errors = append(summary.Errors, strings.TrimSpace(strings.TrimPrefix(entry.Message, "Warning:")))
+		}
+	}
+	if job.failure != "" {
+		summary.Errors = append(summary.Errors, job.failure)
+	}
+	if details.Failure != nil {
+		failure := *details.Failure
+		summary.Failure = &failure
+	}
+	return summary
+}
+
+func copyDuration(d *time.Duration) *time.Duration {
+	if d == nil {
+		return nil
+	}
+	value := *d
+	return &value
+}
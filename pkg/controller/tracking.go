@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// resourceTracking returns how the objects created for a job are tagged, or nil when
+// cleanup finalizers are disabled
+func (mc *MigrationController) resourceTracking(job *MigrationJob) *k8s.ResourceTracking {
+	if !mc.config.cleanupFinalizers {
+		return nil
+	}
+	// Without the original pod, whatever the migration creates is all the workload has
+	return &k8s.ResourceTracking{MigrationID: job.ID, HandedOver: job.originalDeleted}
+}
+
+// releaseTracked removes the cleanup finalizer from an object of a job; failures are
+// logged and left to the reconciler
+func (mc *MigrationController) releaseTracked(ctx context.Context, job *MigrationJob, kind, name string) {
+	if !mc.config.cleanupFinalizers || name == "" {
+		return
+	}
+	if err := mc.k8sClient.ReleaseTracked(ctx, kind, job.Request.PodNamespace, name); err != nil {
+		mc.logf(job, "Warning: %v", err)
+	}
+}
+
+// handOverTrackedResources marks the objects of a job as depending on the workload
+// before it goes past the point of no return. From then on cleanup only releases
+// them, even if their release fails and the migration is later forgotten.
+func (mc *MigrationController) handOverTrackedResources(job *MigrationJob) error {
+	if !mc.config.cleanupFinalizers {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	objects := map[string]string{k8s.TrackedPVC: job.Details.PVClaimName}
+	if job.Details.DeploymentName != "" {
+		objects[k8s.TrackedDeployment] = job.Details.DeploymentName
+	} else {
+		objects[k8s.TrackedPod] = job.Details.NewPodName
+	}
+	for kind, name := range objects {
+		if name == "" {
+			continue
+		}
+		if err := mc.k8sClient.MarkHandedOver(ctx, kind, job.Request.PodNamespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseTrackedResources hands the objects of a successful migration over to the
+// workload by removing their cleanup finalizers
+func (mc *MigrationController) releaseTrackedResources(job *MigrationJob) {
+	if !mc.config.cleanupFinalizers {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if job.Details.DeploymentName != "" {
+		mc.releaseTracked(ctx, job, k8s.TrackedDeployment, job.Details.DeploymentName)
+	} else {
+		mc.releaseTracked(ctx, job, k8s.TrackedPod, job.Details.NewPodName)
+	}
+	mc.releaseTracked(ctx, job, k8s.TrackedPVC, job.Details.PVClaimName)
+}
+
+// reconcileTrackedResources settles every object still carrying the cleanup
+// finalizer. Objects of a migration that is still running are left alone; handed
+// over objects and those of a successful migration whose release failed are
+// released; everything else belongs to a failed migration or to one interrupted by
+// a restart before its point of no return and is deleted.
+func (mc *MigrationController) reconcileTrackedResources() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	tracked, err := mc.k8sClient.ListTrackedResources(ctx)
+	if err != nil {
+		log.Printf("Cleanup reconciler: %v", err)
+		return
+	}
+
+	for _, resource := range tracked {
+		// Unknown migrations have no status
+		var status types.MigrationStatus
+		mc.migrationsMux.RLock()
+		if job, exists := mc.migrations[resource.MigrationID]; exists {
+			status = job.Status
+		}
+		mc.migrationsMux.RUnlock()
+
+		if _, running := migrationTransitions[status]; running {
+			continue
+		}
+		// A forgotten migration that handed its objects over may have succeeded
+		if resource.HandedOver || status.Succeeded() {
+			if err := mc.k8sClient.ReleaseTracked(ctx, resource.Kind, resource.Namespace, resource.Name); err != nil {
+				log.Printf("Cleanup reconciler: %v", err)
+			}
+			continue
+		}
+
+		if err := mc.k8sClient.DeleteTracked(ctx, resource); err != nil {
+			log.Printf("Cleanup reconciler: failed to delete %s %s/%s of migration %s: %v",
+				resource.Kind, resource.Namespace, resource.Name, resource.MigrationID, err)
+			continue
+		}
+		log.Printf("Cleanup reconciler: deleted %s %s/%s left behind by migration %s",
+			resource.Kind, resource.Namespace, resource.Name, resource.MigrationID)
+	}
+}
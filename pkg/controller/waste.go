@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// wasteStats accumulates the gap between requested and used resources of migrated
+// containers
+type wasteStats struct {
+	cpuCount    int64
+	cpu         float64 // cores
+	memoryCount int64
+	memory      int64 // bytes
+}
+
+func (s *wasteStats) add(gap types.ContainerResourceGap) {
+	if gap.CPUWaste != nil {
+		s.cpuCount++
+		s.cpu += *gap.CPUWaste
+	}
+	if gap.MemoryWaste != nil {
+		s.memoryCount++
+		s.memory += *gap.MemoryWaste
+	}
+}
+
+func (s *wasteStats) averages() (cpu float64, memory int64) {
+	if s.cpuCount > 0 {
+		cpu = s.cpu / float64(s.cpuCount)
+	}
+	if s.memoryCount > 0 {
+		memory = s.memory / s.memoryCount
+	}
+	return cpu, memory
+}
+
+// resourceGaps compares what each migrated container requests with what it uses.
+// A waste is only computed for resources with both a request and a usage reading;
+// a negative waste means the container uses more than it requests.
+func resourceGaps(pod *corev1.Pod, states []types.ContainerState, usage map[string]types.ResourceUsage) []types.ContainerResourceGap {
+	migrated := make(map[string]bool, len(states))
+	for _, state := range states {
+		migrated[state.Name] = state.ShouldMigrate
+	}
+
+	var gaps []types.ContainerResourceGap
+	for _, container := range pod.Spec.Containers {
+		if !migrated[container.Name] {
+			continue
+		}
+		gap := types.ContainerResourceGap{Container: container.Name}
+		used, measured := usage[container.Name]
+		if measured {
+			gap.UsedCPU = used.CPUUsage
+			gap.UsedMemory = used.MemoryUsage
+		}
+
+		if request, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			gap.RequestedCPU = float64(request.MilliValue()) / 1000.0
+			if measured && gap.RequestedCPU > 0 {
+				waste := gap.RequestedCPU - gap.UsedCPU
+				percent := waste / gap.RequestedCPU * 100
+				gap.CPUWaste, gap.CPUWastePercent = &waste, &percent
+			}
+		}
+		if request, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+			gap.RequestedMemory = request.Value()
+			if measured && gap.RequestedMemory > 0 {
+				waste := gap.RequestedMemory - gap.UsedMemory
+				percent := float64(waste) / float64(gap.RequestedMemory) * 100
+				gap.MemoryWaste, gap.MemoryWastePercent = &waste, &percent
+			}
+		}
+		gaps = append(gaps, gap)
+	}
+	return gaps
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// webhookEventFinished is sent once a migration's result is final
+const webhookEventFinished = "migration.finished"
+
+// SetWebhookSubscribers replaces the subscribers notified of migration results
+func (mc *MigrationController) SetWebhookSubscribers(subscribers []types.WebhookSubscriber) error {
+	return mc.webhooks.SetSubscribers(subscribers)
+}
+
+// WebhookSubscribers returns the configured subscribers with secrets redacted
+func (mc *MigrationController) WebhookSubscribers() []types.WebhookSubscriber {
+	return mc.webhooks.Subscribers()
+}
+
+// LoadWebhooksFile loads subscribers from a JSON file containing an array of subscribers
+func (mc *MigrationController) LoadWebhooksFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read webhooks file: %w", err)
+	}
+
+	var subscribers []types.WebhookSubscriber
+	if err := json.Unmarshal(data, &subscribers); err != nil {
+		return fmt.Errorf("failed to parse webhooks file: %w", err)
+	}
+	return mc.SetWebhookSubscribers(subscribers)
+}
+
+// buildWebhookEventLocked snapshots a finished job as a webhook payload; migrationsMux must be held
+func (mc *MigrationController) buildWebhookEventLocked(job *MigrationJob) *types.WebhookEvent {
+	response := mc.buildResponseLocked(job)
+	// Deliveries run after the lock is released, so they get their own copy
+	details := *job.Details
+	response.Details = &details
+
+	return &types.WebhookEvent{
+		Event:       webhookEventFinished,
+		Timestamp:   time.Now(),
+		MigrationID: job.ID,
+		RequestID:   job.Request.RequestID,
+		Namespace:   job.Request.PodNamespace,
+		PodName:     job.Request.PodName,
+		Status:      job.Status,
+		Migration:   response,
+	}
+}
@@ -1,44 +1,91 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"ai-storage-orchestrator/pkg/types"
 	
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // Client wraps Kubernetes client with migration-specific functionality
 type Client struct {
-	clientset       kubernetes.Interface
+	clientset        kubernetes.Interface
 	metricsClientset metricsclientset.Interface
-	config          *rest.Config
+	config           *rest.Config
+	pods             *podEvents   // shared informer started by StartPodInformer, nil if not used
+	throttled        atomic.Int64 // 429 responses from the API server
+	connection       types.ClusterConnection
 }
 
 // NewClient creates a new Kubernetes client
 func NewClient(kubeconfig string) (*Client, error) {
 	var config *rest.Config
+	var connection types.ClusterConnection
 	var err error
 
 	if kubeconfig != "" {
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		config, connection, err = loadKubeconfig(kubeconfig)
 	} else {
 		config, err = rest.InClusterConfig()
+		connection.InCluster = true
 	}
 	
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes config: %w", err)
 	}
 
+	c, err := NewClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	c.connection.InCluster = connection.InCluster
+	c.connection.Kubeconfig = connection.Kubeconfig
+	c.connection.Context = connection.Context
+	c.connection.Cluster = connection.Cluster
+	c.connection.User = connection.User
+	return c, nil
+}
+
+// NewClientForConfig creates a Kubernetes client from an existing REST config, such
+// as one pointing at a locally started API server
+func NewClientForConfig(config *rest.Config) (*Client, error) {
+	c := &Client{connection: describeConfig(config)}
+
+	// Every request goes through the throttle counter
+	config = rest.CopyConfig(config)
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &throttleCounter{next: rt, count: &c.throttled}
+	})
+	c.config = config
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
@@ -49,11 +96,15 @@ func NewClient(kubeconfig string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create metrics clientset: %w", err)
 	}
 
-	return &Client{
-		clientset:        clientset,
-		metricsClientset: metricsClientset,
-		config:           config,
-	}, nil
+	c.clientset = clientset
+	c.metricsClientset = metricsClientset
+	return c, nil
+}
+
+// NewClientForClientsets creates a Kubernetes client on existing clientsets, such as
+// fakes. Without a REST config it cannot exec into pods.
+func NewClientForClientsets(clientset kubernetes.Interface, metricsClientset metricsclientset.Interface) *Client {
+	return &Client{clientset: clientset, metricsClientset: metricsClientset}
 }
 
 // GetPod retrieves a pod by name and namespace
@@ -61,8 +112,41 @@ func (c *Client) GetPod(ctx context.Context, namespace, name string) (*corev1.Po
 	return c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
-// GetPodContainerStates analyzes container states in a pod
-func (c *Client) GetPodContainerStates(ctx context.Context, pod *corev1.Pod) ([]types.ContainerState, error) {
+// GetResourceQuotas returns the ResourceQuotas of a namespace
+func (c *Client) GetResourceQuotas(ctx context.Context, namespace string) ([]corev1.ResourceQuota, error) {
+	quotas, err := c.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+	return quotas.Items, nil
+}
+
+// ListPodsOnNode returns the pods scheduled on a node across all namespaces
+func (c *Client) ListPodsOnNode(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	pods, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %w", nodeName, err)
+	}
+	return pods.Items, nil
+}
+
+// ListMigratedPods returns the pods the orchestrator created in a namespace for an
+// original pod, found by their original pod label
+func (c *Client) ListMigratedPods(ctx context.Context, namespace, originalPod string) ([]corev1.Pod, error) {
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{originalPodLabel: originalPod}.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods migrated from %s/%s: %w", namespace, originalPod, err)
+	}
+	return pods.Items, nil
+}
+
+// GetPodContainerStates analyzes container states in a pod, deciding which to migrate
+// according to the classification policy
+func (c *Client) GetPodContainerStates(ctx context.Context, pod *corev1.Pod, policy types.ClassificationPolicy) ([]types.ContainerState, error) {
 	var states []types.ContainerState
 
 	for _, container := range pod.Spec.Containers {
@@ -82,20 +166,55 @@ func (c *Client) GetPodContainerStates(ctx context.Context, pod *corev1.Pod) ([]
 		}
 
 		// Determine container state based on Kubernetes container state
+		var observed string
 		if containerStatus.State.Waiting != nil {
 			state.State = "waiting"
-			state.ShouldMigrate = false // Don't migrate waiting containers
+			state.ShouldMigrate = policy.MigrateWaiting
+			observed = "waiting"
+			if containerStatus.State.Waiting.Reason != "" {
+				observed = fmt.Sprintf("waiting (%s)", containerStatus.State.Waiting.Reason)
+			}
 		} else if containerStatus.State.Running != nil {
 			state.State = "running"
-			state.ShouldMigrate = true // Migrate running containers
+			state.ShouldMigrate = policy.MigrateRunning
+			observed = "running"
 		} else if containerStatus.State.Terminated != nil {
-			if containerStatus.State.Terminated.ExitCode == 0 {
+			exitCode := containerStatus.State.Terminated.ExitCode
+			if policy.IsSuccessExitCode(exitCode) {
+				// Recreating a one-shot container on the target would run it, and its
+				// side effects, again
 				state.State = "completed"
-				state.ShouldMigrate = false // Don't migrate completed containers
+				state.ShouldMigrate = policy.MigrateCompleted
+				observed = fmt.Sprintf("completed with exit code %d", exitCode)
+				if !state.ShouldMigrate && policy.MigrateCompletedRestartAlways &&
+					pod.Spec.RestartPolicy == corev1.RestartPolicyAlways {
+					state.ShouldMigrate = true
+					observed = "completed with restartPolicy Always"
+				}
+				if !state.ShouldMigrate && policy.MigratesCompletedContainer(container.Name) {
+					state.ShouldMigrate = true
+					observed += ", listed in migrate_completed_containers"
+				}
 			} else {
 				state.State = "failed"
-				state.ShouldMigrate = true // Migrate failed containers for retry
+				state.ShouldMigrate = policy.MigrateFailed
+				observed = fmt.Sprintf("failed with exit code %d", exitCode)
 			}
+		} else {
+			state.ShouldMigrate = policy.MigrateUnreported
+			observed = "no status reported"
+		}
+
+		if pattern, ok := policy.SidecarPattern(container.Image); ok {
+			state.ShouldMigrate = false
+			state.Reason = fmt.Sprintf("%s, sidecar image matches %q, skipped", observed, pattern)
+		} else if state.ShouldMigrate {
+			state.Reason = observed + ", migrate"
+			if state.State == "failed" {
+				state.Reason += " for retry"
+			}
+		} else {
+			state.Reason = observed + ", skipped"
 		}
 
 		states = append(states, state)
@@ -104,8 +223,9 @@ func (c *Client) GetPodContainerStates(ctx context.Context, pod *corev1.Pod) ([]
 	return states, nil
 }
 
-// CreatePersistentVolumeClaim creates a PVC for checkpointing container state
-func (c *Client) CreatePersistentVolumeClaim(ctx context.Context, namespace, name string, size string) error {
+// CreatePersistentVolumeClaim creates a PVC for checkpointing container state. A
+// non-empty selectedNode is recorded for WaitForFirstConsumer provisioners.
+func (c *Client) CreatePersistentVolumeClaim(ctx context.Context, namespace, name string, size resource.Quantity, accessMode corev1.PersistentVolumeAccessMode, selectedNode string, tracking *ResourceTracking) error {
 	pvc := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -117,20 +237,97 @@ func (c *Client) CreatePersistentVolumeClaim(ctx context.Context, namespace, nam
 		},
 		Spec: corev1.PersistentVolumeClaimSpec{
 			AccessModes: []corev1.PersistentVolumeAccessMode{
-				corev1.ReadWriteOnce,
+				accessMode,
 			},
 			Resources: corev1.ResourceRequirements{
 				Requests: corev1.ResourceList{
-					corev1.ResourceStorage: resource.MustParse(size),
+					corev1.ResourceStorage: size,
 				},
 			},
 		},
 	}
+	if selectedNode != "" {
+		pvc.Annotations = map[string]string{SelectedNodeAnnotation: selectedNode}
+	}
+	tracking.apply(&pvc.ObjectMeta)
 
 	_, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, pvc, metav1.CreateOptions{})
 	return err
 }
 
+// GetDefaultStorageClass returns the storage class marked as the cluster default,
+// or nil if there is none
+func (c *Client) GetDefaultStorageClass(ctx context.Context) (*storagev1.StorageClass, error) {
+	classes, err := c.clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range classes.Items {
+		annotations := classes.Items[i].Annotations
+		if annotations["storageclass.kubernetes.io/is-default-class"] == "true" ||
+			annotations["storageclass.beta.kubernetes.io/is-default-class"] == "true" {
+			return &classes.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetPodDisruptionBudgetsForPod returns the PodDisruptionBudgets in the pod's namespace that select the pod
+func (c *Client) GetPodDisruptionBudgetsForPod(ctx context.Context, pod *corev1.Pod) ([]policyv1.PodDisruptionBudget, error) {
+	pdbList, err := c.clientset.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+
+	var matching []policyv1.PodDisruptionBudget
+	for _, pdb := range pdbList.Items {
+		// A nil selector matches nothing, an empty one matches every pod in the namespace
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			matching = append(matching, pdb)
+		}
+	}
+
+	return matching, nil
+}
+
+// checkpointSelector matches the PVCs created by CreatePersistentVolumeClaim
+const checkpointSelector = "app=ai-storage-orchestrator,component=migration-checkpoint"
+
+// ListCheckpointPVCs returns the checkpoint PVCs of all namespaces
+func (c *Client) ListCheckpointPVCs(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+	pvcs, err := c.clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: checkpointSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pvcs.Items, nil
+}
+
+// AnnotatePersistentVolumeClaim sets an annotation on a PVC
+func (c *Client) AnnotatePersistentVolumeClaim(ctx context.Context, namespace, name, key, value string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// DeletePersistentVolumeClaim deletes a PVC
+func (c *Client) DeletePersistentVolumeClaim(ctx context.Context, namespace, name string) error {
+	return c.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
 // DeletePod deletes a pod gracefully
 func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
 	gracePeriod := int64(30) // 30 seconds grace period
@@ -140,30 +337,390 @@ func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
 	})
 }
 
-// CreateOptimizedPod creates a new pod with only running containers
-func (c *Client) CreateOptimizedPod(ctx context.Context, originalPod *corev1.Pod, targetNode string, containerStates []types.ContainerState, checkpointPVC string) (*corev1.Pod, error) {
+// RecreatePod creates a pod again from a fetched copy of it that was deleted, under
+// its own name. The scheduler places it anew.
+func (c *Client) RecreatePod(ctx context.Context, pod *corev1.Pod) error {
+	recreated := RecreatablePod(pod)
+	_, err := c.clientset.CoreV1().Pods(recreated.Namespace).Create(ctx, recreated, metav1.CreateOptions{})
+	return err
+}
+
+// RecreatablePod returns a copy of a fetched pod that can be created again under its
+// own name: the fields set by the API server, the scheduler and the kubelet are cleared
+func RecreatablePod(pod *corev1.Pod) *corev1.Pod {
+	recreated := pod.DeepCopy()
+	sanitizePodForRecreate(recreated)
+	recreated.Name = pod.Name
+	return recreated
+}
+
+// DeletePodWithOwnGracePeriod deletes a pod with the termination grace period of its
+// spec, so that PreStop hooks longer than the default 30s are not cut short
+func (c *Client) DeletePodWithOwnGracePeriod(ctx context.Context, namespace, name string) error {
+	return c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// ForceDeletePod deletes a pod without a grace period, removing it from the API even
+// when the kubelet of its node cannot confirm the termination
+func (c *Client) ForceDeletePod(ctx context.Context, namespace, name string) error {
+	gracePeriod := int64(0)
+	return c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+	})
+}
+
+// CreateOptimizedPod creates a new pod with only running containers. An empty
+// newPodName derives the name from the original pod. With useScheduler the pod is
+// bound to the target node through node affinity, so the scheduler still enforces
+// constraints such as topology spread; otherwise nodeName is set directly, which
+// bypasses them.
+func (c *Client) CreateOptimizedPod(ctx context.Context, originalPod *corev1.Pod, newPodName, targetNode string, useScheduler bool, containerStates []types.ContainerState, checkpointPVC string, tracking *ResourceTracking) (*corev1.Pod, error) {
+	newPod := buildOptimizedPod(originalPod, newPodName, targetNode, containerStates, checkpointPVC)
+	tracking.apply(&newPod.ObjectMeta)
+	
+	if useScheduler {
+		requireNode(&newPod.Spec, targetNode)
+	} else {
+		// Set node selector for target node
+		newPod.Spec.NodeName = targetNode
+	}
+
+	return c.clientset.CoreV1().Pods(newPod.Namespace).Create(ctx, newPod, metav1.CreateOptions{})
+}
+
+// deploymentLabel selects the pods of a Deployment wrapping a migrated pod
+const deploymentLabel = "migration.ai-storage/deployment"
+
+// CreateOptimizedDeployment wraps the optimized pod in a single-replica Deployment
+// pinned to the target node by node affinity, so the migrated workload is recreated
+// if its pod dies. An empty name derives it from the original pod.
+func (c *Client) CreateOptimizedDeployment(ctx context.Context, originalPod *corev1.Pod, name, targetNode string, containerStates []types.ContainerState, checkpointPVC string, tracking *ResourceTracking) (*appsv1.Deployment, error) {
+	template := buildOptimizedPod(originalPod, name, targetNode, containerStates, checkpointPVC)
+	name = template.Name
+
+	// The original pod's controller labels would make its ReplicaSet fight over the pods
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	template.Labels[deploymentLabel] = labelValue(name)
+	template.Name = ""
+
+	// Deployments only run pods that are always restarted
+	template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	requireNode(&template.Spec, targetNode)
+
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: originalPod.Namespace,
+			Labels: map[string]string{
+				"app":                               "ai-storage-orchestrator",
+				"migration.ai-storage/original-pod": originalPod.Name,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{deploymentLabel: labelValue(name)},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: template.ObjectMeta,
+				Spec:       template.Spec,
+			},
+		},
+	}
+	// Only the Deployment is tracked; its pods are cleaned up with it
+	tracking.apply(&deployment.ObjectMeta)
+
+	return c.clientset.AppsV1().Deployments(originalPod.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+}
+
+// DeploymentPodSelector returns the labels selecting the pods of a Deployment created
+// by CreateOptimizedDeployment
+func DeploymentPodSelector(name string) map[string]string {
+	return map[string]string{deploymentLabel: labelValue(name)}
+}
+
+// WaitForDeploymentPod waits until a pod of a Deployment created by
+// CreateOptimizedDeployment exists and returns its name
+func (c *Client) WaitForDeploymentPod(ctx context.Context, namespace, name string, timeout time.Duration) (string, error) {
+	watchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	selector := labels.SelectorFromSet(labels.Set{deploymentLabel: labelValue(name)})
+	if c.pods != nil {
+		var podName string
+		err := c.pods.wait(watchCtx, namespace, func() (bool, error) {
+			for _, pod := range c.pods.list(namespace, selector) {
+				if pod.DeletionTimestamp == nil {
+					podName = pod.Name
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("timeout waiting for deployment %s to create a pod", name)
+		}
+		return podName, nil
+	}
+
+	watch, err := c.clientset.CoreV1().Pods(namespace).Watch(watchCtx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to watch deployment pods: %w", err)
+	}
+	defer watch.Stop()
+
+	for event := range watch.ResultChan() {
+		if pod, ok := event.Object.(*corev1.Pod); ok && pod.DeletionTimestamp == nil {
+			return pod.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("timeout waiting for deployment %s to create a pod", name)
+}
+
+// LabelPod sets a label on a pod
+func (c *Client) LabelPod(ctx context.Context, namespace, name, key, value string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.clientset.CoreV1().Pods(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// GetService returns a Service by name
+func (c *Client) GetService(ctx context.Context, namespace, name string) (*corev1.Service, error) {
+	return c.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// CreateClusterIPService creates a ClusterIP Service with the given selector and ports
+func (c *Client) CreateClusterIPService(ctx context.Context, namespace, name string, selector map[string]string, ports []corev1.ServicePort) (*corev1.Service, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app":       "ai-storage-orchestrator",
+				"component": "migration-service",
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+	return c.clientset.CoreV1().Services(namespace).Create(ctx, service, metav1.CreateOptions{})
+}
+
+// SetServiceSelector replaces the selector of an existing Service
+func (c *Client) SetServiceSelector(ctx context.Context, service *corev1.Service, selector map[string]string) error {
+	updated := service.DeepCopy()
+	updated.Spec.Selector = selector
+	_, err := c.clientset.CoreV1().Services(service.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// DeleteService deletes a Service
+func (c *Client) DeleteService(ctx context.Context, namespace, name string) error {
+	return c.clientset.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// labelValue shortens an object name to the 63 characters allowed in label values
+func labelValue(name string) string {
+	if len(name) <= 63 {
+		return name
+	}
+	return strings.TrimRight(name[:63], "-.")
+}
+
+// DeleteDeployment deletes a Deployment together with its ReplicaSets and pods
+func (c *Client) DeleteDeployment(ctx context.Context, namespace, name string) error {
+	propagation := metav1.DeletePropagationBackground
+	return c.clientset.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+}
+
+// NodeHasImage reports whether the node lists the image among the images it has
+// pulled. Short Docker Hub references are matched against their qualified names.
+func NodeHasImage(node *corev1.Node, image string) bool {
+	candidates := []string{image}
+	name := image
+	if !strings.Contains(image, "@") && !strings.Contains(image[strings.LastIndex(image, "/")+1:], ":") {
+		name = image + ":latest"
+		candidates = append(candidates, name)
+	}
+	if first := strings.SplitN(name, "/", 2)[0]; !strings.ContainsAny(first, ".:") && first != "localhost" {
+		if !strings.Contains(name, "/") {
+			candidates = append(candidates, "docker.io/library/"+name)
+		} else {
+			candidates = append(candidates, "docker.io/"+name)
+		}
+	}
+
+	for _, nodeImage := range node.Status.Images {
+		for _, nodeName := range nodeImage.Names {
+			for _, candidate := range candidates {
+				if nodeName == candidate {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// CreateImagePullPod creates a pod bound to node with one container per image, so the
+// kubelet pulls them. The containers run "true" instead of their entrypoint; whether
+// that succeeds does not matter once the image is present.
+func (c *Client) CreateImagePullPod(ctx context.Context, originalPod *corev1.Pod, name, node string, images []string) (*corev1.Pod, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: originalPod.Namespace,
+			Labels: map[string]string{
+				"app":            "ai-storage-orchestrator",
+				"component":      "image-pre-pull",
+				originalPodLabel: originalPod.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:         node,
+			RestartPolicy:    corev1.RestartPolicyNever,
+			ImagePullSecrets: originalPod.Spec.ImagePullSecrets,
+			Tolerations:      originalPod.Spec.Tolerations,
+		},
+	}
+	for i, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name:            fmt.Sprintf("pull-%d", i),
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"true"},
+		})
+	}
+
+	return c.clientset.CoreV1().Pods(originalPod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+}
+
+// imagePullFailures are waiting reasons meaning the kubelet gave up pulling an image
+var imagePullFailures = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// WaitForImagePulls waits until no container of a pod created by CreateImagePullPod
+// is still pulling, and returns the images that could not be pulled
+func (c *Client) WaitForImagePulls(ctx context.Context, namespace, name string, timeout time.Duration) ([]string, error) {
+	pullCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var failed []string
+	var err error
+	if c.pods != nil {
+		err = c.pods.wait(pullCtx, namespace, func() (bool, error) {
+			pod, exists := c.pods.get(namespace, name)
+			if !exists {
+				return false, nil
+			}
+			var done bool
+			done, failed = imagePullStatus(pod)
+			return done, nil
+		})
+	} else {
+		err = wait.PollUntilContextCancel(pullCtx, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+			pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil
+			}
+			var done bool
+			done, failed = imagePullStatus(pod)
+			return done, nil
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("timeout waiting for images to be pulled: %w", err)
+	}
+	return failed, nil
+}
+
+// imagePullStatus reports whether every container of a pre-pull pod is past pulling
+// its image, and the images that failed to pull
+func imagePullStatus(pod *corev1.Pod) (bool, []string) {
+	if len(pod.Status.ContainerStatuses) < len(pod.Spec.Containers) {
+		return false, nil
+	}
+
+	var failed []string
+	for _, status := range pod.Status.ContainerStatuses {
+		waiting := status.State.Waiting
+		switch {
+		case waiting == nil:
+		case imagePullFailures[waiting.Reason]:
+			failed = append(failed, status.Image)
+		case waiting.Reason == "ContainerCreating" || waiting.Reason == "PodInitializing":
+			return false, nil
+		}
+	}
+	return true, failed
+}
+
+// requireNode replaces the pod's node affinity with a hard requirement for the node,
+// leaving placement to the scheduler so the pod's other constraints are honored
+func requireNode(spec *corev1.PodSpec, node string) {
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	spec.Affinity.NodeAffinity = &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchFields: []corev1.NodeSelectorRequirement{{
+					Key:      "metadata.name",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{node},
+				}},
+			}},
+		},
+	}
+}
+
+// TargetNodeLabel names the node an optimized pod was created for
+const TargetNodeLabel = "migration.ai-storage/target-node"
+
+// buildOptimizedPod copies the original pod keeping only the containers to migrate
+// and mounting the checkpoint PVC, if any. Node placement is left to the caller.
+func buildOptimizedPod(originalPod *corev1.Pod, newPodName, targetNode string, containerStates []types.ContainerState, checkpointPVC string) *corev1.Pod {
 	// Create new pod spec based on original but optimized
 	newPod := originalPod.DeepCopy()
 	
-	// Clear status and metadata that should not be copied
-	newPod.Status = corev1.PodStatus{}
-	newPod.ObjectMeta = metav1.ObjectMeta{
-		Name:      fmt.Sprintf("%s-migrated-%d", originalPod.Name, time.Now().Unix()),
-		Namespace: originalPod.Namespace,
-		Labels:    originalPod.Labels,
+	// Strip server-populated fields so the object can be created again
+	sanitizePodForRecreate(newPod)
+	newPod.Name = newPodName
+	if newPod.Name == "" {
+		newPod.Name = fmt.Sprintf("%s-migrated-%d", originalPod.Name, time.Now().Unix())
 	}
 	
 	// Add migration labels
 	if newPod.Labels == nil {
 		newPod.Labels = make(map[string]string)
 	}
-	newPod.Labels["migration.ai-storage/original-pod"] = originalPod.Name
-	newPod.Labels["migration.ai-storage/target-node"] = targetNode
+	newPod.Labels[originalPodLabel] = originalPod.Name
+	newPod.Labels[TargetNodeLabel] = targetNode
+	// A pod created by an earlier migration is not part of this one
+	delete(newPod.Labels, MigrationIDLabel)
 	
-	// Set node selector for target node
-	newPod.Spec.NodeName = targetNode
-	
-	// Filter containers - only include those that should be migrated
+	// Filter containers - only include those that should be migrated. Kept containers
+	// are copied whole, so their PostStart and PreStop hooks are preserved.
 	var optimizedContainers []corev1.Container
 	for _, container := range newPod.Spec.Containers {
 		for _, state := range containerStates {
@@ -195,7 +752,26 @@ func (c *Client) CreateOptimizedPod(ctx context.Context, originalPod *corev1.Pod
 		})
 	}
 
-	return c.clientset.CoreV1().Pods(newPod.Namespace).Create(ctx, newPod, metav1.CreateOptions{})
+	return newPod
+}
+
+// sanitizePodForRecreate clears the fields of a fetched pod that are populated by the
+// API server, the scheduler or owning controllers, so that the copy can be submitted as
+// a brand new pod. Name and node placement are left for the caller to set.
+func sanitizePodForRecreate(pod *corev1.Pod) {
+	pod.ObjectMeta = metav1.ObjectMeta{
+		Namespace:   pod.Namespace,
+		Labels:      pod.Labels,
+		Annotations: pod.Annotations,
+	}
+	// kubectl's last-applied state describes the original object, not the migrated copy
+	delete(pod.Annotations, corev1.LastAppliedConfigAnnotation)
+
+	// Scheduler and kubelet owned fields
+	pod.Spec.NodeName = ""
+	pod.Spec.EphemeralContainers = nil // cannot be set on create, only via the subresource
+
+	pod.Status = corev1.PodStatus{}
 }
 
 // GetPodMetrics retrieves CPU and memory metrics for a pod
@@ -222,30 +798,426 @@ func (c *Client) GetPodMetrics(ctx context.Context, namespace, name string) (*ty
 	}, nil
 }
 
-// WaitForPodReady waits for a pod to be in Ready state
-func (c *Client) WaitForPodReady(ctx context.Context, namespace, name string, timeout time.Duration) error {
-	watchCtx, cancel := context.WithTimeout(ctx, timeout)
+// GetContainerMetrics retrieves the CPU and memory usage of each container of a pod
+func (c *Client) GetContainerMetrics(ctx context.Context, namespace, name string) (map[string]types.ResourceUsage, error) {
+	podMetrics, err := c.metricsClientset.MetricsV1beta1().PodMetricses(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod metrics: %w", err)
+	}
+
+	usage := make(map[string]types.ResourceUsage, len(podMetrics.Containers))
+	for _, container := range podMetrics.Containers {
+		cpu := container.Usage[corev1.ResourceCPU]
+		memory := container.Usage[corev1.ResourceMemory]
+		usage[container.Name] = types.ResourceUsage{
+			CPUUsage:    float64(cpu.MilliValue()) / 1000.0,
+			MemoryUsage: memory.Value(),
+			Timestamp:   podMetrics.Timestamp.Time,
+		}
+	}
+	return usage, nil
+}
+
+// GetNodeMetrics retrieves CPU and memory usage for a node
+func (c *Client) GetNodeMetrics(ctx context.Context, name string) (*types.ResourceUsage, error) {
+	nodeMetrics, err := c.metricsClientset.MetricsV1beta1().NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node metrics: %w", err)
+	}
+
+	cpu := nodeMetrics.Usage[corev1.ResourceCPU]
+	memory := nodeMetrics.Usage[corev1.ResourceMemory]
+
+	return &types.ResourceUsage{
+		CPUUsage:    float64(cpu.MilliValue()) / 1000.0, // Convert millicores to cores
+		MemoryUsage: memory.Value(),
+		Timestamp:   nodeMetrics.Timestamp.Time,
+	}, nil
+}
+
+// GetServerVersion returns the version reported by the API server
+func (c *Client) GetServerVersion() (*k8sversion.Info, error) {
+	return c.clientset.Discovery().ServerVersion()
+}
+
+// GetServerGroupVersions returns the API group versions served by the cluster, such as
+// "policy/v1" or "metrics.k8s.io/v1beta1"
+func (c *Client) GetServerGroupVersions() (map[string]bool, error) {
+	groups, err := c.clientset.Discovery().ServerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	served := make(map[string]bool)
+	for _, group := range groups.Groups {
+		for _, groupVersion := range group.Versions {
+			served[groupVersion.GroupVersion] = true
+		}
+	}
+	return served, nil
+}
+
+// ListNodes returns the nodes matching a label selector
+func (c *Client) ListNodes(ctx context.Context, labelSelector string) ([]corev1.Node, error) {
+	nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nodes.Items, nil
+}
+
+// GetNode returns a node by name
+func (c *Client) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
+	return c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetNodeAllocatable returns the CPU (cores) and memory (bytes) a node can allocate to pods
+func (c *Client) GetNodeAllocatable(ctx context.Context, name string) (float64, int64, error) {
+	node, err := c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get node: %w", err)
+	}
+
+	cpu := node.Status.Allocatable[corev1.ResourceCPU]
+	memory := node.Status.Allocatable[corev1.ResourceMemory]
+	return float64(cpu.MilliValue()) / 1000.0, memory.Value(), nil
+}
+
+// Errors returned by WaitForPodReady when the pod can no longer become ready, as
+// opposed to still not being ready when the timeout expires
+var (
+	ErrPodDeleted    = errors.New("pod was deleted while waiting for it to become ready")
+	ErrPodTerminated = errors.New("pod terminated while waiting for it to become ready")
+)
+
+// WaitForPodReady waits until every given pod condition type is True. With no
+// conditions it waits for the standard Ready condition. The pod is watched so
+// readiness is seen immediately; if the watch cannot be opened or ends early, the
+// pod is polled every pollInterval for the rest of the timeout. A pod that is
+// deleted or reaches a terminal phase, e.g. when evicted, fails the wait at once
+// with ErrPodDeleted or ErrPodTerminated. Once StartPodInformer has run, the shared
+// informer's cache is used instead of a watch of its own. Containers named in
+// tolerated may stay unready: Ready and ContainersReady count as True once every
+// other container is ready (see ReadyExcept).
+func (c *Client) WaitForPodReady(ctx context.Context, namespace, name string, timeout, pollInterval time.Duration, conditions, tolerated []string) error {
+	if len(conditions) == 0 {
+		conditions = []string{string(corev1.PodReady)}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	watch, err := c.clientset.CoreV1().Pods(namespace).Watch(watchCtx, metav1.ListOptions{
+	if c.pods != nil {
+		pending, err := c.pods.waitForPodConditions(waitCtx, namespace, name, conditions, tolerated)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrPodDeleted) || errors.Is(err, ErrPodTerminated) {
+			return err
+		}
+		return fmt.Errorf("timeout waiting for pod conditions to become True: %s", strings.Join(pending, ", "))
+	}
+
+	pending, ready, err := c.watchPodConditions(waitCtx, namespace, name, conditions, tolerated)
+	if ready {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if waitCtx.Err() == nil {
+		var gone error
+		err := wait.PollUntilContextCancel(waitCtx, pollInterval, true, func(ctx context.Context) (bool, error) {
+			pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				gone = ErrPodDeleted
+				return false, gone
+			}
+			if err != nil {
+				return false, nil // transient errors are retried until the timeout
+			}
+			if gone = podTerminated(pod); gone != nil {
+				return false, gone
+			}
+			pending = PendingPodConditions(pod, conditions, tolerated)
+			return len(pending) == 0, nil
+		})
+		if err == nil {
+			return nil
+		}
+		if gone != nil {
+			return gone
+		}
+	}
+
+	return fmt.Errorf("timeout waiting for pod conditions to become True: %s", strings.Join(pending, ", "))
+}
+
+// watchPodConditions watches the pod until the conditions are True, the context ends,
+// or the watch is closed, returning the conditions still pending. The error is set
+// when the pod was deleted or terminated.
+func (c *Client) watchPodConditions(ctx context.Context, namespace, name string, conditions, tolerated []string) ([]string, bool, error) {
+	watcher, err := c.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to watch pod: %w", err)
+		return conditions, false, nil
 	}
-	defer watch.Stop()
+	defer watcher.Stop()
 
-	for event := range watch.ResultChan() {
-		if pod, ok := event.Object.(*corev1.Pod); ok {
-			for _, condition := range pod.Status.Conditions {
-				if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
-					return nil
+	pending := conditions
+	for {
+		var event watch.Event
+		var open bool
+		select {
+		case <-ctx.Done():
+			return pending, false, nil
+		case event, open = <-watcher.ResultChan():
+		}
+		if !open {
+			return pending, false, nil
+		}
+		pod, ok := event.Object.(*corev1.Pod)
+		if !ok {
+			continue
+		}
+		if event.Type == watch.Deleted {
+			return pending, false, ErrPodDeleted
+		}
+		if err := podTerminated(pod); err != nil {
+			return pending, false, err
+		}
+		pending = PendingPodConditions(pod, conditions, tolerated)
+		if len(pending) == 0 {
+			return nil, true, nil
+		}
+	}
+}
+
+// podTerminated returns ErrPodTerminated, with the kubelet's reason such as Evicted,
+// once the pod reached a phase it cannot become ready from
+func podTerminated(pod *corev1.Pod) error {
+	if pod.Status.Phase != corev1.PodFailed && pod.Status.Phase != corev1.PodSucceeded {
+		return nil
+	}
+	detail := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		detail += ": " + pod.Status.Reason
+	}
+	if pod.Status.Message != "" {
+		detail += ": " + pod.Status.Message
+	}
+	return fmt.Errorf("%w (%s)", ErrPodTerminated, detail)
+}
+
+// PendingPodConditions returns the wanted condition types that are not True on the
+// pod, annotated with their current status and reason when known. With none wanted
+// it checks the standard Ready condition. Readiness held back only by tolerated
+// containers counts as True.
+func PendingPodConditions(pod *corev1.Pod, wanted, tolerated []string) []string {
+	if len(wanted) == 0 {
+		wanted = []string{string(corev1.PodReady)}
+	}
+	var pending []string
+	for _, conditionType := range wanted {
+		if len(tolerated) > 0 && readinessCondition(conditionType) && ReadyExcept(pod, tolerated) {
+			continue
+		}
+		found := false
+		for _, condition := range pod.Status.Conditions {
+			if string(condition.Type) != conditionType {
+				continue
+			}
+			found = true
+			if condition.Status != corev1.ConditionTrue {
+				detail := fmt.Sprintf("%s (%s", conditionType, condition.Status)
+				if condition.Reason != "" {
+					detail += ": " + condition.Reason
 				}
+				pending = append(pending, detail+")")
+			}
+			break
+		}
+		if !found {
+			pending = append(pending, conditionType+" (not reported)")
+		}
+	}
+	return pending
+}
+
+// readinessCondition reports whether a condition type is derived from container readiness
+func readinessCondition(conditionType string) bool {
+	return conditionType == string(corev1.PodReady) || conditionType == string(corev1.ContainersReady)
+}
+
+// ReadyExcept reports whether a pod is ready when the tolerated containers are left
+// out: every other container reports ready and, as the kubelet requires for Ready,
+// every readiness gate condition is True
+func ReadyExcept(pod *corev1.Pod, tolerated []string) bool {
+	skip := make(map[string]bool, len(tolerated))
+	for _, name := range tolerated {
+		skip[name] = true
+	}
+	statuses := make(map[string]bool, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status.Ready
+	}
+	for _, container := range pod.Spec.Containers {
+		if !skip[container.Name] && !statuses[container.Name] {
+			return false
+		}
+	}
+	for _, gate := range pod.Spec.ReadinessGates {
+		met := false
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == gate.ConditionType && condition.Status == corev1.ConditionTrue {
+				met = true
+				break
 			}
 		}
+		if !met {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainerReadiness reports whether each container of a pod is ready, with its
+// restarts and current state
+func ContainerReadiness(pod *corev1.Pod) []types.ContainerReadiness {
+	statuses := make(map[string]corev1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	readiness := make([]types.ContainerReadiness, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		status := statuses[container.Name]
+		entry := types.ContainerReadiness{
+			Name:         container.Name,
+			Ready:        status.Ready,
+			RestartCount: status.RestartCount,
+			State:        "waiting",
+		}
+		switch {
+		case status.State.Running != nil:
+			entry.State = "running"
+		case status.State.Terminated != nil:
+			entry.State = "terminated"
+			entry.Reason = status.State.Terminated.Reason
+		case status.State.Waiting != nil:
+			entry.Reason = status.State.Waiting.Reason
+		}
+		readiness = append(readiness, entry)
+	}
+	return readiness
+}
+
+// UnreadyContainers returns the containers of a pod among names that do not report ready
+func UnreadyContainers(pod *corev1.Pod, names []string) []string {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	ready := make(map[string]bool, len(pod.Status.ContainerStatuses))
+	for _, status := range pod.Status.ContainerStatuses {
+		ready[status.Name] = status.Ready
+	}
+	var unready []string
+	for _, container := range pod.Spec.Containers {
+		if wanted[container.Name] && !ready[container.Name] {
+			unready = append(unready, container.Name)
+		}
+	}
+	return unready
+}
+
+// maxExecOutputBytes bounds how much of each exec output stream is kept
+const maxExecOutputBytes = 4096
+
+// ExecInPod runs a command in a container via the exec subresource and returns its
+// combined output and exit code. A non-zero exit code is not reported as an error.
+func (c *Client) ExecInPod(ctx context.Context, namespace, podName, container string, command []string) (string, int, error) {
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	output := &limitedBuffer{limit: maxExecOutputBytes}
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: output,
+		Stderr: output,
+	})
+	if err != nil {
+		var exitErr utilexec.CodeExitError
+		if errors.As(err, &exitErr) {
+			return output.String(), exitErr.ExitStatus(), nil
+		}
+		return output.String(), 0, fmt.Errorf("failed to exec in pod: %w", err)
+	}
+
+	return output.String(), 0, nil
+}
+
+// GetContainerLogs returns up to tailLines of a container's most recent log output,
+// truncated from the front so that at most maxBytes are kept. The boolean reports
+// whether truncation happened.
+func (c *Client) GetContainerLogs(ctx context.Context, namespace, podName, container string, tailLines int64, maxBytes int) (string, bool, error) {
+	raw, err := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get logs of container %s: %w", container, err)
+	}
+
+	if len(raw) > maxBytes {
+		return string(raw[len(raw)-maxBytes:]), true, nil
+	}
+	return string(raw), false, nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest.
+// It is safe for concurrent use, as exec copies stdout and stderr into it from
+// separate goroutines.
+type limitedBuffer struct {
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if remaining := b.limit - b.buf.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.buf.Write(p[:remaining])
+		} else {
+			b.buf.Write(p)
+		}
 	}
+	return len(p), nil
+}
 
-	return fmt.Errorf("timeout waiting for pod to be ready")
+func (b *limitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
 }
 
 // GetWorkloadReplicas gets the current replica count for a workload (Deployment, StatefulSet, ReplicaSet)
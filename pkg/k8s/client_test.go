@@ -0,0 +1,333 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// Exec copies stdout and stderr into one buffer from two goroutines
+func TestLimitedBufferConcurrentWrites(t *testing.T) {
+	buffer := &limitedBuffer{limit: 1000}
+	var wg sync.WaitGroup
+	for _, stream := range []string{"o", "e"} {
+		wg.Add(1)
+		go func(chunk string) {
+			defer wg.Done()
+			for i := 0; i < 400; i++ {
+				if n, err := buffer.Write([]byte(chunk)); n != 1 || err != nil {
+					t.Errorf("Write = %d, %v; want 1, nil", n, err)
+				}
+			}
+		}(stream)
+	}
+	wg.Wait()
+
+	output := buffer.String()
+	if len(output) != 800 || strings.Count(output, "o") != 400 || strings.Count(output, "e") != 400 {
+		t.Errorf("kept %d bytes (%d stdout, %d stderr), want every byte of both streams",
+			len(output), strings.Count(output, "o"), strings.Count(output, "e"))
+	}
+}
+
+func TestLimitedBufferDiscardsPastLimit(t *testing.T) {
+	buffer := &limitedBuffer{limit: 4}
+	for _, chunk := range []string{"abc", "def", "ghi"} {
+		if n, _ := buffer.Write([]byte(chunk)); n != len(chunk) {
+			t.Errorf("Write(%q) = %d, want %d", chunk, n, len(chunk))
+		}
+	}
+	if got := buffer.String(); got != "abcd" {
+		t.Errorf("String() = %q, want %q", got, "abcd")
+	}
+}
+
+// fetchedPod is a pod of a ReplicaSet as the API server returns it once scheduled and
+// running, with every field the server, scheduler, kubelet and kubectl fill in
+func fetchedPod() *corev1.Pod {
+	controller := true
+	created := metav1.NewTime(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "trainer-7d9f8b6c4-x2k9p",
+			GenerateName:      "trainer-7d9f8b6c4-",
+			Namespace:         "ml",
+			UID:               "3f1c2a4e-9b7d-4c6a-8e2f-1a2b3c4d5e6f",
+			ResourceVersion:   "184467",
+			Generation:        1,
+			CreationTimestamp: created,
+			Labels:            map[string]string{"app": "trainer", "pod-template-hash": "7d9f8b6c4"},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Pod"}`,
+				"prometheus.io/scrape":             "true",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "trainer-7d9f8b6c4",
+				UID: "9a8b7c6d-5e4f-3a2b-1c0d-e9f8a7b6c5d4", Controller: &controller,
+			}},
+			Finalizers: []string{"example.com/protect"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate,
+				APIVersion: "v1", Time: &created, FieldsType: "FieldsV1",
+			}},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:           "node-a",
+			ServiceAccountName: "default",
+			Containers: []corev1.Container{
+				{
+					Name:  "trainer",
+					Image: "registry.example.com/trainer:1.4",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					},
+					VolumeMounts: []corev1.VolumeMount{{Name: "kube-api-access-h7m2q", MountPath: "/var/run/secrets/kubernetes.io/serviceaccount", ReadOnly: true}},
+				},
+				{Name: "exporter", Image: "registry.example.com/exporter:0.9"},
+			},
+			EphemeralContainers: []corev1.EphemeralContainer{{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debugger-8xq2z", Image: "busybox"},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "kube-api-access-h7m2q",
+				VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}}},
+				}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      "10.244.1.17",
+			HostIP:     "192.168.0.11",
+			QOSClass:   corev1.PodQOSBurstable,
+			StartTime:  &created,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "trainer", Ready: true, ContainerID: "containerd://4b1e"},
+				{Name: "exporter", Ready: true, ContainerID: "containerd://9c2f"},
+			},
+		},
+	}
+}
+
+// rejectServerSetFields fails pod creates the way the API server does for objects
+// carrying fields only the server, scheduler or kubelet may set
+func rejectServerSetFields(clientset *fake.Clientset) {
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		var invalid []string
+		if pod.ResourceVersion != "" {
+			invalid = append(invalid, "metadata.resourceVersion")
+		}
+		if pod.UID != "" {
+			invalid = append(invalid, "metadata.uid")
+		}
+		if len(pod.Spec.EphemeralContainers) > 0 {
+			invalid = append(invalid, "spec.ephemeralContainers")
+		}
+		if len(invalid) > 0 {
+			return true, nil, apierrors.NewBadRequest("may not be set on create: " + strings.Join(invalid, ", "))
+		}
+		return false, nil, nil
+	})
+}
+
+func TestSanitizePodForRecreate(t *testing.T) {
+	pod := fetchedPod()
+	sanitizePodForRecreate(pod)
+
+	if pod.Name != "" || pod.GenerateName != "" || pod.UID != "" || pod.ResourceVersion != "" || pod.Generation != 0 {
+		t.Errorf("identity kept: name %q, generateName %q, uid %q, resourceVersion %q, generation %d",
+			pod.Name, pod.GenerateName, pod.UID, pod.ResourceVersion, pod.Generation)
+	}
+	if !pod.CreationTimestamp.IsZero() || pod.ManagedFields != nil || pod.OwnerReferences != nil || pod.Finalizers != nil {
+		t.Errorf("server-set metadata kept: %+v", pod.ObjectMeta)
+	}
+	if pod.Namespace != "ml" || pod.Labels["app"] != "trainer" || pod.Annotations["prometheus.io/scrape"] != "true" {
+		t.Errorf("namespace, labels or annotations lost: %+v", pod.ObjectMeta)
+	}
+	if _, ok := pod.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		t.Error("kubectl's last-applied configuration kept")
+	}
+	if pod.Spec.NodeName != "" || pod.Spec.EphemeralContainers != nil {
+		t.Errorf("node %q and %d ephemeral containers kept", pod.Spec.NodeName, len(pod.Spec.EphemeralContainers))
+	}
+	if len(pod.Spec.Containers) != 2 || len(pod.Spec.Volumes) != 1 || pod.Spec.ServiceAccountName != "default" {
+		t.Errorf("spec changed beyond the server-set fields: %+v", pod.Spec)
+	}
+	if pod.Status.Phase != "" || pod.Status.PodIP != "" || pod.Status.StartTime != nil || pod.Status.ContainerStatuses != nil {
+		t.Errorf("status kept: %+v", pod.Status)
+	}
+}
+
+// The fetched object, left as it is, would be rejected; the copy is not, and the
+// original is not changed
+func TestCreateOptimizedPodFromFetchedPod(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	rejectServerSetFields(clientset)
+	client := NewClientForClientsets(clientset, metricsfake.NewSimpleClientset())
+	original := fetchedPod()
+
+	if _, err := clientset.CoreV1().Pods("ml").Create(context.Background(), original.DeepCopy(), metav1.CreateOptions{}); !apierrors.IsBadRequest(err) {
+		t.Fatalf("creating the fetched pod as it is: %v, want it rejected", err)
+	}
+	states := []types.ContainerState{{Name: "trainer", ShouldMigrate: true}, {Name: "exporter"}}
+	created, err := client.CreateOptimizedPod(context.Background(), original, "trainer-migrated", "node-b", false, states, "", nil)
+	if err != nil {
+		t.Fatalf("CreateOptimizedPod: %v", err)
+	}
+	if created.Name != "trainer-migrated" || created.Spec.NodeName != "node-b" || len(created.Spec.Containers) != 1 {
+		t.Errorf("created %s on %q with %d containers, want trainer-migrated on node-b with 1",
+			created.Name, created.Spec.NodeName, len(created.Spec.Containers))
+	}
+	if created.Labels[originalPodLabel] != original.Name {
+		t.Errorf("original pod label = %q, want %q", created.Labels[originalPodLabel], original.Name)
+	}
+	if original.ResourceVersion == "" || original.Spec.NodeName != "node-a" || original.Status.Phase != corev1.PodRunning {
+		t.Error("the fetched pod was changed")
+	}
+
+	if err := client.RecreatePod(context.Background(), original); err != nil {
+		t.Fatalf("RecreatePod: %v", err)
+	}
+	recreated, err := clientset.CoreV1().Pods("ml").Get(context.Background(), original.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recreated.Spec.NodeName != "" || len(recreated.Spec.Containers) != 2 {
+		t.Errorf("recreated pod on %q with %d containers, want it unscheduled with both", recreated.Spec.NodeName, len(recreated.Spec.Containers))
+	}
+}
+
+// A readiness wait ends as soon as its pod is deleted or evicted, however it waits,
+// and tells that apart from the pod never becoming ready
+func TestWaitForPodReadyPodLost(t *testing.T) {
+	evict := func(clientset *fake.Clientset, pod *corev1.Pod) error {
+		pod.Status.Phase = corev1.PodFailed
+		pod.Status.Reason = "Evicted"
+		_, err := clientset.CoreV1().Pods(pod.Namespace).UpdateStatus(context.Background(), pod, metav1.UpdateOptions{})
+		return err
+	}
+	remove := func(clientset *fake.Clientset, pod *corev1.Pod) error {
+		return clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	}
+	for _, mode := range []string{"informer", "watch", "poll"} {
+		for _, test := range []struct {
+			name string
+			lose func(*fake.Clientset, *corev1.Pod) error
+			want error
+		}{
+			{"deleted", remove, ErrPodDeleted},
+			{"evicted", evict, ErrPodTerminated},
+			{"unready", nil, nil},
+		} {
+			t.Run(mode+"/"+test.name, func(t *testing.T) {
+				clientset := fake.NewSimpleClientset()
+				client := NewClientForClientsets(clientset, metricsfake.NewSimpleClientset())
+				// The pod is in the informer's cache from its first sync
+				pod := unreadyPod("app-migrated")
+				if _, err := clientset.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+				watching := make(chan struct{}, 1)
+				switch mode {
+				case "informer":
+					stop := make(chan struct{})
+					defer close(stop)
+					if err := client.StartPodInformer(stop); err != nil {
+						t.Fatal(err)
+					}
+				case "watch":
+					clientset.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+						watcher, err := clientset.Tracker().Watch(action.GetResource(), action.GetNamespace())
+						watching <- struct{}{}
+						return true, watcher, err
+					})
+				case "poll":
+					clientset.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+						watching <- struct{}{}
+						return true, nil, errors.New("watch not served")
+					})
+				}
+
+				timeout := time.Minute
+				if test.lose == nil {
+					timeout = 200 * time.Millisecond
+				}
+				started := time.Now()
+				done := make(chan error, 1)
+				go func() {
+					done <- client.WaitForPodReady(context.Background(), "default", pod.Name, timeout, 10*time.Millisecond, nil, nil)
+				}()
+				if test.lose != nil {
+					if mode == "informer" {
+						waitForWaiter(t, client.pods, "default")
+					} else {
+						<-watching
+					}
+					if err := test.lose(clientset, pod); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				err := <-done
+				if test.want == nil {
+					if err == nil || errors.Is(err, ErrPodDeleted) || errors.Is(err, ErrPodTerminated) {
+						t.Fatalf("err = %v, want a timeout", err)
+					}
+					if !strings.Contains(err.Error(), "timeout waiting for pod conditions") {
+						t.Errorf("err = %v, want the pending conditions", err)
+					}
+					return
+				}
+				if !errors.Is(err, test.want) {
+					t.Fatalf("err = %v, want %v", err, test.want)
+				}
+				if elapsed := time.Since(started); elapsed > 10*time.Second {
+					t.Errorf("took %s, want the lost pod noticed right away", elapsed)
+				}
+				if test.want == ErrPodTerminated && !strings.Contains(err.Error(), "Evicted") {
+					t.Errorf("err = %v, want the kubelet's reason", err)
+				}
+			})
+		}
+	}
+}
+
+func TestPendingPodConditions(t *testing.T) {
+	pod := unreadyPod("app")
+	pod.Status.Conditions[0].Reason = "ContainersNotReady"
+	pod.Spec.Containers = []corev1.Container{{Name: "main"}, {Name: "sidecar"}}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "main", Ready: true}, {Name: "sidecar"}}
+
+	for _, test := range []struct {
+		name              string
+		wanted, tolerated []string
+		pending           []string
+	}{
+		{"ready by default", nil, nil, []string{"Ready (False: ContainersNotReady)"}},
+		{"unreported condition", []string{"PodScheduled"}, nil, []string{"PodScheduled (not reported)"}},
+		{"unready container tolerated", nil, []string{"sidecar"}, nil},
+		{"other container tolerated", []string{"Ready"}, []string{"main"}, []string{"Ready (False: ContainersNotReady)"}},
+	} {
+		pending := PendingPodConditions(pod, test.wanted, test.tolerated)
+		if strings.Join(pending, "|") != strings.Join(test.pending, "|") {
+			t.Errorf("%s: pending %q, want %q", test.name, pending, test.pending)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"fmt"
+	"net/url"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// loadKubeconfig builds the REST config of a kubeconfig file's current context and
+// names the context, cluster and user it uses
+func loadKubeconfig(path string) (*rest.Config, types.ClusterConnection, error) {
+	loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: path}, &clientcmd.ConfigOverrides{})
+	config, err := loader.ClientConfig()
+	if err != nil {
+		return nil, types.ClusterConnection{}, err
+	}
+	raw, err := loader.RawConfig()
+	if err != nil {
+		return nil, types.ClusterConnection{}, fmt.Errorf("failed to read kubeconfig %s: %w", path, err)
+	}
+
+	connection := types.ClusterConnection{Kubeconfig: path, Context: raw.CurrentContext}
+	if context, ok := raw.Contexts[raw.CurrentContext]; ok {
+		connection.Cluster = context.Cluster
+		connection.User = context.AuthInfo
+	}
+	return config, connection, nil
+}
+
+// describeConfig reports the API server and how requests to it are authenticated,
+// leaving out the credentials themselves
+func describeConfig(config *rest.Config) types.ClusterConnection {
+	return types.ClusterConnection{
+		Server:                redactServer(config.Host),
+		AuthMethod:            authMethod(config),
+		InsecureSkipTLSVerify: config.Insecure,
+	}
+}
+
+// redactServer drops credentials embedded in the API server URL
+func redactServer(host string) string {
+	u, err := url.Parse(host)
+	if err != nil || u.User == nil {
+		return host
+	}
+	u.User = nil
+	return u.String()
+}
+
+// authMethod names how a REST config authenticates
+func authMethod(config *rest.Config) string {
+	switch {
+	case config.ExecProvider != nil:
+		return "exec"
+	case config.AuthProvider != nil:
+		return "auth_provider:" + config.AuthProvider.Name
+	case config.BearerToken != "" || config.BearerTokenFile != "":
+		return "token"
+	case len(config.CertData) > 0 || config.CertFile != "":
+		return "client_certificate"
+	case config.Username != "":
+		return "basic"
+	default:
+		return "none"
+	}
+}
+
+// Connection returns the cluster the client operates against
+func (c *Client) Connection() types.ClusterConnection {
+	return c.connection
+}
@@ -0,0 +1,153 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// originalPodLabel is set on every pod the orchestrator creates, naming the pod it
+// was created for; the shared pod informer only caches pods carrying it
+const originalPodLabel = "migration.ai-storage/original-pod"
+
+// podEvents is a shared informer over the pods created by the orchestrator. Waits
+// re-check their pod from the cache whenever a pod in its namespace changes, instead
+// of each opening a watch or polling the API server.
+type podEvents struct {
+	informer cache.SharedIndexInformer
+
+	mu      sync.Mutex
+	waiters map[string]map[chan struct{}]bool // by namespace
+}
+
+// StartPodInformer switches the waits on pods created by the orchestrator (readiness,
+// Deployment pods and image pre-pulls) to one shared informer. It returns once the
+// cache has synced; the informer runs until stopCh is closed.
+func (c *Client) StartPodInformer(stopCh <-chan struct{}) error {
+	informer := coreinformers.NewFilteredPodInformer(c.clientset, metav1.NamespaceAll, 0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = originalPodLabel
+		})
+	events := &podEvents{informer: informer, waiters: make(map[string]map[chan struct{}]bool)}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    events.notify,
+		UpdateFunc: func(_, obj interface{}) { events.notify(obj) },
+		DeleteFunc: events.notify,
+	}); err != nil {
+		return err
+	}
+
+	go informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		return fmt.Errorf("pod informer cache did not sync")
+	}
+	c.pods = events
+	return nil
+}
+
+// notify wakes the waits in the namespace of a changed pod
+func (e *podEvents) notify(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.waiters[pod.Namespace] {
+		select {
+		case ch <- struct{}{}:
+		default: // a wake-up is already pending
+		}
+	}
+}
+
+// wait calls check now and after every change to a pod in namespace, until it
+// reports done, fails, or ctx ends
+func (e *podEvents) wait(ctx context.Context, namespace string, check func() (bool, error)) error {
+	ch := make(chan struct{}, 1)
+	e.mu.Lock()
+	if e.waiters[namespace] == nil {
+		e.waiters[namespace] = make(map[chan struct{}]bool)
+	}
+	e.waiters[namespace][ch] = true
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		delete(e.waiters[namespace], ch)
+		if len(e.waiters[namespace]) == 0 {
+			delete(e.waiters, namespace)
+		}
+		e.mu.Unlock()
+	}()
+
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+		}
+	}
+}
+
+// get returns a pod from the cache
+func (e *podEvents) get(namespace, name string) (*corev1.Pod, bool) {
+	obj, exists, err := e.informer.GetIndexer().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil, false
+	}
+	pod, ok := obj.(*corev1.Pod)
+	return pod, ok
+}
+
+// list returns the cached pods of a namespace matching a label selector
+func (e *podEvents) list(namespace string, selector labels.Selector) []*corev1.Pod {
+	objs, err := e.informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return nil
+	}
+	var pods []*corev1.Pod
+	for _, obj := range objs {
+		if pod, ok := obj.(*corev1.Pod); ok && selector.Matches(labels.Set(pod.Labels)) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// waitForPodConditions waits on the cache until the conditions are True, returning
+// the conditions still pending. A pod seen in the cache and then gone was deleted.
+func (e *podEvents) waitForPodConditions(ctx context.Context, namespace, name string, conditions, tolerated []string) ([]string, error) {
+	pending := conditions
+	seen := false
+	err := e.wait(ctx, namespace, func() (bool, error) {
+		pod, exists := e.get(namespace, name)
+		if !exists {
+			if seen {
+				return false, ErrPodDeleted
+			}
+			return false, nil // not in the cache yet
+		}
+		seen = true
+		if err := podTerminated(pod); err != nil {
+			return false, err
+		}
+		pending = PendingPodConditions(pod, conditions, tolerated)
+		return len(pending) == 0, nil
+	})
+	return pending, err
+}
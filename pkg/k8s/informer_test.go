@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+// defaultPodReadyPollInterval is the controller's default --pod-ready-poll-interval
+const defaultPodReadyPollInterval = 2 * time.Second
+
+func unreadyPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{originalPodLabel: "app"}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		},
+	}
+}
+
+// BenchmarkWaitForPodReady measures how long a readiness wait takes to return
+// after the pod becomes ready, and the API calls it makes, when waiting through
+// the shared informer, a watch of its own, and polling (the watch failing)
+func BenchmarkWaitForPodReady(b *testing.B) {
+	for _, mode := range []string{"informer", "watch", "poll"} {
+		b.Run(mode, func(b *testing.B) {
+			clientset := fake.NewSimpleClientset()
+			client := NewClientForClientsets(clientset, metricsfake.NewSimpleClientset())
+			// waiting is signalled once the wait can see the pod become ready
+			waiting := make(chan struct{}, 1)
+			signal := func() {
+				select {
+				case waiting <- struct{}{}:
+				default:
+				}
+			}
+			switch mode {
+			case "informer":
+				stop := make(chan struct{})
+				defer close(stop)
+				if err := client.StartPodInformer(stop); err != nil {
+					b.Fatal(err)
+				}
+			case "watch":
+				clientset.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+					watcher, err := clientset.Tracker().Watch(action.GetResource(), action.GetNamespace())
+					signal()
+					return true, watcher, err
+				})
+			case "poll":
+				clientset.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+					return true, nil, errors.New("watch not served")
+				})
+				clientset.PrependReactor("get", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					get := action.(k8stesting.GetAction)
+					pod, err := clientset.Tracker().Get(get.GetResource(), get.GetNamespace(), get.GetName())
+					signal()
+					return true, pod, err
+				})
+			}
+
+			var latency time.Duration
+			calls := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				pod := unreadyPod(fmt.Sprintf("app-%d", i))
+				if _, err := clientset.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+					b.Fatal(err)
+				}
+				actions := len(clientset.Actions())
+				select {
+				case <-waiting: // left by the last poll of the previous wait
+				default:
+				}
+				done := make(chan error, 1)
+				go func() {
+					done <- client.WaitForPodReady(context.Background(), "default", pod.Name, time.Minute, defaultPodReadyPollInterval, nil, nil)
+				}()
+				if mode == "informer" {
+					waitForWaiter(b, client.pods, "default")
+				} else {
+					<-waiting
+				}
+				b.StartTimer()
+
+				ready := time.Now()
+				pod.Status.Conditions[0].Status = corev1.ConditionTrue
+				if _, err := clientset.CoreV1().Pods("default").UpdateStatus(context.Background(), pod, metav1.UpdateOptions{}); err != nil {
+					b.Fatal(err)
+				}
+				if err := <-done; err != nil {
+					b.Fatal(err)
+				}
+				latency += time.Since(ready)
+
+				b.StopTimer()
+				calls += len(clientset.Actions()) - actions - 1 // less the status update
+				if err := clientset.CoreV1().Pods("default").Delete(context.Background(), pod.Name, metav1.DeleteOptions{}); err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(latency.Microseconds())/float64(b.N), "µs-to-ready/op")
+			b.ReportMetric(float64(calls)/float64(b.N), "api-calls/op")
+		})
+	}
+}
+
+// waitForWaiter waits until a wait is registered on the informer for namespace
+func waitForWaiter(t testing.TB, events *podEvents, namespace string) {
+	for {
+		events.mu.Lock()
+		registered := len(events.waiters[namespace]) > 0
+		events.mu.Unlock()
+		if registered {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,27 @@
+package k8s
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// throttleCounter counts the API server responses rejecting a request as too many
+// requests (429), including those client-go retries on its own
+type throttleCounter struct {
+	next  http.RoundTripper
+	count *atomic.Int64
+}
+
+func (t *throttleCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.count.Add(1)
+	}
+	return resp, err
+}
+
+// ThrottledRequests returns how many requests the API server has answered with 429
+// Too Many Requests since the client was created
+func (c *Client) ThrottledRequests() int64 {
+	return c.throttled.Load()
+}
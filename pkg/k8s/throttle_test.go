@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestThrottleCounter(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/pods/throttled"):
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "TooManyRequests", "code": 429}`))
+		case strings.HasSuffix(r.URL.Path, "/pods/retried") && calls.Add(1) == 1:
+			// client-go retries a 429 with Retry-After on its own
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "TooManyRequests", "code": 429}`))
+		default:
+			w.Write([]byte(`{"kind": "Pod", "apiVersion": "v1", "metadata": {"name": "app", "namespace": "default"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClientForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetPod(context.Background(), "default", "app"); err != nil {
+		t.Fatalf("GetPod: %v", err)
+	}
+	if count := client.ThrottledRequests(); count != 0 {
+		t.Errorf("ThrottledRequests = %d after a served request, want 0", count)
+	}
+
+	if _, err := client.GetPod(context.Background(), "default", "throttled"); err == nil {
+		t.Fatal("GetPod of a throttled request succeeded")
+	}
+	if count := client.ThrottledRequests(); count != 1 {
+		t.Errorf("ThrottledRequests = %d after a throttled request, want 1", count)
+	}
+
+	if _, err := client.GetPod(context.Background(), "default", "retried"); err != nil {
+		t.Fatalf("GetPod retried after a 429: %v", err)
+	}
+	if count := client.ThrottledRequests(); count != 2 {
+		t.Errorf("ThrottledRequests = %d after a retried 429, want 2", count)
+	}
+}
@@ -0,0 +1,183 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// CleanupFinalizer keeps an object created by a migration from disappearing
+	// unnoticed until the migration either hands it over or removes it
+	CleanupFinalizer = "migration.ai-storage/cleanup"
+	// MigrationIDLabel names the migration that created an object
+	MigrationIDLabel = "migration.ai-storage/migration-id"
+	// HandoverAnnotation marks an object the workload depends on: the migration that
+	// created it went past the point of no return, so it is never deleted by cleanup
+	HandoverAnnotation = "migration.ai-storage/handover"
+)
+
+// Kinds of objects created by a migration
+const (
+	TrackedPod        = "Pod"
+	TrackedPVC        = "PersistentVolumeClaim"
+	TrackedDeployment = "Deployment"
+)
+
+// ResourceTracking tags the objects a migration creates with its ID and the cleanup
+// finalizer, so that they can be found after a restart. A nil tracking tags nothing.
+type ResourceTracking struct {
+	MigrationID string
+	// Created already handed over, e.g. after the original pod was deleted
+	HandedOver bool
+}
+
+// apply adds the migration label and the cleanup finalizer to an object
+func (t *ResourceTracking) apply(meta *metav1.ObjectMeta) {
+	if t == nil {
+		return
+	}
+	if meta.Labels == nil {
+		meta.Labels = make(map[string]string)
+	}
+	meta.Labels[MigrationIDLabel] = t.MigrationID
+	meta.Finalizers = append(meta.Finalizers, CleanupFinalizer)
+	if t.HandedOver {
+		if meta.Annotations == nil {
+			meta.Annotations = make(map[string]string)
+		}
+		meta.Annotations[HandoverAnnotation] = "true"
+	}
+}
+
+// TrackedResource is an object that still carries the cleanup finalizer
+type TrackedResource struct {
+	Kind        string
+	Namespace   string
+	Name        string
+	MigrationID string
+	Terminating bool // deleted, waiting only for the finalizer
+	HandedOver  bool // carries HandoverAnnotation
+}
+
+// ListTrackedResources returns the pods, PVCs and Deployments of every namespace that
+// still carry the cleanup finalizer
+func (c *Client) ListTrackedResources(ctx context.Context) ([]TrackedResource, error) {
+	options := metav1.ListOptions{LabelSelector: MigrationIDLabel}
+	var tracked []TrackedResource
+	add := func(kind string, meta metav1.ObjectMeta) {
+		for _, finalizer := range meta.Finalizers {
+			if finalizer == CleanupFinalizer {
+				tracked = append(tracked, TrackedResource{
+					Kind:        kind,
+					Namespace:   meta.Namespace,
+					Name:        meta.Name,
+					MigrationID: meta.Labels[MigrationIDLabel],
+					Terminating: meta.DeletionTimestamp != nil,
+					HandedOver:  meta.Annotations[HandoverAnnotation] == "true",
+				})
+				return
+			}
+		}
+	}
+
+	pods, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		add(TrackedPod, pod.ObjectMeta)
+	}
+
+	pvcs, err := c.clientset.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked PVCs: %w", err)
+	}
+	for _, pvc := range pvcs.Items {
+		add(TrackedPVC, pvc.ObjectMeta)
+	}
+
+	deployments, err := c.clientset.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		add(TrackedDeployment, deployment.ObjectMeta)
+	}
+
+	return tracked, nil
+}
+
+// ReleaseTracked removes the cleanup finalizer from an object, leaving it otherwise
+// untouched. Objects that are already gone are ignored.
+func (c *Client) ReleaseTracked(ctx context.Context, kind, namespace, name string) error {
+	err := c.patchTracked(ctx, kind, namespace, name, map[string]interface{}{
+		"$deleteFromPrimitiveList/finalizers": []string{CleanupFinalizer},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove cleanup finalizer from %s %s/%s: %w", kind, namespace, name, err)
+	}
+	return nil
+}
+
+// MarkHandedOver adds HandoverAnnotation to an object, so that cleanup releases it
+// rather than deleting it whatever happens to the migration. Objects that are
+// already gone are ignored.
+func (c *Client) MarkHandedOver(ctx context.Context, kind, namespace, name string) error {
+	err := c.patchTracked(ctx, kind, namespace, name, map[string]interface{}{
+		"annotations": map[string]string{HandoverAnnotation: "true"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark %s %s/%s as handed over: %w", kind, namespace, name, err)
+	}
+	return nil
+}
+
+// patchTracked applies a strategic merge patch to the metadata of a tracked object
+func (c *Client) patchTracked(ctx context.Context, kind, namespace, name string, metadata map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case TrackedPod:
+		_, err = c.clientset.CoreV1().Pods(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case TrackedPVC:
+		_, err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case TrackedDeployment:
+		_, err = c.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("unknown tracked kind %q", kind)
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// DeleteTracked deletes an object created by a migration and removes its cleanup
+// finalizer so the deletion can complete
+func (c *Client) DeleteTracked(ctx context.Context, resource TrackedResource) error {
+	if !resource.Terminating {
+		var err error
+		switch resource.Kind {
+		case TrackedPod:
+			err = c.DeletePod(ctx, resource.Namespace, resource.Name)
+		case TrackedPVC:
+			err = c.DeletePersistentVolumeClaim(ctx, resource.Namespace, resource.Name)
+		case TrackedDeployment:
+			err = c.DeleteDeployment(ctx, resource.Namespace, resource.Name)
+		default:
+			return fmt.Errorf("unknown tracked kind %q", resource.Kind)
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return c.ReleaseTracked(ctx, resource.Kind, resource.Namespace, resource.Name)
+}
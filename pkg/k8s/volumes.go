@@ -0,0 +1,170 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// SelectedNodeAnnotation is set on a PVC by the scheduler to tell the provisioner of a
+// WaitForFirstConsumer storage class which node the volume must be reachable from
+const SelectedNodeAnnotation = "volume.kubernetes.io/selected-node"
+
+// WaitForPersistentVolumeClaimBound waits until the PVC is bound and returns its volume
+func (c *Client) WaitForPersistentVolumeClaimBound(ctx context.Context, namespace, name string, timeout time.Duration) (*corev1.PersistentVolume, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var volumeName string
+	err := wait.PollUntilContextCancel(waitCtx, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+		pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil // transient errors are retried until the timeout
+		}
+		if pvc.Status.Phase == corev1.ClaimBound && pvc.Spec.VolumeName != "" {
+			volumeName = pvc.Spec.VolumeName
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("PVC %s/%s not bound after %v", namespace, name, timeout)
+	}
+
+	return c.clientset.CoreV1().PersistentVolumes().Get(ctx, volumeName, metav1.GetOptions{})
+}
+
+// GetPersistentVolumeClaim retrieves a PVC by name and namespace
+func (c *Client) GetPersistentVolumeClaim(ctx context.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	return c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetPersistentVolume retrieves a PV by name
+func (c *Client) GetPersistentVolume(ctx context.Context, name string) (*corev1.PersistentVolume, error) {
+	return c.clientset.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+}
+
+// SingleNodeClaims returns the PVCs mounted by a pod that can only be attached to one
+// node at a time: those with no access mode other than ReadWriteOnce or
+// ReadWriteOncePod. A bound PVC's actual access modes take precedence over the
+// requested ones.
+func (c *Client) SingleNodeClaims(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	var claims []string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		name := volume.PersistentVolumeClaim.ClaimName
+		pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PVC %s/%s: %w", pod.Namespace, name, err)
+		}
+		modes := pvc.Status.AccessModes
+		if len(modes) == 0 {
+			modes = pvc.Spec.AccessModes
+		}
+		singleNode := len(modes) > 0
+		for _, mode := range modes {
+			if mode != corev1.ReadWriteOnce && mode != corev1.ReadWriteOncePod {
+				singleNode = false
+			}
+		}
+		if singleNode {
+			claims = append(claims, name)
+		}
+	}
+	return claims, nil
+}
+
+// WaitForPodDeleted waits until the pod is gone from the API server, which for a
+// pod with volumes means the kubelet has unmounted them
+func (c *Client) WaitForPodDeleted(ctx context.Context, namespace, name string, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := wait.PollUntilContextCancel(waitCtx, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+		_, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, nil // transient errors are retried until the timeout
+	})
+	if err != nil {
+		return fmt.Errorf("pod %s/%s still exists after %v", namespace, name, timeout)
+	}
+	return nil
+}
+
+// nodeSelectorOperators maps node selector operators to label selector operators
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// VolumeAccessibleFrom reports whether the node satisfies the node affinity of the
+// volume. Volumes without node affinity are reachable from every node.
+func VolumeAccessibleFrom(pv *corev1.PersistentVolume, node *corev1.Node) (bool, error) {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return true, nil
+	}
+
+	// Terms are ORed, the requirements within a term ANDed
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		matches, err := nodeSelectorTermMatches(term, node)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func nodeSelectorTermMatches(term corev1.NodeSelectorTerm, node *corev1.Node) (bool, error) {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false, nil // an empty term matches no objects
+	}
+
+	selector := labels.NewSelector()
+	for _, expression := range term.MatchExpressions {
+		operator, ok := nodeSelectorOperators[expression.Operator]
+		if !ok {
+			return false, fmt.Errorf("unsupported node selector operator %q", expression.Operator)
+		}
+		requirement, err := labels.NewRequirement(expression.Key, operator, expression.Values)
+		if err != nil {
+			return false, err
+		}
+		selector = selector.Add(*requirement)
+	}
+	if !selector.Matches(labels.Set(node.Labels)) {
+		return false, nil
+	}
+
+	// metadata.name is the only field a node selector may match on
+	for _, field := range term.MatchFields {
+		if field.Key != "metadata.name" {
+			return false, fmt.Errorf("unsupported node selector field %q", field.Key)
+		}
+		requirement, err := labels.NewRequirement("name", nodeSelectorOperators[field.Operator], field.Values)
+		if err != nil {
+			return false, err
+		}
+		if !requirement.Matches(labels.Set{"name": node.Name}) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
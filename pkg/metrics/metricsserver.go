@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"context"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// MetricsServerProvider reads usage from the metrics.k8s.io API served by metrics-server
+type MetricsServerProvider struct {
+	k8sClient *k8s.Client
+}
+
+// NewMetricsServerProvider creates a provider backed by metrics-server
+func NewMetricsServerProvider(k8sClient *k8s.Client) *MetricsServerProvider {
+	return &MetricsServerProvider{k8sClient: k8sClient}
+}
+
+// PodMetrics returns pod usage from metrics-server
+func (p *MetricsServerProvider) PodMetrics(ctx context.Context, namespace, name string) (*types.ResourceUsage, error) {
+	return p.k8sClient.GetPodMetrics(ctx, namespace, name)
+}
+
+// ContainerMetrics returns per-container usage from metrics-server
+func (p *MetricsServerProvider) ContainerMetrics(ctx context.Context, namespace, name string) (map[string]types.ResourceUsage, error) {
+	return p.k8sClient.GetContainerMetrics(ctx, namespace, name)
+}
+
+// NodeMetrics returns node usage from metrics-server
+func (p *MetricsServerProvider) NodeMetrics(ctx context.Context, name string) (*types.ResourceUsage, error) {
+	return p.k8sClient.GetNodeMetrics(ctx, name)
+}
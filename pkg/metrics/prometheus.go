@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// PromQL queries built from cAdvisor metrics scraped from the kubelets
+const (
+	podCPUQuery     = `sum(rate(container_cpu_usage_seconds_total{namespace=%q,pod=%q,container!=""}[5m]))`
+	podMemoryQuery  = `sum(container_memory_working_set_bytes{namespace=%q,pod=%q,container!=""})`
+	nodeCPUQuery    = `sum(rate(container_cpu_usage_seconds_total{node=%q,container!=""}[5m]))`
+	nodeMemoryQuery = `sum(container_memory_working_set_bytes{node=%q,container!=""})`
+
+	containerCPUQuery    = `sum by (container) (rate(container_cpu_usage_seconds_total{namespace=%q,pod=%q,container!=""}[5m]))`
+	containerMemoryQuery = `sum by (container) (container_memory_working_set_bytes{namespace=%q,pod=%q,container!=""})`
+)
+
+// podGPUQuery reads the utilization (0-100 per GPU) reported by NVIDIA's DCGM exporter
+// with its Kubernetes pod mapping enabled, as GPUs' worth of busy time
+const podGPUQuery = `sum(DCGM_FI_DEV_GPU_UTIL{namespace=%q,pod=%q}) / 100`
+
+// errNoSamples is returned by query when the query matches no series
+var errNoSamples = errors.New("no samples returned")
+
+// PrometheusProvider reads usage by running PromQL queries against a Prometheus server
+type PrometheusProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewPrometheusProvider creates a provider querying the Prometheus server at baseURL
+func NewPrometheusProvider(baseURL string) (*PrometheusProvider, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("prometheus URL is required for the prometheus metrics provider")
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid prometheus URL %q: %w", baseURL, err)
+	}
+
+	return &PrometheusProvider{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// PodMetrics returns pod usage from Prometheus
+func (p *PrometheusProvider) PodMetrics(ctx context.Context, namespace, name string) (*types.ResourceUsage, error) {
+	usage, err := p.usage(ctx,
+		fmt.Sprintf(podCPUQuery, namespace, name),
+		fmt.Sprintf(podMemoryQuery, namespace, name))
+	if err != nil {
+		return nil, err
+	}
+
+	// Pods without GPUs, or clusters without the DCGM exporter, have no GPU series
+	gpu, _, err := p.query(ctx, fmt.Sprintf(podGPUQuery, namespace, name))
+	if err != nil && !errors.Is(err, errNoSamples) {
+		return nil, fmt.Errorf("failed to query GPU usage: %w", err)
+	}
+	usage.GPUUsage = gpu
+	return usage, nil
+}
+
+// ContainerMetrics returns per-container usage from Prometheus
+func (p *PrometheusProvider) ContainerMetrics(ctx context.Context, namespace, name string) (map[string]types.ResourceUsage, error) {
+	result, err := p.run(ctx, fmt.Sprintf(containerCPUQuery, namespace, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query CPU usage: %w", err)
+	}
+	usage := make(map[string]types.ResourceUsage, len(result.Data.Result))
+	for _, series := range result.Data.Result {
+		value, timestamp, err := sampleValue(series.Value)
+		if err != nil {
+			return nil, err
+		}
+		usage[series.Metric["container"]] = types.ResourceUsage{CPUUsage: value, Timestamp: timestamp}
+	}
+
+	result, err = p.run(ctx, fmt.Sprintf(containerMemoryQuery, namespace, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query memory usage: %w", err)
+	}
+	for _, series := range result.Data.Result {
+		value, _, err := sampleValue(series.Value)
+		if err != nil {
+			return nil, err
+		}
+		container := series.Metric["container"]
+		entry := usage[container]
+		entry.MemoryUsage = int64(value)
+		usage[container] = entry
+	}
+	return usage, nil
+}
+
+// NodeMetrics returns node usage from Prometheus
+func (p *PrometheusProvider) NodeMetrics(ctx context.Context, name string) (*types.ResourceUsage, error) {
+	return p.usage(ctx,
+		fmt.Sprintf(nodeCPUQuery, name),
+		fmt.Sprintf(nodeMemoryQuery, name))
+}
+
+// usage runs a CPU (cores) and a memory (bytes) query and combines the results
+func (p *PrometheusProvider) usage(ctx context.Context, cpuQuery, memoryQuery string) (*types.ResourceUsage, error) {
+	cpu, timestamp, err := p.query(ctx, cpuQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query CPU usage: %w", err)
+	}
+	memory, _, err := p.query(ctx, memoryQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query memory usage: %w", err)
+	}
+
+	return &types.ResourceUsage{
+		CPUUsage:    cpu,
+		MemoryUsage: int64(memory),
+		Timestamp:   timestamp,
+	}, nil
+}
+
+// prometheusResponse is the subset of the /api/v1/query response used here
+type prometheusResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  [2]interface{}    `json:"value"` // [unix timestamp, "value"]
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// query runs an instant query that must return a single-sample vector
+func (p *PrometheusProvider) query(ctx context.Context, promQL string) (float64, time.Time, error) {
+	result, err := p.run(ctx, promQL)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if len(result.Data.Result) == 0 {
+		return 0, time.Time{}, fmt.Errorf("%w for query %s", errNoSamples, promQL)
+	}
+	return sampleValue(result.Data.Result[0].Value)
+}
+
+// run runs an instant query that must return a vector, possibly empty
+func (p *PrometheusProvider) run(ctx context.Context, promQL string) (*prometheusResponse, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/query?query=%s", p.baseURL, url.QueryEscape(promQL))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode prometheus response: %w", err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed (%s): %s", result.ErrorType, result.Error)
+	}
+	if result.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("%w for query %s", errNoSamples, promQL)
+	}
+	return &result, nil
+}
+
+// sampleValue decodes a [unix timestamp, "value"] sample
+func sampleValue(sample [2]interface{}) (float64, time.Time, error) {
+	seconds, ok := sample[0].(float64)
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("unexpected sample timestamp %v", sample[0])
+	}
+	raw, ok := sample[1].(string)
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("unexpected sample value %v", sample[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("invalid sample value %q: %w", raw, err)
+	}
+
+	return value, time.Unix(0, int64(seconds*float64(time.Second))), nil
+}
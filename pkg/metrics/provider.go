@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// Supported metrics provider names
+const (
+	ProviderMetricsServer = "metrics-server"
+	ProviderPrometheus    = "prometheus"
+)
+
+// Provider supplies actual resource usage for pods and nodes
+type Provider interface {
+	// PodMetrics returns the total CPU and memory usage of all containers in a pod
+	PodMetrics(ctx context.Context, namespace, name string) (*types.ResourceUsage, error)
+	// ContainerMetrics returns the CPU and memory usage of each container of a pod
+	ContainerMetrics(ctx context.Context, namespace, name string) (map[string]types.ResourceUsage, error)
+	// NodeMetrics returns the CPU and memory usage of a node
+	NodeMetrics(ctx context.Context, name string) (*types.ResourceUsage, error)
+}
+
+// NewProvider creates the metrics provider selected by name
+func NewProvider(name string, k8sClient *k8s.Client, prometheusURL string) (Provider, error) {
+	switch name {
+	case ProviderMetricsServer, "":
+		return NewMetricsServerProvider(k8sClient), nil
+	case ProviderPrometheus:
+		return NewPrometheusProvider(prometheusURL)
+	default:
+		return nil, fmt.Errorf("unsupported metrics provider: %s", name)
+	}
+}
@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Client asks an external policy service, such as Open Policy Agent, whether a
+// migration may run. The input is POSTed as {"input": ...} and the service answers
+// {"result": {"allow": bool, "reason": "..."}}, the shape of OPA's data API.
+type Client struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewClient creates a client for the policy service at endpoint, e.g.
+// http://opa:8181/v1/data/orchestrator/migration
+func NewClient(endpoint string) *Client {
+	return &Client{endpoint: endpoint, client: &http.Client{Timeout: requestTimeout}}
+}
+
+// ValidateEndpoint checks that a policy endpoint is an absolute http or https URL
+func ValidateEndpoint(endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("policy endpoint must be an absolute http or https URL, got %q", endpoint)
+	}
+	return nil
+}
+
+// Endpoint returns the URL decisions are requested from
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+type decisionRequest struct {
+	Input *types.PolicyInput `json:"input"`
+}
+
+type decisionResponse struct {
+	Result *struct {
+		Allow  bool   `json:"allow"`
+		Reason string `json:"reason"`
+	} `json:"result"`
+}
+
+// Decide asks the service about one migration. An error means no decision was made.
+func (c *Client) Decide(ctx context.Context, input *types.PolicyInput) (allowed bool, reason string, err error) {
+	payload, err := json.Marshal(decisionRequest{Input: input})
+	if err != nil {
+		return false, "", fmt.Errorf("failed to encode policy input: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return false, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return false, "", fmt.Errorf("policy service answered %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	var decision decisionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return false, "", fmt.Errorf("failed to decode policy decision: %w", err)
+	}
+	// OPA leaves out result when the policy is not defined
+	if decision.Result == nil {
+		return false, "", fmt.Errorf("policy service returned no result")
+	}
+	return decision.Result.Allow, decision.Result.Reason, nil
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+func TestDecide(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		status   int
+		body     string
+		allowed  bool
+		reason   string
+		errorMsg string // part of the error, empty when a decision is made
+	}{
+		{name: "allow", status: http.StatusOK, body: `{"result": {"allow": true}}`, allowed: true},
+		{name: "deny", status: http.StatusOK, body: `{"result": {"allow": false, "reason": "frozen namespace"}}`, reason: "frozen namespace"},
+		{name: "policy not defined", status: http.StatusOK, body: `{}`, errorMsg: "no result"},
+		{name: "invalid body", status: http.StatusOK, body: `allow`, errorMsg: "failed to decode"},
+		{name: "server error", status: http.StatusInternalServerError, body: "boom\n", errorMsg: "500 Internal Server Error: boom"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var input types.PolicyInput
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Input types.PolicyInput `json:"input"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decode policy input: %v", err)
+				}
+				input = body.Input
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			request := &types.MigrationRequest{PodName: "app", PodNamespace: "default"}
+			allowed, reason, err := NewClient(server.URL).Decide(context.Background(), &types.PolicyInput{Request: request})
+			if input.Request == nil || input.Request.PodName != "app" {
+				t.Errorf("policy service got input %+v, want the request of app", input)
+			}
+			if test.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), test.errorMsg) {
+					t.Fatalf("Decide: %v, want an error containing %q", err, test.errorMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decide: %v", err)
+			}
+			if allowed != test.allowed || reason != test.reason {
+				t.Errorf("Decide = %v, %q; want %v, %q", allowed, reason, test.allowed, test.reason)
+			}
+		})
+	}
+}
+
+func TestDecideUnreachable(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := NewClient(slow.URL).Decide(ctx, &types.PolicyInput{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Decide against a hanging service: %v, want the deadline exceeded", err)
+	}
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	if _, _, err := NewClient(down.URL).Decide(context.Background(), &types.PolicyInput{}); err == nil {
+		t.Error("Decide against a stopped service made a decision")
+	}
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	for endpoint, valid := range map[string]bool{
+		"http://opa:8181/v1/data/orchestrator/migration": true,
+		"https://policy.example.com/decide":              true,
+		"opa:8181/v1/data":                               false,
+		"ftp://opa/v1/data":                              false,
+		"http:///v1/data":                                false,
+	} {
+		if err := ValidateEndpoint(endpoint); (err == nil) != valid {
+			t.Errorf("ValidateEndpoint(%q) = %v, want valid %v", endpoint, err, valid)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+)
+
+// createMigrationRecordsTable creates the records table on first use
+const createMigrationRecordsTable = `
+CREATE TABLE IF NOT EXISTS migration_records (
+	migration_id              TEXT PRIMARY KEY,
+	pod_name                  TEXT NOT NULL,
+	pod_namespace             TEXT NOT NULL,
+	source_node               TEXT NOT NULL,
+	target_node               TEXT NOT NULL,
+	new_pod_name              TEXT,
+	status                    TEXT NOT NULL,
+	start_time                TIMESTAMPTZ NOT NULL,
+	end_time                  TIMESTAMPTZ,
+	duration_ms               BIGINT,
+	containers_total          INTEGER,
+	containers_migrated       INTEGER,
+	checkpoint_pvc            TEXT,
+	original_cpu_cores        DOUBLE PRECISION,
+	original_memory_bytes     BIGINT,
+	optimized_cpu_cores       DOUBLE PRECISION,
+	optimized_memory_bytes    BIGINT,
+	cpu_savings_percentage    DOUBLE PRECISION,
+	memory_savings_percentage DOUBLE PRECISION,
+	recorded_at               TIMESTAMPTZ NOT NULL DEFAULT now()
+)`
+
+// migrateMigrationRecordsTable adds columns introduced after the table was first created
+const migrateMigrationRecordsTable = `
+ALTER TABLE migration_records
+	ADD COLUMN IF NOT EXISTS request_id TEXT,
+	ADD COLUMN IF NOT EXISTS original_gpu_usage DOUBLE PRECISION,
+	ADD COLUMN IF NOT EXISTS optimized_gpu_usage DOUBLE PRECISION,
+	ADD COLUMN IF NOT EXISTS gpu_savings_percentage DOUBLE PRECISION`
+
+// insertMigrationRecord upserts so a record re-sent after a retry does not fail
+const insertMigrationRecord = `
+INSERT INTO migration_records (
+	migration_id, pod_name, pod_namespace, source_node, target_node, new_pod_name,
+	status, start_time, end_time, duration_ms, containers_total, containers_migrated,
+	checkpoint_pvc, original_cpu_cores, original_memory_bytes, optimized_cpu_cores,
+	optimized_memory_bytes, cpu_savings_percentage, memory_savings_percentage, request_id,
+	original_gpu_usage, optimized_gpu_usage, gpu_savings_percentage
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+ON CONFLICT (migration_id) DO UPDATE SET
+	status = EXCLUDED.status,
+	end_time = EXCLUDED.end_time,
+	duration_ms = EXCLUDED.duration_ms,
+	optimized_cpu_cores = EXCLUDED.optimized_cpu_cores,
+	optimized_memory_bytes = EXCLUDED.optimized_memory_bytes,
+	cpu_savings_percentage = EXCLUDED.cpu_savings_percentage,
+	memory_savings_percentage = EXCLUDED.memory_savings_percentage,
+	original_gpu_usage = EXCLUDED.original_gpu_usage,
+	optimized_gpu_usage = EXCLUDED.optimized_gpu_usage,
+	gpu_savings_percentage = EXCLUDED.gpu_savings_percentage,
+	recorded_at = now()`
+
+// selectMigrationRecords reads the records of the migrations that ended in a range
+const selectMigrationRecords = `
+SELECT
+	migration_id, request_id, pod_name, pod_namespace, source_node, target_node, new_pod_name,
+	status, start_time, end_time, duration_ms, containers_total, containers_migrated,
+	checkpoint_pvc, original_cpu_cores, original_memory_bytes, optimized_cpu_cores,
+	optimized_memory_bytes, cpu_savings_percentage, memory_savings_percentage,
+	original_gpu_usage, optimized_gpu_usage, gpu_savings_percentage
+FROM migration_records
+WHERE end_time >= $1 AND end_time < $2
+ORDER BY end_time`
+
+// PostgresSink writes migration records to a PostgreSQL table
+type PostgresSink struct {
+	db *sql.DB
+}
+
+// NewPostgresSink connects to PostgreSQL and makes sure the records table exists
+func NewPostgresSink(dsn string) (*PostgresSink, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, createMigrationRecordsTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create migration_records table: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, migrateMigrationRecordsTable); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate migration_records table: %w", err)
+	}
+
+	return &PostgresSink{db: db}, nil
+}
+
+// Write implements MigrationSink
+func (s *PostgresSink) Write(ctx context.Context, record *types.MigrationRecord) error {
+	_, err := s.db.ExecContext(ctx, insertMigrationRecord,
+		record.MigrationID,
+		record.PodName,
+		record.PodNamespace,
+		record.SourceNode,
+		record.TargetNode,
+		nullString(record.NewPodName),
+		string(record.Status),
+		record.StartTime,
+		record.EndTime,
+		record.DurationMs,
+		record.ContainersTotal,
+		record.ContainersMigrated,
+		nullString(record.CheckpointPVC),
+		record.OriginalCPU,
+		record.OriginalMemory,
+		record.OptimizedCPU,
+		record.OptimizedMemory,
+		record.CPUSavings,
+		record.MemorySavings,
+		nullString(record.RequestID),
+		record.OriginalGPU,
+		record.OptimizedGPU,
+		record.GPUSavings,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert migration record %s: %w", record.MigrationID, err)
+	}
+	return nil
+}
+
+// Records implements RecordReader
+func (s *PostgresSink) Records(ctx context.Context, from, to time.Time) ([]*types.MigrationRecord, error) {
+	rows, err := s.db.QueryContext(ctx, selectMigrationRecords, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query migration records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*types.MigrationRecord
+	for rows.Next() {
+		var record types.MigrationRecord
+		var requestID, newPodName, checkpointPVC sql.NullString
+		var status string
+		var containersTotal, containersMigrated sql.NullInt64
+		if err := rows.Scan(
+			&record.MigrationID,
+			&requestID,
+			&record.PodName,
+			&record.PodNamespace,
+			&record.SourceNode,
+			&record.TargetNode,
+			&newPodName,
+			&status,
+			&record.StartTime,
+			&record.EndTime,
+			&record.DurationMs,
+			&containersTotal,
+			&containersMigrated,
+			&checkpointPVC,
+			&record.OriginalCPU,
+			&record.OriginalMemory,
+			&record.OptimizedCPU,
+			&record.OptimizedMemory,
+			&record.CPUSavings,
+			&record.MemorySavings,
+			&record.OriginalGPU,
+			&record.OptimizedGPU,
+			&record.GPUSavings,
+		); err != nil {
+			return nil, fmt.Errorf("failed to read migration record: %w", err)
+		}
+		record.RequestID = requestID.String
+		record.NewPodName = newPodName.String
+		record.CheckpointPVC = checkpointPVC.String
+		record.Status = types.MigrationStatus(status)
+		record.ContainersTotal = int(containersTotal.Int64)
+		record.ContainersMigrated = int(containersMigrated.Int64)
+		records = append(records, &record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read migration records: %w", err)
+	}
+	return records, nil
+}
+
+// Close implements MigrationSink
+func (s *PostgresSink) Close() error {
+	return s.db.Close()
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
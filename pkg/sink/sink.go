@@ -0,0 +1,42 @@
+package sink
+
+import (
+	"context"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// MigrationSink persists finished migration records outside the process
+type MigrationSink interface {
+	// Write stores one record; implementations must be safe for concurrent use
+	Write(ctx context.Context, record *types.MigrationRecord) error
+	Close() error
+}
+
+// RecordReader is implemented by sinks whose records can be read back
+type RecordReader interface {
+	// Records returns the stored records of the migrations that ended in [from, to)
+	Records(ctx context.Context, from, to time.Time) ([]*types.MigrationRecord, error)
+}
+
+// NewSink returns a PostgreSQL sink for the given DSN, or a no-op sink when the DSN is empty
+func NewSink(postgresDSN string) (MigrationSink, error) {
+	if postgresDSN == "" {
+		return NoopSink{}, nil
+	}
+	return NewPostgresSink(postgresDSN)
+}
+
+// NoopSink discards every record
+type NoopSink struct{}
+
+// Write implements MigrationSink
+func (NoopSink) Write(ctx context.Context, record *types.MigrationRecord) error {
+	return nil
+}
+
+// Close implements MigrationSink
+func (NoopSink) Close() error {
+	return nil
+}
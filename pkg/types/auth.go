@@ -0,0 +1,10 @@
+package types
+
+// APIKey grants access to the API. A key with no namespaces is unscoped and may use
+// every endpoint; a scoped key may only touch migrations and autoscalers in its
+// namespaces and is refused cluster-wide operations.
+type APIKey struct {
+	Name       string   `json:"name"`
+	Key        string   `json:"key"`
+	Namespaces []string `json:"namespaces,omitempty"`
+}
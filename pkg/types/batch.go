@@ -0,0 +1,79 @@
+package types
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// BatchMigrationRequest migrates several pods of one application as a unit
+type BatchMigrationRequest struct {
+	Migrations []MigrationRequest `json:"migrations" binding:"required"`
+
+	// How many of the batch's pods may be mid-migration at once, as a count or a
+	// percentage of the batch (e.g. "25%"); defaults to 25%, and at least one
+	MaxUnavailable *intstr.IntOrString `json:"max_unavailable,omitempty"`
+
+	// Minimum time between the starts of two child migrations, to smooth the load on
+	// the target node; combines with MaxUnavailable
+	PodDelay int `json:"pod_delay,omitempty"` // seconds
+
+	// Pods whose migration may only start once other pods of the batch migrated
+	// successfully; must not form a cycle
+	Dependencies []BatchDependency `json:"dependencies,omitempty"`
+}
+
+// BatchDependency makes a pod of a batch wait for others. Pods are referenced as
+// namespace/name, or by name alone when no other pod of the batch shares it.
+type BatchDependency struct {
+	Pod       string   `json:"pod"`
+	DependsOn []string `json:"depends_on"`
+}
+
+// DrainRequest migrates every eligible pod off a node as one batch
+type DrainRequest struct {
+	TargetNode     string              `json:"target_node" binding:"required"`
+	MaxUnavailable *intstr.IntOrString `json:"max_unavailable,omitempty"`
+	PodDelay       int                 `json:"pod_delay,omitempty"` // seconds
+
+	// Options applied to every pod's migration
+	MigrationOptions
+}
+
+// BatchStatus represents the overall status of a batch migration
+type BatchStatus string
+
+const (
+	BatchStatusRunning   BatchStatus = "running"
+	BatchStatusCompleted BatchStatus = "completed" // every child migration completed
+	BatchStatusFailed    BatchStatus = "failed"    // at least one child failed, was cancelled or was skipped
+)
+
+// BatchMigrationResponse represents the state of a batch migration
+type BatchMigrationResponse struct {
+	BatchID        string                `json:"batch_id"`
+	Status         BatchStatus           `json:"status"`
+	MaxUnavailable int                   `json:"max_unavailable"`
+	Unavailable    int                   `json:"unavailable"`      // children whose pod is or may yet be down
+	PodDelay       int                   `json:"pod_delay"`        // effective seconds between child starts
+	Source         string                `json:"source,omitempty"` // e.g. the drained node
+	StartTime      time.Time             `json:"start_time"`
+	EndTime        *time.Time            `json:"end_time,omitempty"`
+	Children       []BatchMigrationChild `json:"children"`
+	// Pods as namespace/name in the order their migrations may start, after dependencies
+	Order []string `json:"order"`
+	// Set when a drain request joined a drain of the same node already in progress
+	Coalesced bool `json:"coalesced,omitempty"`
+}
+
+// BatchMigrationChild is one pod migration within a batch
+type BatchMigrationChild struct {
+	PodName      string          `json:"pod_name"`
+	PodNamespace string          `json:"pod_namespace"`
+	MigrationID  string          `json:"migration_id,omitempty"` // empty while queued in the batch
+	Status       MigrationStatus `json:"status,omitempty"`
+	CurrentStep  string          `json:"current_step,omitempty"`
+	DependsOn    []string        `json:"depends_on,omitempty"` // namespace/name of the pods migrated first
+	// Why the child was never started, e.g. a dependency failed
+	SkippedReason string `json:"skipped_reason,omitempty"`
+}
@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// ClusterFeature reports whether the cluster supports a behavior the orchestrator
+// depends on
+type ClusterFeature struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// ClusterConnection is the cluster the orchestrator operates against, taken from its
+// kubeconfig or in-cluster config. Credentials are never included.
+type ClusterConnection struct {
+	Server    string `json:"server"`
+	InCluster bool   `json:"in_cluster"`
+	// Kubeconfig file and the context, cluster and user entries of it in use
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	Context    string `json:"context,omitempty"`
+	Cluster    string `json:"cluster,omitempty"`
+	User       string `json:"user,omitempty"`
+	// token, client_certificate, basic, exec, auth_provider:<name> or none
+	AuthMethod            string `json:"auth_method"`
+	InsecureSkipTLSVerify bool   `json:"insecure_skip_tls_verify,omitempty"`
+}
+
+// ClusterCapabilities is the cached result of probing the API server
+type ClusterCapabilities struct {
+	ServerVersion string           `json:"server_version,omitempty"`
+	Platform      string           `json:"platform,omitempty"`
+	Features      []ClusterFeature `json:"features,omitempty"`
+	ProbedAt      time.Time        `json:"probed_at"`
+	Error         string           `json:"error,omitempty"`
+}
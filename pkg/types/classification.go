@@ -0,0 +1,96 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClassificationPolicy decides which container states are migrated. The defaults
+// migrate running and failed containers and skip waiting and completed ones.
+type ClassificationPolicy struct {
+	MigrateWaiting   bool `json:"migrate_waiting"`
+	MigrateRunning   bool `json:"migrate_running"`
+	MigrateCompleted bool `json:"migrate_completed"`
+	// Completed containers of a pod with restartPolicy Always are restarted by the
+	// kubelet, so they can be treated as long-running even when completed ones are not
+	MigrateCompletedRestartAlways bool `json:"migrate_completed_restart_always"`
+	// Completed containers migrated anyway, by name, e.g. one-shot containers whose
+	// side effects are safe to repeat
+	MigrateCompletedContainers []string `json:"migrate_completed_containers,omitempty"`
+	MigrateFailed              bool     `json:"migrate_failed"`
+	// Containers without a reported status yet
+	MigrateUnreported bool `json:"migrate_unreported"`
+	// Exit codes that count as completed rather than failed
+	SuccessExitCodes []int32 `json:"success_exit_codes"`
+	// Containers whose image contains one of these strings are service-mesh or other
+	// injected sidecars and are left out of the migrated pod
+	SidecarImagePatterns []string `json:"sidecar_image_patterns"`
+}
+
+// DefaultSidecarImagePatterns match the proxies injected by common service meshes
+var DefaultSidecarImagePatterns = []string{
+	"istio/proxyv2",
+	"linkerd/proxy",
+	"linkerd2-proxy",
+}
+
+// DefaultClassificationPolicy returns the policy matching the built-in heuristic
+func DefaultClassificationPolicy() ClassificationPolicy {
+	return ClassificationPolicy{
+		MigrateRunning:       true,
+		MigrateFailed:        true,
+		SuccessExitCodes:     []int32{0},
+		SidecarImagePatterns: append([]string{}, DefaultSidecarImagePatterns...),
+	}
+}
+
+// Validate checks the policy for values that cannot be exit codes
+func (p ClassificationPolicy) Validate() error {
+	for _, code := range p.SuccessExitCodes {
+		if code < 0 || code > 255 {
+			return fmt.Errorf("success_exit_codes: %d is not a valid exit code (0-255)", code)
+		}
+	}
+	for _, pattern := range p.SidecarImagePatterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("sidecar_image_patterns: patterns must not be empty")
+		}
+	}
+	for _, name := range p.MigrateCompletedContainers {
+		if name == "" {
+			return fmt.Errorf("migrate_completed_containers: container names must not be empty")
+		}
+	}
+	return nil
+}
+
+// MigratesCompletedContainer reports whether a completed container is migrated
+// anyway because it is listed by name
+func (p ClassificationPolicy) MigratesCompletedContainer(name string) bool {
+	for _, listed := range p.MigrateCompletedContainers {
+		if listed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SidecarPattern returns the pattern an image matches, if it is a known sidecar
+func (p ClassificationPolicy) SidecarPattern(image string) (string, bool) {
+	for _, pattern := range p.SidecarImagePatterns {
+		if strings.Contains(image, pattern) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// IsSuccessExitCode reports whether a terminated container counts as completed
+func (p ClassificationPolicy) IsSuccessExitCode(code int32) bool {
+	for _, success := range p.SuccessExitCodes {
+		if code == success {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package types
+
+import "time"
+
+// ControllerDebugState is a point-in-time dump of the migration controller's
+// internal bookkeeping, for troubleshooting stuck or leaking controllers
+type ControllerDebugState struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Goroutines int       `json:"goroutines"`
+
+	Migrations []DebugMigration `json:"migrations"`
+	// Pods with an unfinished migration, as namespace/name
+	PodsInFlight []string `json:"pods_in_flight"`
+	// Unfinished migrations per source and target node
+	SourceNodeCounts map[string]int `json:"source_node_counts"`
+	TargetNodeCounts map[string]int `json:"target_node_counts"`
+
+	Scheduler DebugScheduler `json:"scheduler"`
+
+	ActiveBatches  []string `json:"active_batches"`
+	SinkQueueDepth int      `json:"sink_queue_depth"`
+}
+
+// DebugMigration is the internal state of one tracked migration
+type DebugMigration struct {
+	ID           string          `json:"id"`
+	Status       MigrationStatus `json:"status"`
+	Pod          string          `json:"pod"`
+	SourceNode   string          `json:"source_node"`
+	TargetNode   string          `json:"target_node"`
+	CurrentStep  string          `json:"current_step,omitempty"`
+	CancelReason string          `json:"cancel_reason,omitempty"`
+	Finished     bool            `json:"finished"`
+	Age          string          `json:"age"`
+	LogEntries   int             `json:"log_entries"`
+}
+
+// DebugScheduler is the state of the execution slot scheduler
+type DebugScheduler struct {
+	Limit              int            `json:"limit"`
+	Running            []string       `json:"running"`
+	Protected          []string       `json:"protected"`
+	Waiting            []DebugWaiter  `json:"waiting"`
+	RunningByNamespace map[string]int `json:"running_by_namespace"`
+}
+
+// DebugWaiter is a migration queued for an execution slot, in queue order
+type DebugWaiter struct {
+	MigrationID string `json:"migration_id"`
+	Namespace   string `json:"namespace"`
+	Priority    int    `json:"priority"`
+}
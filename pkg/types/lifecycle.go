@@ -0,0 +1,17 @@
+package types
+
+// LifecycleState is where the orchestrator process is in its lifetime
+type LifecycleState string
+
+const (
+	LifecycleRunning  LifecycleState = "running"
+	LifecycleDraining LifecycleState = "draining"
+	LifecycleStopped  LifecycleState = "stopped"
+)
+
+// OrchestratorStatus reports the lifecycle state and the work still in progress
+type OrchestratorStatus struct {
+	State              LifecycleState `json:"state"`
+	InFlightMigrations int            `json:"in_flight_migrations"`
+	InFlightBatches    int            `json:"in_flight_batches"`
+}
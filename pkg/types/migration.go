@@ -10,12 +10,205 @@ type MigrationRequest struct {
 	SourceNode   string `json:"source_node" binding:"required"`
 	
 	// Target node information  
-	TargetNode string `json:"target_node" binding:"required"`
+	TargetNode string `json:"target_node"`
+
+	// Instead of target_node, the labels of a node pool; the schedulable matching node
+	// with the most free capacity becomes the target node
+	TargetNodeSelector map[string]string `json:"target_node_selector,omitempty"`
+
+	// Name for the new pod, e.g. when something references the pod by name;
+	// defaults to new_pod_name_template rendered for the migration
+	NewPodName string `json:"new_pod_name,omitempty"`
+
+	// Name of the Service kept in front of the new pod with ensure_service;
+	// defaults to <pod_name>-stable
+	ServiceName string `json:"service_name,omitempty"`
+
+	// Command and args replacing those of the named migrated containers, e.g. to tell
+	// the app it resumes from a checkpoint; containers not listed keep theirs
+	CommandOverrides map[string][]string `json:"command_overrides,omitempty"`
+	ArgsOverrides    map[string][]string `json:"args_overrides,omitempty"`
+
+	// CPU and memory requests and limits replacing those of the named migrated
+	// containers, e.g. as tried out with the analyze endpoint's overrides
+	ResourceOverrides map[string]ResourceOverride `json:"resource_overrides,omitempty"`
 	
+	// Name of a saved preset supplying default options; options set in the request take precedence
+	Preset string `json:"preset,omitempty"`
+
+	// pod_spec_hash of a plan the caller reviewed, e.g. from a dry run; the migration
+	// fails at capture if the pod changed since, and is never planned again
+	ExpectedPodSpecHash string `json:"expected_pod_spec_hash,omitempty"`
+
+	// Correlation ID of the HTTP request that started the migration (X-Request-ID)
+	RequestID string `json:"-"`
+
+	// Step at which to synthesize a failure, from the debug-only fail-at query parameter
+	FailAt string `json:"-"`
+
 	// Migration options
+	MigrationOptions
+}
+
+// MigrationOptions holds the tunable options of a migration, shared by requests and presets
+type MigrationOptions struct {
+	// PreservePV creates a checkpoint PVC and mounts it into the migrated containers.
+	// ForceRestart takes precedence: the containers start cold without a checkpoint
+	// even when PreservePV is set, for when the preserved state is known to be bad.
 	PreservePV    bool   `json:"preserve_pv,omitempty"`
 	ForceRestart  bool   `json:"force_restart,omitempty"`
 	Timeout       int    `json:"timeout,omitempty"` // seconds
+
+	// Size of the checkpoint PVC as a Kubernetes quantity; defaults to the pod's memory usage plus
+	// --checkpoint-size-margin, or to --checkpoint-size when usage is unknown
+	CheckpointSize string `json:"checkpoint_size,omitempty"`
+
+	// Access mode of the checkpoint PVC (e.g. ReadWriteMany); defaults to --checkpoint-access-mode
+	CheckpointAccessMode string `json:"checkpoint_access_mode,omitempty"`
+
+	// Save the complete manifest of the original pod under --snapshot-dir before
+	// anything is changed, to restore it by hand if all else fails
+	SnapshotOriginal bool `json:"snapshot_original,omitempty"`
+
+	// Template naming the new pod when new_pod_name is not given, e.g.
+	// {original}-{targetnode}; defaults to --pod-name-template. Placeholders: {original},
+	// {namespace}, {sourcenode}, {targetnode}, {shortid} and {timestamp}
+	NewPodNameTemplate string `json:"new_pod_name_template,omitempty"`
+
+	// Delete the original pod even if a PodDisruptionBudget currently allows no disruptions
+	ForceIgnorePDB bool `json:"force_ignore_pdb,omitempty"`
+
+	// Command executed in the migrated pod once it is ready; a non-zero exit rolls the migration back
+	VerifyCommand   []string `json:"verify_command,omitempty"`
+	VerifyContainer string   `json:"verify_container,omitempty"` // defaults to the first migrated container
+
+	// Keep the tail of the original pod's container logs in the migration details: for
+	// containers dropped by the migration, and for every container when it fails
+	CaptureLogs     bool  `json:"capture_logs,omitempty"`
+	CaptureLogLines int64 `json:"capture_log_lines,omitempty"` // defaults to 100
+
+	// Pod condition types that must all be True before the new pod counts as ready, e.g.
+	// readiness-gate conditions such as ModelLoaded; defaults to the standard Ready condition
+	ReadinessConditions []string `json:"readiness_conditions,omitempty"`
+	// Containers allowed to stay unready, e.g. an optional component that never
+	// initializes: the new pod counts as ready once all other containers are
+	TolerateUnreadyContainers []string `json:"tolerate_unready_containers,omitempty"`
+
+	// Scheduling: higher priorities get execution slots first, and may take the slot of a
+	// running lower-priority migration that opted in with Preemptible
+	Priority    int  `json:"priority,omitempty"`
+	Preemptible bool `json:"preemptible,omitempty"`
+
+	// Sets of container names that must migrate together: if any member is kept, all are
+	ContainerGrouping [][]string `json:"container_grouping,omitempty"`
+
+	// How the new pod is put on the target node: node_name (default) sets nodeName and
+	// bypasses the scheduler; scheduler requires the node through node affinity, so the
+	// pod's topology spread constraints and other scheduling rules still apply
+	Placement string `json:"placement,omitempty"`
+
+	// Point a ClusterIP Service at the new pod so consumers keep a stable address,
+	// creating it from the containers' ports if it does not exist
+	EnsureService bool `json:"ensure_service,omitempty"`
+
+	// Skip the check that source and target node share CPU architecture and OS, for
+	// multi-arch images
+	AllowArchMismatch bool `json:"allow_arch_mismatch,omitempty"`
+
+	// Create a single-replica Deployment pinned to the target node instead of a bare pod,
+	// so the migrated workload is recreated if its pod dies
+	WrapInDeployment bool `json:"wrap_in_deployment,omitempty"`
+
+	// What must hold before the migration counts as successful and the original pod is
+	// deleted: pod_created, pod_ready (default), probe_passed (verify_command exits 0)
+	// or stability_window (ready without restarts for stability_window_seconds, default 60)
+	SuccessCriteria        string `json:"success_criteria,omitempty"`
+	StabilityWindowSeconds int    `json:"stability_window_seconds,omitempty"`
+
+	// Pull the new pod's images onto the target node before creating it, through a
+	// short-lived helper pod, so the cutover does not wait on image pulls
+	PrePullImages bool `json:"pre_pull_images,omitempty"`
+
+	// Sample the new pod's usage every SampleInterval seconds (default 30) for
+	// SampleDuration seconds (default 600) after completion, at most 120 samples
+	SampleUsage    bool `json:"sample_usage,omitempty"`
+	SampleInterval int  `json:"sample_interval,omitempty"`
+	SampleDuration int  `json:"sample_duration,omitempty"`
+
+	// Carry containers matching the sidecar image patterns over to the new pod
+	// instead of leaving them out
+	IncludeSidecars bool `json:"include_sidecars,omitempty"`
+
+	// Completed containers to recreate in the new pod anyway, by name, in addition to
+	// the policy's migrate_completed_containers; completed containers are otherwise
+	// left out so one-shot work is not run again
+	MigrateCompletedContainers []string `json:"migrate_completed_containers,omitempty"`
+}
+
+// DeepCopy returns a copy of the options sharing no slices with o
+func (o MigrationOptions) DeepCopy() MigrationOptions {
+	out := o
+	out.VerifyCommand = copyStrings(o.VerifyCommand)
+	out.ReadinessConditions = copyStrings(o.ReadinessConditions)
+	out.TolerateUnreadyContainers = copyStrings(o.TolerateUnreadyContainers)
+	out.MigrateCompletedContainers = copyStrings(o.MigrateCompletedContainers)
+	if o.ContainerGrouping != nil {
+		out.ContainerGrouping = make([][]string, len(o.ContainerGrouping))
+		for i, group := range o.ContainerGrouping {
+			out.ContainerGrouping[i] = copyStrings(group)
+		}
+	}
+	return out
+}
+
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(in)), in...)
+}
+
+// NodeSelection records how a target node was picked from a node selector
+type NodeSelection struct {
+	Selector map[string]string `json:"selector"`
+	Node     string            `json:"node"`
+	// Nodes considered by the latest selection, best first
+	Candidates []NodeScore `json:"candidates,omitempty"`
+}
+
+// NodeScore is a node considered for a target node selector and how it ranked
+type NodeScore struct {
+	Node  string  `json:"node"`
+	Score float64 `json:"score"` // average free CPU and memory fraction; -1 when usage is unknown
+}
+
+// NodeReselection records a move to another target node after the previous one
+// stopped being Ready while the new pod was being created
+type NodeReselection struct {
+	FromNode string    `json:"from_node"`
+	ToNode   string    `json:"to_node"`
+	Reason   string    `json:"reason"`
+	Time     time.Time `json:"time"`
+}
+
+// Success criteria of a migration, from least to most strict
+const (
+	SuccessCriteriaPodCreated      = "pod_created"
+	SuccessCriteriaPodReady        = "pod_ready"
+	SuccessCriteriaProbePassed     = "probe_passed"
+	SuccessCriteriaStabilityWindow = "stability_window"
+)
+
+// Placement modes of the new pod
+const (
+	PlacementNodeName  = "node_name"
+	PlacementScheduler = "scheduler"
+)
+
+// MigrationPreset is a named, reusable set of migration options
+type MigrationPreset struct {
+	Name    string           `json:"name" binding:"required"`
+	Options MigrationOptions `json:"options"`
 }
 
 // MigrationResponse represents the response for a migration request
@@ -24,6 +217,23 @@ type MigrationResponse struct {
 	Status      MigrationStatus        `json:"status"`
 	Message     string                 `json:"message"`
 	Details     *MigrationDetails      `json:"details,omitempty"`
+	// Final record of a migration in a terminal status
+	Summary *MigrationSummary `json:"summary,omitempty"`
+
+	// Why a pending migration is still waiting for an execution slot
+	QueueReason string `json:"queue_reason,omitempty"`
+	// Place of a queued migration in line for a slot (1 is next), and when it is
+	// expected to start based on the average migration duration
+	QueuePosition      int            `json:"queue_position,omitempty"`
+	EstimatedWait      *time.Duration `json:"estimated_wait,omitempty"`
+	EstimatedStartTime *time.Time     `json:"estimated_start_time,omitempty"`
+}
+
+// BulkMigrationStatus is one entry of a bulk status lookup
+type BulkMigrationStatus struct {
+	Found     bool               `json:"found"`
+	Error     string             `json:"error,omitempty"`
+	Migration *MigrationResponse `json:"migration,omitempty"`
 }
 
 // MigrationStatus represents the current status of a migration
@@ -35,43 +245,399 @@ const (
 	MigrationStatusCompleted  MigrationStatus = "completed"
 	MigrationStatusFailed     MigrationStatus = "failed"
 	MigrationStatusCancelled  MigrationStatus = "cancelled"
+
+	// The new pod is running but the original pod was kept because of safe mode
+	MigrationStatusCompletedSafe MigrationStatus = "completed_safe"
+
+	// The new pod is ready and the original pod is about to be replaced
+	MigrationStatusAwaitingCutover MigrationStatus = "awaiting_cutover"
+	// Resources created for a failed migration are being removed
+	MigrationStatusRollingBack MigrationStatus = "rolling_back"
+	// The new pod replaced the original, but only some of its containers became
+	// ready; kept under --partial-readiness-policy=keep
+	MigrationStatusPartiallyDegraded MigrationStatus = "partially_degraded"
 )
 
+// Succeeded reports whether a migration ended with its new pod in place: completed,
+// completed_safe or partially_degraded
+func (s MigrationStatus) Succeeded() bool {
+	switch s {
+	case MigrationStatusCompleted, MigrationStatusCompletedSafe, MigrationStatusPartiallyDegraded:
+		return true
+	}
+	return false
+}
+
 // MigrationDetails contains detailed information about the migration process
 type MigrationDetails struct {
-	StartTime     time.Time              `json:"start_time"`
-	EndTime       *time.Time             `json:"end_time,omitempty"`
-	Duration      *time.Duration         `json:"duration,omitempty"`
+	// Correlation ID of the originating HTTP request
+	RequestID string         `json:"request_id,omitempty"`
+	StartTime time.Time      `json:"start_time"`
+	EndTime   *time.Time     `json:"end_time,omitempty"`
+	Duration  *time.Duration `json:"duration,omitempty"`
+	// How long the new pod took to satisfy its readiness conditions after creation
+	TimeToReady *time.Duration `json:"time_to_ready,omitempty"`
+	// Tolerated containers that were not ready when the new pod counted as ready
+	ToleratedUnreadyContainers []string `json:"tolerated_unready_containers,omitempty"`
+	// Readiness of each container of a new pod that did not become ready in time, and
+	// the unready ones of a partially ready pod kept anyway
+	ContainerReadiness []ContainerReadiness `json:"container_readiness,omitempty"`
+	DegradedContainers []string             `json:"degraded_containers,omitempty"`
+	// Label keys of the original pod left off the new pod
+	StrippedLabels []string `json:"stripped_labels,omitempty"`
+	// Set when the target node was picked by target_node_selector
+	TargetNodeSelection *NodeSelection `json:"target_node_selection,omitempty"`
+	// Target nodes abandoned because they stopped being Ready, in order
+	NodeReselections []NodeReselection `json:"node_reselections,omitempty"`
+	// How long the new pod was Pending before being scheduled, and from scheduling
+	// until all its containers had started; nil if it never got that far
+	SchedulingLatency *time.Duration `json:"scheduling_latency,omitempty"`
+	StartupLatency    *time.Duration `json:"startup_latency,omitempty"`
+
+	// The success criteria the migration met and the evidence for it
+	SuccessEvidence *SuccessEvidence `json:"success_evidence,omitempty"`
+
+	// Result of pre_pull_images
+	ImagePrePull *ImagePrePull `json:"image_pre_pull,omitempty"`
+
+	// Usage of the new pod sampled after completion when sample_usage was requested
+	UsageSamples []ResourceUsage `json:"usage_samples,omitempty"`
 	
 	// Resource usage before migration
 	OriginalResources *ResourceUsage     `json:"original_resources,omitempty"`
 	// Resource usage after migration  
 	OptimizedResources *ResourceUsage    `json:"optimized_resources,omitempty"`
 	
+	// What the migration decided to do in its capture step, and then did
+	Plan *MigrationPlan `json:"plan,omitempty"`
+	// Hash of the original pod the current plan was made from, and every change of
+	// the pod found before acting on a plan
+	PodSpecHash string      `json:"pod_spec_hash,omitempty"`
+	SpecDrifts  []SpecDrift `json:"spec_drifts,omitempty"`
+	// Containers of the original pod with PostStart or PreStop hooks
+	LifecycleHooks []ContainerHooks `json:"lifecycle_hooks,omitempty"`
+
 	// Container status information
 	ContainerStates []ContainerState    `json:"container_states,omitempty"`
+	// Requested versus used resources of each migrated container
+	ResourceGaps []ContainerResourceGap `json:"resource_gaps,omitempty"`
 	
 	// PV checkpoint information
 	CheckpointPath  string             `json:"checkpoint_path,omitempty"`
 	PVClaimName     string             `json:"pv_claim_name,omitempty"`
+	// Size of the checkpoint PVC and what it was derived from: request, memory_usage or default
+	CheckpointSize       string `json:"checkpoint_size,omitempty"`
+	CheckpointSizeBasis  string `json:"checkpoint_size_basis,omitempty"`
+	CheckpointAccessMode string `json:"checkpoint_access_mode,omitempty"`
+	// How the checkpoint PVC was bound so the new pod can reach it
+	CheckpointBinding *CheckpointBinding `json:"checkpoint_binding,omitempty"`
+	// When the checkpoint PVC will be deleted, per the pod's orchestrator/checkpoint-retention annotation
+	CheckpointExpiresAt *time.Time `json:"checkpoint_expires_at,omitempty"`
+	// Why no checkpoint was used although preserve_pv was requested
+	CheckpointSkippedReason string `json:"checkpoint_skipped_reason,omitempty"`
+	// Checksum of the checkpoint as created and its check before the new pod restores it
+	CheckpointVerification *CheckpointVerification `json:"checkpoint_verification,omitempty"`
 	
 	// New pod information after migration
-	NewPodName      string             `json:"new_pod_name,omitempty"`
+	NewPodName string `json:"new_pod_name,omitempty"`
+	// Verdict of the external policy service, when one is configured
+	PolicyDecision *PolicyDecision `json:"policy_decision,omitempty"`
+	// Where the manifest of the original pod was saved, with snapshot_original
+	OriginalSnapshot string `json:"original_snapshot,omitempty"`
+	// Template the new pod's name was rendered from, unless new_pod_name was given
+	NewPodNameTemplate string `json:"new_pod_name_template,omitempty"`
+	DeploymentName     string `json:"deployment_name,omitempty"` // set when wrap_in_deployment was requested
+	Placement          string `json:"placement,omitempty"`
+	ServiceName        string `json:"service_name,omitempty"`
+	ServiceCreated     bool   `json:"service_created,omitempty"` // false when an existing Service was repointed
+	// PVCs of the original pod only one node can attach; when set, the original pod
+	// is deleted before the new one is created instead of after it is ready
+	SingleNodeClaims    []string `json:"single_node_claims,omitempty"`
+	DeleteOriginalFirst bool     `json:"delete_original_first,omitempty"`
+	// What rollback did instead of deleting the new pod once the original was gone:
+	// kept the new pod, or recreated the original from its captured manifest
+	NewPodKept        bool `json:"new_pod_kept,omitempty"`
+	OriginalRecreated bool `json:"original_recreated,omitempty"`
+	// Set when an earlier migration already moved the pod and NewPodName is the pod
+	// it created; nothing was done
+	AlreadyMigrated bool `json:"already_migrated,omitempty"`
+
+	// Decisions taken by the restart count policy (skipped containers, priority boosts)
+	RestartDecisions []string `json:"restart_decisions,omitempty"`
+
+	// Step at which a failure was injected on request; the failure was not organic
+	InjectedFault string `json:"injected_fault,omitempty"`
+
+	// True when safe mode left the original pod running next to the new one
+	OriginalPodRetained bool `json:"original_pod_retained,omitempty"`
+
+	// Command and args overrides applied to the new pod's containers
+	ContainerOverrides []ContainerOverride `json:"container_overrides,omitempty"`
+
+	// Why the migration failed, with a remediation hint for known failures
+	Failure *FailureDetail `json:"failure,omitempty"`
+
+	// Step currently executing, and the time each finished step took
+	CurrentStep string       `json:"current_step,omitempty"`
+	StepTimings []StepTiming `json:"step_timings,omitempty"`
+	// Estimate from the historical average duration of the remaining steps, refreshed
+	// whenever a step completes; omitted until enough history exists
+	EstimatedTimeRemaining *time.Duration `json:"estimated_time_remaining,omitempty"`
+
+	// Utilization of the target node measured with the post-migration metrics
+	TargetNodeUtilization *NodeUtilization `json:"target_node_utilization,omitempty"`
+	// Utilization of the source node before the migration and, once the original pod
+	// is gone, after it
+	SourceNodeRelief *SourceNodeRelief `json:"source_node_relief,omitempty"`
+
+	// True while optimized resources are still being collected after completion
+	MetricsPending bool `json:"metrics_pending,omitempty"`
+
+	// Result of the post-migration verification command, if one was requested
+	Verification *VerificationResult `json:"verification,omitempty"`
+
+	// Namespace quota headroom checked before creating the optimized pod
+	QuotaHeadroom []QuotaHeadroom `json:"quota_headroom,omitempty"`
+
+	// PodDisruptionBudget decision taken before deleting the original pod
+	PDBCheck *PDBCheck `json:"pdb_check,omitempty"`
+
+	// Set when this migration was cancelled to free its slot, or took another's slot
+	PreemptedBy *PreemptionRecord `json:"preempted_by,omitempty"`
+	Preempted   *PreemptionRecord `json:"preempted,omitempty"`
+
+	// How each requested container group affected which containers were migrated
+	GroupingDecisions []ContainerGroupDecision `json:"grouping_decisions,omitempty"`
+
+	// Log tails of the original pod captured when capture_logs was requested
+	CapturedLogs []CapturedLog `json:"captured_logs,omitempty"`
+}
+
+// PreemptionRecord identifies the other migration involved in a preemption
+type PreemptionRecord struct {
+	MigrationID string    `json:"migration_id"`
+	Priority    int       `json:"priority"`
+	Time        time.Time `json:"time"`
+}
+
+// StepTiming records how long one migration step took
+type StepTiming struct {
+	Step      string        `json:"step"`
+	StartTime time.Time     `json:"start_time"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// ContainerGroupDecision records the outcome of one container group
+type ContainerGroupDecision struct {
+	Containers []string `json:"containers"`
+	Kept       bool     `json:"kept"`               // whether the group is migrated
+	Promoted   []string `json:"promoted,omitempty"` // members kept only because of the group
+	Missing    []string `json:"missing,omitempty"`  // members not found in the pod
+}
+
+// CapturedLog holds the tail of one container's logs taken from the original pod
+type CapturedLog struct {
+	Container string    `json:"container"`
+	Reason    string    `json:"reason"` // dropped or failure
+	Log       string    `json:"log,omitempty"`
+	Truncated bool      `json:"truncated,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// QuotaHeadroom records how one quota-constrained resource accommodates the new pod
+type QuotaHeadroom struct {
+	Quota     string `json:"quota"`
+	Resource  string `json:"resource"`
+	Hard      string `json:"hard"`
+	Used      string `json:"used"`      // includes the still-running original pod
+	Required  string `json:"required"`  // needed by the optimized pod and checkpoint
+	Remaining string `json:"remaining"` // hard minus used, before the migration
+	Fits      bool   `json:"fits"`
+}
+
+// ContainerOverride records the command, args and resources given to a migrated
+// container and the ones it had; each is nil when it was not overridden
+type ContainerOverride struct {
+	Container         string            `json:"container"`
+	Command           []string          `json:"command,omitempty"`
+	Args              []string          `json:"args,omitempty"`
+	Resources         *ResourceOverride `json:"resources,omitempty"`
+	OriginalCommand   []string          `json:"original_command,omitempty"`
+	OriginalArgs      []string          `json:"original_args,omitempty"`
+	OriginalResources *ResourceOverride `json:"original_resources,omitempty"` // the overridden amounts only
+}
+
+// FailureDetail describes why a migration failed. Error is the raw error; Class and
+// Hint are set when the error is of a known kind.
+type FailureDetail struct {
+	Step  string `json:"step,omitempty"`
+	Error string `json:"error"`
+	Class string `json:"class,omitempty"`
+	Hint  string `json:"hint,omitempty"`
+}
+
+// PDBCheck records how PodDisruptionBudgets affected deleting the original pod
+type PDBCheck struct {
+	Budgets  []string `json:"budgets,omitempty"` // PDBs selecting the original pod
+	Allowed  bool     `json:"allowed"`
+	Ignored  bool     `json:"ignored,omitempty"` // set when force_ignore_pdb was requested
+	Attempts int      `json:"attempts"`
+	Message  string   `json:"message,omitempty"`
+}
+
+// Outcomes of binding the checkpoint PVC
+const (
+	// Bound immediately to a volume reachable from the target node
+	CheckpointBindingBound = "bound"
+	// WaitForFirstConsumer: the provisioner was told to place the volume on the target node
+	CheckpointBindingDeferred = "deferred_to_target"
+	// No storage class information, placement could not be checked
+	CheckpointBindingUnverified = "unverified"
+)
+
+// What a migration did with a checkpoint after checking its integrity
+const (
+	CheckpointActionRestore     = "restore"
+	CheckpointActionFail        = "fail"
+	CheckpointActionColdRestart = "cold_restart"
+)
+
+// CheckpointVerification records the checksum of a checkpoint and whether it still
+// matched when the new pod was about to restore from it
+type CheckpointVerification struct {
+	Checksum string `json:"checksum"`
+	// Whether the checksum covers the volume, which WaitForFirstConsumer storage
+	// classes only bind for the new pod
+	VolumeBound bool       `json:"volume_bound"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"`
+	Passed      bool       `json:"passed"`
+	Reason      string     `json:"reason,omitempty"`
+	// restore, fail or cold_restart
+	Action string `json:"action,omitempty"`
+}
+
+// CheckpointBinding records how the checkpoint PVC was placed relative to the new pod
+type CheckpointBinding struct {
+	StorageClass string `json:"storage_class,omitempty"`
+	BindingMode  string `json:"binding_mode,omitempty"`
+	SelectedNode string `json:"selected_node,omitempty"` // node handed to a WaitForFirstConsumer provisioner
+	VolumeName   string `json:"volume_name,omitempty"`
+	Outcome      string `json:"outcome"`
+	Message      string `json:"message,omitempty"`
+}
+
+// SuccessEvidence records how a migration met its success criteria
+type SuccessEvidence struct {
+	Criteria        string         `json:"criteria"`
+	MetAt           time.Time      `json:"met_at"`
+	Detail          string         `json:"detail"`
+	ProbeOutput     string         `json:"probe_output,omitempty"`
+	StabilityWindow *time.Duration `json:"stability_window,omitempty"`
+}
+
+// VerificationResult records the outcome of a verification command run in the new pod
+type VerificationResult struct {
+	Container string   `json:"container"`
+	Command   []string `json:"command"`
+	ExitCode  int      `json:"exit_code"`
+	Output    string   `json:"output,omitempty"`
+	Passed    bool     `json:"passed"`
+}
+
+// MigrationLogEntry is a single progress line recorded for a migration
+type MigrationLogEntry struct {
+	Sequence  int64     `json:"sequence"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
+// ImagePrePull reports the images pulled onto the target node ahead of the new pod
+type ImagePrePull struct {
+	Pulled         []string      `json:"pulled,omitempty"`
+	AlreadyPresent []string      `json:"already_present,omitempty"`
+	Failed         []string      `json:"failed,omitempty"`
+	HelperPod      string        `json:"helper_pod,omitempty"`
+	Duration       time.Duration `json:"duration"`
 }
 
 // ResourceUsage represents CPU and memory usage
 type ResourceUsage struct {
 	CPUUsage    float64 `json:"cpu_usage"`    // CPU cores
 	MemoryUsage int64   `json:"memory_usage"` // bytes
-	Timestamp   time.Time `json:"timestamp"`
+	// GPUs' worth of utilization: the sum of each GPU's busy fraction, so 1.5 is one
+	// fully and one half busy GPU. Zero for pods without GPUs or without a GPU source.
+	GPUUsage  float64   `json:"gpu_usage,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NodeUtilization represents a node's usage relative to its allocatable resources
+type NodeUtilization struct {
+	Node          string    `json:"node"`
+	CPUUsage      float64   `json:"cpu_usage"`    // CPU cores
+	MemoryUsage   int64     `json:"memory_usage"` // bytes
+	CPUPercent    float64   `json:"cpu_percent"`
+	MemoryPercent float64   `json:"memory_percent"`
+	UnderPressure bool      `json:"under_pressure"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// SourceNodeRelief compares the source node before a migration with the node after
+// the original pod was deleted. The freed amounts are before minus after, so they
+// are negative when other work grew on the node meanwhile.
+type SourceNodeRelief struct {
+	Node   string           `json:"node"`
+	Before *NodeUtilization `json:"before"`
+	After  *NodeUtilization `json:"after,omitempty"`
+
+	CPUFreed    float64 `json:"cpu_freed"`    // CPU cores
+	MemoryFreed int64   `json:"memory_freed"` // bytes
+	// Drop in utilization, in percentage points of allocatable
+	CPUPercentRelief    float64 `json:"cpu_percent_relief"`
+	MemoryPercentRelief float64 `json:"memory_percent_relief"`
 }
 
 // ContainerState represents the state of a container during migration
 type ContainerState struct {
-	Name        string `json:"name"`
-	State       string `json:"state"`       // waiting, running, completed  
+	Name          string `json:"name"`
+	State         string `json:"state"` // waiting, running, completed
+	RestartCount  int32  `json:"restart_count"`
+	ShouldMigrate bool   `json:"should_migrate"`   // whether this container should be migrated
+	Reason        string `json:"reason,omitempty"` // why the container is or isn't migrated
+}
+
+// ContainerReadiness is the readiness of one container of the new pod
+type ContainerReadiness struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
 	RestartCount int32  `json:"restart_count"`
-	ShouldMigrate bool  `json:"should_migrate"` // whether this container should be migrated
+	State        string `json:"state"`            // waiting, running or terminated
+	Reason       string `json:"reason,omitempty"` // e.g. CrashLoopBackOff
+}
+
+// ContainerHooks records the lifecycle hooks of a container and how they are run:
+// exec, http_get or tcp_socket
+type ContainerHooks struct {
+	Container string `json:"container"`
+	PostStart string `json:"post_start,omitempty"`
+	PreStop   string `json:"pre_stop,omitempty"`
+	Migrated  bool   `json:"migrated"` // whether the container, and its hooks, are kept
+}
+
+// ContainerResourceGap compares the resources a migrated container requests with
+// what it was using before migration. Waste is requested minus used, negative when
+// the container uses more than it requests, and is left out for resources without
+// both a request and a usage reading.
+type ContainerResourceGap struct {
+	Container          string   `json:"container"`
+	RequestedCPU       float64  `json:"requested_cpu"` // CPU cores
+	UsedCPU            float64  `json:"used_cpu"`
+	CPUWaste           *float64 `json:"cpu_waste,omitempty"`
+	CPUWastePercent    *float64 `json:"cpu_waste_percentage,omitempty"`
+	RequestedMemory    int64    `json:"requested_memory"` // bytes
+	UsedMemory         int64    `json:"used_memory"`
+	MemoryWaste        *int64   `json:"memory_waste,omitempty"`
+	MemoryWastePercent *float64 `json:"memory_waste_percentage,omitempty"`
 }
 
 // MigrationMetrics represents performance metrics for migrations
@@ -82,4 +648,134 @@ type MigrationMetrics struct {
 	AverageDuration    time.Duration `json:"average_duration"`
 	CPUSavings         float64       `json:"cpu_savings_percentage"`
 	MemorySavings      float64       `json:"memory_savings_percentage"`
+	// GPU savings of the most recent completed migration of a pod using GPUs
+	GPUSavings float64 `json:"gpu_savings_percentage"`
+
+	// Net resources reclaimed across all completed migrations: the sum of original
+	// minus optimized usage, so a migration that grew its pod counts negatively
+	CPUCoresSaved    float64 `json:"cpu_cores_saved"`
+	MemoryBytesSaved int64   `json:"memory_bytes_saved"`
+	GPUsSaved        float64 `json:"gpus_saved"`
+
+	// Average duration of each successfully completed migration step
+	AverageStepDurations map[string]time.Duration `json:"average_step_durations,omitempty"`
+
+	// Average time completed migrations' new pods spent Pending before scheduling and
+	// from scheduling until their containers started
+	AverageSchedulingLatency time.Duration `json:"average_scheduling_latency"`
+	AverageStartupLatency    time.Duration `json:"average_startup_latency"`
+
+	// Average drop in source node CPU and memory utilization, in percentage points,
+	// over the completed migrations that deleted their original pod
+	AverageSourceCPURelief    float64 `json:"average_source_cpu_relief"`
+	AverageSourceMemoryRelief float64 `json:"average_source_memory_relief"`
+
+	// Average gap between requested and used CPU cores and memory bytes of migrated
+	// containers, over the containers with both a request and a usage reading
+	AverageCPUWaste    float64 `json:"average_cpu_waste"`
+	AverageMemoryWaste int64   `json:"average_memory_waste"`
+
+	// Migrations currently holding an execution slot, per namespace
+	ActiveByNamespace map[string]int `json:"active_by_namespace,omitempty"`
+
+	// Migrations allowed to execute at once, lowered by backpressure while the API
+	// server throttles, and the requests it has answered with 429 so far
+	EffectiveConcurrency int   `json:"effective_concurrency"`
+	APIThrottledRequests int64 `json:"api_throttled_requests"`
+
+	// Migration log lines held back from the process log by sampling
+	LogMessagesSuppressed int64 `json:"log_messages_suppressed"`
+
+	// Throughput from a sliding window of recent completions
+	Rate *MigrationRate `json:"rate,omitempty"`
+}
+
+// StepDurationHistogram is the distribution of the durations of a migration step
+type StepDurationHistogram struct {
+	Step    string            `json:"step"`
+	Buckets []HistogramBucket `json:"buckets"` // cumulative, by increasing upper bound
+	Count   int64             `json:"count"`
+	Sum     time.Duration     `json:"sum"`
+}
+
+// HistogramBucket counts the observations at or below an upper bound
+type HistogramBucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      int64         `json:"count"`
+}
+
+// Where a MetricsSummary read its records from
+const (
+	MetricsSourceSink   = "sink"   // the migration sink, including migrations no longer in memory
+	MetricsSourceMemory = "memory" // the migrations kept in memory, without a sink to read back
+)
+
+// MetricsSummary aggregates the migrations that finished within a time window.
+// Savings are averaged over the migrations with both measurements.
+type MetricsSummary struct {
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Source string    `json:"source"` // where the records were read from: MetricsSourceSink or MetricsSourceMemory
+
+	TotalMigrations      int64   `json:"total_migrations"`
+	SuccessfulMigrations int64   `json:"successful_migrations"`
+	FailedMigrations     int64   `json:"failed_migrations"`
+	CancelledMigrations  int64   `json:"cancelled_migrations"`
+	SuccessRate          float64 `json:"success_rate_percentage"`
+	// Average duration of the successful migrations
+	AverageDuration time.Duration `json:"average_duration"`
+
+	MeasuredMigrations int64   `json:"measured_migrations"`
+	CPUSavings         float64 `json:"cpu_savings_percentage"`
+	MemorySavings      float64 `json:"memory_savings_percentage"`
+	CPUCoresSaved      float64 `json:"cpu_cores_saved"`
+	MemoryBytesSaved   int64   `json:"memory_bytes_saved"`
+	GPUsSaved          float64 `json:"gpus_saved"`
+}
+
+// MigrationRate is the recent throughput of migrations ending as completed or failed
+type MigrationRate struct {
+	PerMinute        int      `json:"per_minute"`                        // finished in the last minute
+	AveragePerMinute float64  `json:"average_per_minute"`                // moving average over the last 5 minutes
+	SuccessRate      *float64 `json:"success_rate_percentage,omitempty"` // over the last 5 minutes; nil without data
+}
+
+// MigrationRecord is the flattened record of a finished migration exported to external
+// stores. Pointer fields are nil when the value was not measured.
+type MigrationRecord struct {
+	MigrationID        string          `json:"migration_id"`
+	RequestID          string          `json:"request_id,omitempty"`
+	PodName            string          `json:"pod_name"`
+	PodNamespace       string          `json:"pod_namespace"`
+	SourceNode         string          `json:"source_node"`
+	TargetNode         string          `json:"target_node"`
+	NewPodName         string          `json:"new_pod_name,omitempty"`
+	Status             MigrationStatus `json:"status"`
+	StartTime          time.Time       `json:"start_time"`
+	EndTime            *time.Time      `json:"end_time,omitempty"`
+	DurationMs         *int64          `json:"duration_ms,omitempty"`
+	ContainersTotal    int             `json:"containers_total"`
+	ContainersMigrated int             `json:"containers_migrated"`
+	CheckpointPVC      string          `json:"checkpoint_pvc,omitempty"`
+	OriginalCPU        *float64        `json:"original_cpu_cores,omitempty"`
+	OriginalMemory     *int64          `json:"original_memory_bytes,omitempty"`
+	OptimizedCPU       *float64        `json:"optimized_cpu_cores,omitempty"`
+	OptimizedMemory    *int64          `json:"optimized_memory_bytes,omitempty"`
+	CPUSavings         *float64        `json:"cpu_savings_percentage,omitempty"`
+	MemorySavings      *float64        `json:"memory_savings_percentage,omitempty"`
+	OriginalGPU        *float64        `json:"original_gpu_usage,omitempty"`
+	OptimizedGPU       *float64        `json:"optimized_gpu_usage,omitempty"`
+	GPUSavings         *float64        `json:"gpu_savings_percentage,omitempty"`
+}
+
+// PodMigrationEntry is one migration in the history of a pod
+type PodMigrationEntry struct {
+	MigrationID string          `json:"migration_id"`
+	Status      MigrationStatus `json:"status"`
+	PodName     string          `json:"pod_name"`
+	NewPodName  string          `json:"new_pod_name,omitempty"`
+	SourceNode  string          `json:"source_node"`
+	TargetNode  string          `json:"target_node"`
+	StartTime   time.Time       `json:"start_time"`
+	EndTime     *time.Time      `json:"end_time,omitempty"`
 }
@@ -0,0 +1,92 @@
+package types
+
+import "time"
+
+// MigrationPlan describes everything a migration will do, decided from the pod and
+// request before anything is changed. Planning a request without starting it is a
+// dry run; a started migration plans in its capture step, executes the plan, and
+// keeps it in its details.
+type MigrationPlan struct {
+	PodName      string    `json:"pod_name"`
+	PodNamespace string    `json:"pod_namespace"`
+	SourceNode   string    `json:"source_node"`
+	TargetNode   string    `json:"target_node"`
+	PlannedAt    time.Time `json:"planned_at"`
+	// Hash of the pod's identity, labels and spec the plan was made from
+	PodSpecHash string `json:"pod_spec_hash"`
+	// Set when the target node was picked by target_node_selector
+	TargetNodeSelection *NodeSelection `json:"target_node_selection,omitempty"`
+
+	// Every container of the pod, with whether it is kept and why
+	Containers        []ContainerState         `json:"containers"`
+	ContainersKept    int                      `json:"containers_kept"`
+	RestartDecisions  []string                 `json:"restart_decisions,omitempty"`
+	GroupingDecisions []ContainerGroupDecision `json:"grouping_decisions,omitempty"`
+	// Containers with PostStart or PreStop hooks; kept containers keep their hooks
+	LifecycleHooks []ContainerHooks `json:"lifecycle_hooks,omitempty"`
+
+	Checkpoint PlannedCheckpoint `json:"checkpoint"`
+	Cutover    PlannedCutover    `json:"cutover"`
+	Readiness  PlannedReadiness  `json:"readiness"`
+
+	// Measured usage of the pod and the usage projected after migration, when
+	// metrics are available
+	CurrentUsage           *ResourceUsage `json:"current_usage,omitempty"`
+	ProjectedUsage         *ResourceUsage `json:"projected_usage,omitempty"`
+	ProjectedCPUSavings    float64        `json:"projected_cpu_savings_percentage"`
+	ProjectedMemorySavings float64        `json:"projected_memory_savings_percentage"`
+	// Requested versus used resources of each kept container
+	ResourceGaps []ContainerResourceGap `json:"resource_gaps,omitempty"`
+}
+
+// SpecDrift is a change of the original pod found between planning a migration and
+// acting on the plan
+type SpecDrift struct {
+	// Step about to act on the plan
+	Step        string    `json:"step"`
+	DetectedAt  time.Time `json:"detected_at"`
+	PlannedHash string    `json:"planned_hash"`
+	CurrentHash string    `json:"current_hash"`
+	// abort or replan
+	Action string `json:"action"`
+}
+
+// PlannedCheckpoint is the checkpoint PVC the migration will create, if any
+type PlannedCheckpoint struct {
+	Enabled bool `json:"enabled"`
+	// Size of the PVC and what it was derived from: request, memory_usage or default
+	Size       string `json:"size,omitempty"`
+	SizeBasis  string `json:"size_basis,omitempty"`
+	AccessMode string `json:"access_mode,omitempty"`
+	// Why no checkpoint is used
+	SkippedReason string `json:"skipped_reason,omitempty"`
+}
+
+// PlannedCutover is how the original pod will be replaced
+type PlannedCutover struct {
+	// Safe mode keeps the original pod running next to the new one
+	RetainOriginal bool `json:"retain_original"`
+	// Pods with single-node PVCs lose the original pod before the new one is created
+	DeleteOriginalFirst bool     `json:"delete_original_first"`
+	SingleNodeClaims    []string `json:"single_node_claims,omitempty"`
+	// Whether PodDisruptionBudgets are waited for before the original pod is deleted
+	RespectDisruptionBudgets bool `json:"respect_disruption_budgets"`
+	// nodeName pins the new pod to the target node; scheduler lets the scheduler place it
+	Placement        string `json:"placement"`
+	WrapInDeployment bool   `json:"wrap_in_deployment,omitempty"`
+	// The original pod has PreStop hooks, so it is deleted with its own termination
+	// grace period rather than the default 30s to let them finish
+	HonorPreStopHooks bool `json:"honor_pre_stop_hooks,omitempty"`
+}
+
+// PlannedReadiness is when the new pod counts as good enough to cut over to
+type PlannedReadiness struct {
+	SuccessCriteria           string        `json:"success_criteria"`
+	Conditions                []string      `json:"conditions"`
+	TolerateUnreadyContainers []string      `json:"tolerate_unready_containers,omitempty"`
+	Timeout                   time.Duration `json:"timeout"`
+	// Part of Timeout added because a kept container has a PostStart hook
+	PostStartAllowance time.Duration `json:"post_start_allowance,omitempty"`
+	StabilityWindow    time.Duration `json:"stability_window,omitempty"`
+	VerifyCommand      []string      `json:"verify_command,omitempty"`
+}
@@ -0,0 +1,25 @@
+package types
+
+import "time"
+
+// PolicyInput is what the external policy service decides on: the request and the
+// plan of the migration it would start
+type PolicyInput struct {
+	Request *MigrationRequest `json:"request"`
+	Plan    *MigrationPlan    `json:"plan"`
+}
+
+// PolicyDecision records whether the external policy service allowed a migration
+type PolicyDecision struct {
+	Allowed   bool      `json:"allowed"`
+	Reason    string    `json:"reason,omitempty"`
+	Endpoint  string    `json:"endpoint"`
+	DecidedAt time.Time `json:"decided_at"`
+	// Hash of the pod the decision was made on; a migration capturing another pod
+	// has drifted from the approved plan
+	PodSpecHash string `json:"pod_spec_hash,omitempty"`
+	// Why the service gave no decision; the migration was then allowed when failing
+	// open and denied when failing closed
+	Error      string `json:"error,omitempty"`
+	FailedOpen bool   `json:"failed_open,omitempty"`
+}
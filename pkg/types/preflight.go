@@ -0,0 +1,16 @@
+package types
+
+// PreflightCheck is the outcome of one precondition of a migration
+type PreflightCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Skipped bool   `json:"skipped,omitempty"` // not applicable to the request, counts as passed
+	Message string `json:"message"`
+}
+
+// PreflightReport lists every precondition checked for a migration request; nothing
+// is created while producing it
+type PreflightReport struct {
+	Passed bool             `json:"passed"`
+	Checks []PreflightCheck `json:"checks"`
+}
@@ -0,0 +1,55 @@
+package types
+
+// ResourceOverride replaces a container's CPU and memory requests and limits.
+// Keys are "cpu" and "memory", values are Kubernetes quantities such as "500m".
+type ResourceOverride struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
+
+// ResourcePreviewRequest holds the overrides to preview, by container name
+type ResourcePreviewRequest struct {
+	Overrides map[string]ResourceOverride `json:"overrides,omitempty"`
+}
+
+// ResourceAmounts is an amount of CPU (cores) and memory (bytes)
+type ResourceAmounts struct {
+	CPU    float64 `json:"cpu"`
+	Memory int64   `json:"memory"`
+}
+
+// ResourceDiff compares current amounts with the proposed ones
+type ResourceDiff struct {
+	Current  ResourceAmounts `json:"current"`
+	Proposed ResourceAmounts `json:"proposed"`
+	Delta    ResourceAmounts `json:"delta"` // proposed minus current
+}
+
+// ContainerResourcePreview is the requests and limits diff of one container.
+// Containers the migration would skip are proposed at zero.
+type ContainerResourcePreview struct {
+	Name       string       `json:"name"`
+	Migrated   bool         `json:"migrated"`
+	Reason     string       `json:"reason"`
+	Overridden bool         `json:"overridden"`
+	Requests   ResourceDiff `json:"requests"`
+	Limits     ResourceDiff `json:"limits"`
+}
+
+// ResourcePreview shows what the optimized pod would reserve compared with the
+// current pod, without changing anything
+type ResourcePreview struct {
+	PodName       string                     `json:"pod_name"`
+	PodNamespace  string                     `json:"pod_namespace"`
+	Containers    []ContainerResourcePreview `json:"containers"`
+	TotalRequests ResourceDiff               `json:"total_requests"`
+	TotalLimits   ResourceDiff               `json:"total_limits"`
+
+	// Reduction of the pod's requests, in percent of the current requests
+	RequestCPUSavings    float64 `json:"request_cpu_savings_percentage"`
+	RequestMemorySavings float64 `json:"request_memory_savings_percentage"`
+
+	// Measured usage and the usage projected after migration, when metrics are available
+	CurrentUsage   *ResourceUsage `json:"current_usage,omitempty"`
+	ProjectedUsage *ResourceUsage `json:"projected_usage,omitempty"`
+}
@@ -0,0 +1,53 @@
+package types
+
+import "time"
+
+// RecommendationStatus tells whether a recommendation has been acted on
+type RecommendationStatus string
+
+const (
+	RecommendationOpen     RecommendationStatus = "open"
+	RecommendationPromoted RecommendationStatus = "promoted"
+)
+
+// Recommendation is a migration the pressure observer would have started: the
+// heaviest pod of a node under pressure, moved to the node with the most headroom
+type Recommendation struct {
+	ID           string               `json:"id"`
+	Status       RecommendationStatus `json:"status"`
+	PodName      string               `json:"pod_name"`
+	PodNamespace string               `json:"pod_namespace"`
+	SourceNode   string               `json:"source_node"`
+	TargetNode   string               `json:"target_node"`
+	Reason       string               `json:"reason"`
+
+	// First and latest scan that recommended this migration
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+
+	SourceUtilization *NodeUtilization `json:"source_utilization,omitempty"`
+	TargetUtilization *NodeUtilization `json:"target_utilization,omitempty"`
+
+	// Measured usage of the pod and the usage projected after migration
+	PodUsage               *ResourceUsage `json:"pod_usage,omitempty"`
+	ProjectedUsage         *ResourceUsage `json:"projected_usage,omitempty"`
+	ProjectedCPUSavings    float64        `json:"projected_cpu_savings_percentage"`
+	ProjectedMemorySavings float64        `json:"projected_memory_savings_percentage"`
+
+	// Migration started from this recommendation
+	MigrationID string `json:"migration_id,omitempty"`
+}
+
+// RecommendationList is the response of GET /api/v1/recommendations
+type RecommendationList struct {
+	Recommendations []Recommendation `json:"recommendations"`
+	// Zero when the observer is disabled
+	ScanInterval time.Duration `json:"scan_interval"`
+	LastScan     *time.Time    `json:"last_scan,omitempty"`
+}
+
+// PromoteRecommendationRequest starts the migration of a recommendation with the
+// given options
+type PromoteRecommendationRequest struct {
+	MigrationOptions
+}
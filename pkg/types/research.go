@@ -0,0 +1,98 @@
+package types
+
+import "time"
+
+// ResearchRecordSchema versions the layout of research records. Fields may be added
+// within a version; renaming, removing or changing the meaning of one bumps it.
+const ResearchRecordSchema = "migration-rationale/v1"
+
+// ResearchRecord is the full rationale of one finished migration, written as a JSON
+// document to the results directory for offline analysis. Its own durations are in
+// milliseconds, and resources in CPU cores and bytes.
+type ResearchRecord struct {
+	Schema       string          `json:"schema"`
+	MigrationID  string          `json:"migration_id"`
+	RequestID    string          `json:"request_id,omitempty"`
+	PodName      string          `json:"pod_name"`
+	PodNamespace string          `json:"pod_namespace"`
+	NewPodName   string          `json:"new_pod_name,omitempty"`
+	Status       MigrationStatus `json:"status"`
+	ExportedAt   time.Time       `json:"exported_at"`
+
+	Classification ResearchClassification `json:"classification"`
+	Resources      ResearchResources      `json:"resources"`
+	NodeSelection  ResearchNodeSelection  `json:"node_selection"`
+	Timing         ResearchTiming         `json:"timing"`
+
+	Failure *FailureDetail `json:"failure,omitempty"`
+}
+
+// ResearchClassification is step 1: which containers were migrated and why
+type ResearchClassification struct {
+	Policy             ClassificationPolicy `json:"policy"`
+	Containers         []ContainerState     `json:"containers"`
+	ContainersTotal    int                  `json:"containers_total"`
+	ContainersMigrated int                  `json:"containers_migrated"`
+	// Decisions of the restart count policy and the requested container groups
+	RestartDecisions  []string                 `json:"restart_decisions,omitempty"`
+	GroupingDecisions []ContainerGroupDecision `json:"grouping_decisions,omitempty"`
+}
+
+// ResearchResources is the usage of the pod before and after the migration and how
+// it was measured
+type ResearchResources struct {
+	Original      *ResourceUsage `json:"original,omitempty"`
+	Optimized     *ResourceUsage `json:"optimized,omitempty"`
+	CPUSavings    *float64       `json:"cpu_savings_percentage,omitempty"`
+	MemorySavings *float64       `json:"memory_savings_percentage,omitempty"`
+	GPUSavings    *float64       `json:"gpu_savings_percentage,omitempty"`
+	// Usage of the new pod sampled after completion, when sample_usage was requested
+	Samples     []ResourceUsage     `json:"samples,omitempty"`
+	Methodology SamplingMethodology `json:"methodology"`
+}
+
+// SamplingMethodology describes how the resource usage of a migration was measured
+type SamplingMethodology struct {
+	// Metrics provider the readings came from: metrics-server or prometheus
+	Source string `json:"source"`
+	// Original usage is one reading of the running pod before the checkpoint; optimized
+	// usage one reading of the new pod after the stabilization delay
+	OriginalReading      string `json:"original_reading"`
+	OptimizedReading     string `json:"optimized_reading"`
+	StabilizationDelayMs int64  `json:"stabilization_delay_ms"`
+	SampleIntervalMs     int64  `json:"sample_interval_ms,omitempty"`
+	SampleDurationMs     int64  `json:"sample_duration_ms,omitempty"`
+}
+
+// ResearchNodeSelection is step 2: how the target node was chosen and how the move
+// affected the source and target nodes
+type ResearchNodeSelection struct {
+	SourceNode string `json:"source_node"`
+	TargetNode string `json:"target_node"`
+	// Set when the target node was picked by target_node_selector, with the scores of
+	// the candidates; nil when target_node named it
+	Selection    *NodeSelection    `json:"selection,omitempty"`
+	Reselections []NodeReselection `json:"reselections,omitempty"`
+
+	TargetNodeUtilization *NodeUtilization  `json:"target_node_utilization,omitempty"`
+	SourceNodeRelief      *SourceNodeRelief `json:"source_node_relief,omitempty"`
+}
+
+// ResearchTiming is step 3: where the time of the migration went
+type ResearchTiming struct {
+	StartTime           time.Time          `json:"start_time"`
+	EndTime             *time.Time         `json:"end_time,omitempty"`
+	DurationMs          int64              `json:"duration_ms"`
+	Steps               []ResearchStepTime `json:"steps"`
+	TimeToReadyMs       *int64             `json:"time_to_ready_ms,omitempty"`
+	SchedulingLatencyMs *int64             `json:"scheduling_latency_ms,omitempty"`
+	StartupLatencyMs    *int64             `json:"startup_latency_ms,omitempty"`
+	FailedStep          string             `json:"failed_step,omitempty"`
+}
+
+// ResearchStepTime is the time one finished step took
+type ResearchStepTime struct {
+	Step       string    `json:"step"`
+	StartTime  time.Time `json:"start_time"`
+	DurationMs int64     `json:"duration_ms"`
+}
@@ -0,0 +1,52 @@
+package types
+
+import "time"
+
+// MigrationSummary is the final record of a migration, assembled once when it
+// reaches a terminal status and never modified afterwards. A completed migration
+// gets a fresh summary when its post-migration metrics arrive.
+type MigrationSummary struct {
+	MigrationID  string          `json:"migration_id"`
+	RequestID    string          `json:"request_id,omitempty"`
+	PodName      string          `json:"pod_name"`
+	PodNamespace string          `json:"pod_namespace"`
+	NewPodName   string          `json:"new_pod_name,omitempty"`
+	SourceNode   string          `json:"source_node"`
+	TargetNode   string          `json:"target_node"`
+	Status       MigrationStatus `json:"status"`
+
+	StartTime time.Time     `json:"start_time"`
+	EndTime   time.Time     `json:"end_time"`
+	Duration  time.Duration `json:"duration"`
+	// Time spent in each finished step, plus the readiness and startup latencies of the new pod
+	Steps             []StepTiming   `json:"steps,omitempty"`
+	TimeToReady       *time.Duration `json:"time_to_ready,omitempty"`
+	SchedulingLatency *time.Duration `json:"scheduling_latency,omitempty"`
+	StartupLatency    *time.Duration `json:"startup_latency,omitempty"`
+	// Step the migration was in when it failed
+	FailedStep string `json:"failed_step,omitempty"`
+
+	ContainersTotal    int `json:"containers_total"`
+	ContainersMigrated int `json:"containers_migrated"`
+	// Migrated containers that never became ready in a partially_degraded migration
+	DegradedContainers []string `json:"degraded_containers,omitempty"`
+	CheckpointPVC      string   `json:"checkpoint_pvc,omitempty"`
+
+	OriginalResources  *ResourceUsage `json:"original_resources,omitempty"`
+	OptimizedResources *ResourceUsage `json:"optimized_resources,omitempty"`
+	// Savings in percent of the original usage; nil until both measurements exist
+	CPUSavings    *float64 `json:"cpu_savings_percentage,omitempty"`
+	MemorySavings *float64 `json:"memory_savings_percentage,omitempty"`
+	GPUSavings    *float64 `json:"gpu_savings_percentage,omitempty"`
+	// False while the post-migration metrics of a completed migration are outstanding
+	MetricsFinal bool `json:"metrics_final"`
+
+	// Kubernetes objects the migration created, and those removed again by rollback,
+	// as kind/name
+	ResourcesCreated   []string `json:"resources_created,omitempty"`
+	ResourcesCleanedUp []string `json:"resources_cleaned_up,omitempty"`
+
+	Errors []string `json:"errors,omitempty"`
+	// Classified failure with a remediation hint
+	Failure *FailureDetail `json:"failure,omitempty"`
+}
@@ -0,0 +1,44 @@
+package types
+
+import "time"
+
+// WebhookSubscriber receives the terminal result of every migration matching its filters
+type WebhookSubscriber struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Key for the HMAC-SHA256 payload signature; redacted when subscribers are listed
+	Secret string `json:"secret,omitempty"`
+
+	// Filters; empty means all namespaces or all statuses
+	Namespaces []string          `json:"namespaces,omitempty"`
+	Statuses   []MigrationStatus `json:"statuses,omitempty"`
+}
+
+// Matches reports whether an event for the given namespace and status passes the filters
+func (s WebhookSubscriber) Matches(namespace string, status MigrationStatus) bool {
+	return containsOrEmpty(s.Namespaces, namespace) && containsOrEmpty(s.Statuses, status)
+}
+
+func containsOrEmpty[T comparable](values []T, value T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent is the payload delivered to webhook subscribers
+type WebhookEvent struct {
+	Event       string             `json:"event"`
+	Timestamp   time.Time          `json:"timestamp"`
+	MigrationID string             `json:"migration_id"`
+	RequestID   string             `json:"request_id,omitempty"`
+	Namespace   string             `json:"namespace"`
+	PodName     string             `json:"pod_name"`
+	Status      MigrationStatus    `json:"status"`
+	Migration   *MigrationResponse `json:"migration"`
+}
@@ -0,0 +1,69 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Build information, injected at build time with
+//
+//	go build -ldflags "-X ai-storage-orchestrator/pkg/version.Version=v1.2.3 \
+//	  -X ai-storage-orchestrator/pkg/version.GitCommit=$(git rev-parse HEAD) \
+//	  -X ai-storage-orchestrator/pkg/version.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "1.0.0"
+	GitCommit = "unknown"
+	BuildDate = "unknown"
+)
+
+// clientGoModule is the module whose version determines the supported Kubernetes API
+const clientGoModule = "k8s.io/client-go"
+
+// Info describes the running orchestrator build
+type Info struct {
+	Version           string `json:"version"`
+	GitCommit         string `json:"git_commit"`
+	BuildDate         string `json:"build_date"`
+	GoVersion         string `json:"go_version"`
+	Platform          string `json:"platform"`
+	KubernetesVersion string `json:"kubernetes_api_version"` // client-go version the binary was built against
+}
+
+// Get returns the build information. The git commit falls back to the VCS revision
+// recorded by the Go toolchain when it was not injected.
+func Get() Info {
+	info := Info{
+		Version:           Version,
+		GitCommit:         GitCommit,
+		BuildDate:         BuildDate,
+		GoVersion:         runtime.Version(),
+		Platform:          runtime.GOOS + "/" + runtime.GOARCH,
+		KubernetesVersion: "unknown",
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path == clientGoModule {
+			info.KubernetesVersion = dep.Version
+			break
+		}
+	}
+	if info.GitCommit == "unknown" {
+		for _, setting := range buildInfo.Settings {
+			if setting.Key == "vcs.revision" {
+				info.GitCommit = setting.Value
+				break
+			}
+		}
+	}
+	return info
+}
+
+// String returns a one-line summary suitable for logs
+func (i Info) String() string {
+	return "version=" + i.Version + " commit=" + i.GitCommit + " built=" + i.BuildDate +
+		" go=" + i.GoVersion + " platform=" + i.Platform + " client-go=" + i.KubernetesVersion
+}
@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the payload, keyed by the subscriber secret
+	SignatureHeader = "X-Orchestrator-Signature"
+	// EventHeader names the event type of the payload
+	EventHeader = "X-Orchestrator-Event"
+
+	maxAttempts        = 5
+	initialBackoff     = time.Second
+	maxBackoff         = 30 * time.Second
+	deliveryTimeout    = 10 * time.Second
+	maxConcurrentSends = 20
+)
+
+// Dispatcher fans migration events out to the configured subscribers. Subscribers
+// can be replaced at any time; deliveries already in flight finish with the old set.
+type Dispatcher struct {
+	mu          sync.RWMutex
+	subscribers []types.WebhookSubscriber
+	client      *http.Client
+	sends       chan struct{}   // bounds concurrent deliveries
+	closed      context.Context // done once Close is called, ending retries
+	close       context.CancelFunc
+}
+
+// NewDispatcher creates a dispatcher without subscribers
+func NewDispatcher() *Dispatcher {
+	d := &Dispatcher{
+		client: &http.Client{Timeout: deliveryTimeout},
+		sends:  make(chan struct{}, maxConcurrentSends),
+	}
+	d.closed, d.close = context.WithCancel(context.Background())
+	return d
+}
+
+// Close gives up the retries of deliveries in flight. Events dispatched afterwards
+// are attempted once.
+func (d *Dispatcher) Close() {
+	d.close()
+}
+
+// ValidateSubscribers checks names are unique and URLs are absolute http(s) URLs
+func ValidateSubscribers(subscribers []types.WebhookSubscriber) error {
+	names := make(map[string]bool, len(subscribers))
+	for _, subscriber := range subscribers {
+		if subscriber.Name == "" {
+			return fmt.Errorf("webhook subscriber name is required")
+		}
+		if names[subscriber.Name] {
+			return fmt.Errorf("duplicate webhook subscriber %s", subscriber.Name)
+		}
+		names[subscriber.Name] = true
+
+		parsed, err := url.Parse(subscriber.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("webhook subscriber %s: url must be an absolute http or https URL", subscriber.Name)
+		}
+	}
+	return nil
+}
+
+// SetSubscribers validates and replaces the subscriber list
+func (d *Dispatcher) SetSubscribers(subscribers []types.WebhookSubscriber) error {
+	if err := ValidateSubscribers(subscribers); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	d.subscribers = append([]types.WebhookSubscriber(nil), subscribers...)
+	d.mu.Unlock()
+	return nil
+}
+
+// Subscribers returns the configured subscribers with their secrets redacted
+func (d *Dispatcher) Subscribers() []types.WebhookSubscriber {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	redacted := make([]types.WebhookSubscriber, len(d.subscribers))
+	for i, subscriber := range d.subscribers {
+		redacted[i] = subscriber
+		if subscriber.Secret != "" {
+			redacted[i].Secret = "********"
+		}
+	}
+	return redacted
+}
+
+// Dispatch delivers an event in the background to every subscriber whose filters match
+func (d *Dispatcher) Dispatch(event *types.WebhookEvent) {
+	d.mu.RLock()
+	var matching []types.WebhookSubscriber
+	for _, subscriber := range d.subscribers {
+		if subscriber.Matches(event.Namespace, event.Status) {
+			matching = append(matching, subscriber)
+		}
+	}
+	d.mu.RUnlock()
+	if len(matching) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Webhook: failed to encode %s event for %s: %v", event.Event, event.MigrationID, err)
+		return
+	}
+
+	for _, subscriber := range matching {
+		go d.deliver(subscriber, event, payload)
+	}
+}
+
+// deliver posts the payload to one subscriber, retrying with exponential backoff
+func (d *Dispatcher) deliver(subscriber types.WebhookSubscriber, event *types.WebhookEvent, payload []byte) {
+	d.sends <- struct{}{}
+	defer func() { <-d.sends }()
+
+	backoff := initialBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err := d.send(subscriber, event, payload)
+		if err == nil {
+			return
+		}
+		if attempt == maxAttempts {
+			log.Printf("Webhook %s: giving up on %s event for %s after %d attempts: %v",
+				subscriber.Name, event.Event, event.MigrationID, attempt, err)
+			return
+		}
+		log.Printf("Webhook %s: attempt %d for %s failed, retrying in %s: %v",
+			subscriber.Name, attempt, event.MigrationID, backoff, err)
+		select {
+		case <-d.closed.Done():
+			log.Printf("Webhook %s: giving up on %s event for %s at shutdown after %d attempts: %v",
+				subscriber.Name, event.Event, event.MigrationID, attempt, err)
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (d *Dispatcher) send(subscriber types.WebhookSubscriber, event *types.WebhookEvent, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscriber.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Event)
+	if event.RequestID != "" {
+		req.Header.Set("X-Request-ID", event.RequestID)
+	}
+	if subscriber.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(subscriber.Secret, payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of payload keyed by secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/types"
+)
+
+// A closed dispatcher gives up on a failing subscriber instead of sleeping out its
+// backoff
+func TestCloseEndsRetries(t *testing.T) {
+	var attempts atomic.Int32
+	failed := make(chan struct{}, maxAttempts)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		failed <- struct{}{}
+	}))
+	defer server.Close()
+
+	d := NewDispatcher()
+	if err := d.SetSubscribers([]types.WebhookSubscriber{{Name: "ops", URL: server.URL}}); err != nil {
+		t.Fatal(err)
+	}
+	d.Dispatch(&types.WebhookEvent{Event: "migration.failed", MigrationID: "m-1", Status: types.MigrationStatusFailed})
+	<-failed
+	d.Close()
+
+	// The delivery releases its send slot once it gives up, well before the backoff ends
+	deadline := time.Now().Add(initialBackoff / 2)
+	for len(d.sends) > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("delivery still retrying after Close")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
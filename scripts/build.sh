@@ -53,7 +53,9 @@ echo -e "${GREEN}✓ Dependencies downloaded${NC}"
 
 # Build the binary
 echo "Building Go binary..."
-CGO_ENABLED=0 GOOS=linux go build -a -installsuffix cgo -ldflags "-w -s" -o main ./cmd/main.go
+VERSION_PKG=ai-storage-orchestrator/pkg/version
+LDFLAGS="-w -s -X ${VERSION_PKG}.Version=${TAG} -X ${VERSION_PKG}.GitCommit=$(git rev-parse HEAD 2>/dev/null || echo unknown) -X ${VERSION_PKG}.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+CGO_ENABLED=0 GOOS=linux go build -a -installsuffix cgo -ldflags "${LDFLAGS}" -o main ./cmd/main.go
 
 if [ $? -ne 0 ]; then
     echo -e "${RED}Failed to build Go binary${NC}"
@@ -64,7 +66,10 @@ echo -e "${GREEN}✓ Go binary built successfully${NC}"
 
 # Build Docker image
 echo "Building Docker image..."
-docker build -t ${IMAGE_NAME}:${TAG} .
+docker build -t ${IMAGE_NAME}:${TAG} \
+    --build-arg VERSION=${TAG} \
+    --build-arg GIT_COMMIT=$(git rev-parse HEAD 2>/dev/null || echo unknown) \
+    --build-arg BUILD_DATE=$(date -u +%Y-%m-%dT%H:%M:%SZ) .
 
 if [ $? -ne 0 ]; then
     echo -e "${RED}Failed to build Docker image${NC}"
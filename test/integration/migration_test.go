@@ -0,0 +1,250 @@
+//go:build integration
+
+// Package integration runs migrations against a real API server started by envtest.
+// It checks the client interactions a fake clientset accepts without complaint:
+// admission of the objects the orchestrator builds, status subresources, and
+// graceful pod deletion. No kubelet or scheduler runs, so the tests stand in for
+// the kubelet by reporting pod status themselves.
+//
+// Run with `make test-integration`, which installs the envtest binaries first. The
+// tests skip when KUBEBUILDER_ASSETS does not point at kube-apiserver and etcd.
+package integration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ai-storage-orchestrator/pkg/controller"
+	"ai-storage-orchestrator/pkg/k8s"
+	"ai-storage-orchestrator/pkg/metrics"
+	"ai-storage-orchestrator/pkg/sink"
+	"ai-storage-orchestrator/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+const namespace = "default"
+
+// startAPIServer starts kube-apiserver and etcd for the test, or skips it when the
+// envtest binaries are not installed
+func startAPIServer(t *testing.T) (*k8s.Client, kubernetes.Interface) {
+	t.Helper()
+	assets := os.Getenv("KUBEBUILDER_ASSETS")
+	if assets == "" {
+		t.Skip("KUBEBUILDER_ASSETS not set; run `make test-integration`")
+	}
+	for _, binary := range []string{"kube-apiserver", "etcd"} {
+		if _, err := os.Stat(filepath.Join(assets, binary)); err != nil {
+			t.Skipf("envtest binary %s not found in %s; run `make test-integration`", binary, assets)
+		}
+	}
+
+	env := &envtest.Environment{}
+	config, err := env.Start()
+	if err != nil {
+		t.Fatalf("start envtest: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("stop envtest: %v", err)
+		}
+	})
+
+	client, err := k8s.NewClientForConfig(config)
+	if err != nil {
+		t.Fatalf("NewClientForConfig: %v", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("kubernetes.NewForConfig: %v", err)
+	}
+	return client, clientset
+}
+
+// createNode registers a ready node, as its kubelet would
+func createNode(t *testing.T, clientset kubernetes.Interface, name string) {
+	t.Helper()
+	ctx := context.Background()
+	node, err := clientset.CoreV1().Nodes().Create(ctx, &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{
+			corev1.LabelHostname:   name,
+			corev1.LabelArchStable: "amd64",
+			corev1.LabelOSStable:   "linux",
+		}},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("create node %s: %v", name, err)
+	}
+	node.Status = corev1.NodeStatus{
+		Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		Capacity: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("8"),
+			corev1.ResourceMemory: resource.MustParse("32Gi"),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		},
+		Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("8"),
+			corev1.ResourceMemory: resource.MustParse("32Gi"),
+			corev1.ResourcePods:   resource.MustParse("110"),
+		},
+	}
+	if _, err := clientset.CoreV1().Nodes().UpdateStatus(ctx, node, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("update status of node %s: %v", name, err)
+	}
+}
+
+// reportRunning sets the status the kubelet would report for a pod whose
+// containers all started
+func reportRunning(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod) error {
+	now := metav1.Now()
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.StartTime = &now
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: now},
+		{Type: corev1.ContainersReady, Status: corev1.ConditionTrue, LastTransitionTime: now},
+		{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: now},
+	}
+	pod.Status.ContainerStatuses = nil
+	for _, container := range pod.Spec.Containers {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:    container.Name,
+			Image:   container.Image,
+			ImageID: container.Image,
+			Ready:   true,
+			Started: boolPtr(true),
+			State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: now}},
+		})
+	}
+	_, err := clientset.CoreV1().Pods(pod.Namespace).UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	return err
+}
+
+// actAsKubelet reports every pod other than skip that appears on node as running,
+// until ctx ends
+func actAsKubelet(ctx context.Context, clientset kubernetes.Interface, node, skip string) {
+	_ = wait.PollUntilContextCancel(ctx, 50*time.Millisecond, true, func(ctx context.Context) (bool, error) {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, nil
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Name == skip || pod.Spec.NodeName != node || pod.Status.Phase == corev1.PodRunning {
+				continue
+			}
+			_ = reportRunning(ctx, clientset, pod)
+		}
+		return false, nil
+	})
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestMigrationAgainstAPIServer(t *testing.T) {
+	client, clientset := startAPIServer(t)
+	ctx := context.Background()
+	createNode(t, clientset, "node-a")
+	createNode(t, clientset, "node-b")
+
+	pod, err := clientset.CoreV1().Pods(namespace).Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "trainer", Namespace: namespace, Labels: map[string]string{"app": "trainer"}},
+		Spec: corev1.PodSpec{
+			NodeName: "node-a",
+			Containers: []corev1.Container{
+				{Name: "model", Image: "trainer:1.0"},
+				{Name: "sidecar", Image: "sidecar:1.0"},
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("create pod: %v", err)
+	}
+	if err := reportRunning(ctx, clientset, pod); err != nil {
+		t.Fatalf("report pod running: %v", err)
+	}
+
+	kubeletCtx, stopKubelet := context.WithCancel(ctx)
+	defer stopKubelet()
+	go actAsKubelet(kubeletCtx, clientset, "node-b", "")
+
+	config := controller.DefaultMigrationConfig()
+	config.SafeMode = false
+	config.PodReadyPollInterval = 100 * time.Millisecond
+	config.MetricsStabilizationDelay = 0
+	mc, err := controller.NewMigrationController(client, metrics.NewMetricsServerProvider(client), sink.NoopSink{}, config)
+	if err != nil {
+		t.Fatalf("NewMigrationController: %v", err)
+	}
+
+	started, err := mc.StartMigration(&types.MigrationRequest{
+		PodName:      "trainer",
+		PodNamespace: namespace,
+		SourceNode:   "node-a",
+		TargetNode:   "node-b",
+		MigrationOptions: types.MigrationOptions{
+			PreservePV: true,
+			Timeout:    60,
+		},
+	})
+	if err != nil {
+		t.Fatalf("StartMigration: %v", err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 90*time.Second)
+	defer cancel()
+	response, finished, err := mc.WaitForMigration(waitCtx, started.MigrationID)
+	if err != nil {
+		t.Fatalf("WaitForMigration: %v", err)
+	}
+	if !finished {
+		t.Fatalf("migration did not finish, status %s", response.Status)
+	}
+	if response.Status != types.MigrationStatusCompleted {
+		t.Fatalf("status = %s (%s), want completed", response.Status, response.Message)
+	}
+
+	details := response.Details
+	newPod, err := clientset.CoreV1().Pods(namespace).Get(ctx, details.NewPodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get new pod %s: %v", details.NewPodName, err)
+	}
+	if newPod.Spec.NodeName != "node-b" {
+		t.Errorf("new pod on node %q, want node-b", newPod.Spec.NodeName)
+	}
+	if details.PVClaimName == "" {
+		t.Fatal("no checkpoint PVC recorded")
+	}
+	if _, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, details.PVClaimName, metav1.GetOptions{}); err != nil {
+		t.Errorf("get checkpoint PVC %s: %v", details.PVClaimName, err)
+	}
+	mounted := false
+	for _, volume := range newPod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == details.PVClaimName {
+			mounted = true
+		}
+	}
+	if !mounted {
+		t.Errorf("new pod does not mount checkpoint PVC %s", details.PVClaimName)
+	}
+
+	// Without a kubelet to confirm the termination, a gracefully deleted pod stays
+	// in the API marked for deletion
+	original, err := clientset.CoreV1().Pods(namespace).Get(ctx, "trainer", metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		t.Fatalf("get original pod: %v", err)
+	case original.DeletionTimestamp == nil:
+		t.Error("original pod not deleted")
+	}
+}